@@ -0,0 +1,435 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type actionChoice struct {
+	config            *Config
+	actions           []string
+	cursor            int
+	selectedAction    string
+	selectedContainer Container
+
+	// all holds every action while typing filters actions down to the
+	// ones matching query.
+	all   []string
+	query string
+}
+
+func initialActionModel(cfg *Config, container Container) actionChoice {
+	ids := []string{
+		"exit",
+		"start",
+		"stop",
+		"restart",
+		"pause",
+		"logs",
+		"shell",
+		"browseFiles",
+		"debugDns",
+		"testConnectivity",
+		"probeHttp",
+		"showTraffic",
+		"checkClock",
+		"checkEnvironment",
+		"copyId",
+		"showProvenance",
+		"editNote",
+		"editLabels",
+		"editCommand",
+		"rm",
+	}
+	if container.State == "paused" {
+		ids[4] = "unpause"
+	}
+	if len(publishedTCPPorts(container.Ports)) == 0 {
+		ids = append(ids, "forwardPort")
+	}
+	if cfg.HostsFile.Enabled {
+		if hasHostsEntry(cfg, container) {
+			ids = append(ids, "removeHostsEntry")
+		} else {
+			ids = append(ids, "addHostsEntry")
+		}
+	}
+	if container.Healthcheck {
+		ids = append(ids, "healthcheck", "wait")
+	}
+	if isDevcontainer(container) {
+		ids = append(ids, "devShell", "devPorts")
+	}
+	if isRescued(container) {
+		ids = append(ids, "endRescue")
+	} else if container.CrashLoop || container.failed() {
+		ids = append(ids, "rescue")
+	}
+	if len(macroNames(cfg)) > 0 {
+		ids = append(ids, "playMacro")
+	}
+	ids = append(ids, containerPackActions(cfg, container)...)
+	actions := allowedActions(cfg, ids)
+	if cfg.Ui.HideUnavailableActions {
+		var available []string
+		for _, action := range actions {
+			if actionUnavailable(action, container) == "" {
+				available = append(available, action)
+			}
+		}
+		actions = available
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actionGroup(actions[i]) < actionGroup(actions[j])
+	})
+
+	// Start on the first safe action rather than exit or a destructive one.
+	cursor := 0
+	for i, action := range actions {
+		if action != "exit" && !isDestructive(action) {
+			cursor = i
+			break
+		}
+	}
+	if cfg.Ui.RememberActions {
+		last := loadState().ContainerActions[container.Name]
+		for i, action := range actions {
+			if action == last {
+				cursor = i
+			}
+		}
+	}
+
+	return actionChoice{
+		config:            cfg,
+		actions:           actions,
+		cursor:            cursor,
+		selectedAction:    "",
+		selectedContainer: container,
+		all:               actions,
+	}
+}
+
+// filter keeps the actions whose label or name contains the query.
+func (menu *actionChoice) filter() {
+	query := strings.ToLower(menu.query)
+	menu.actions = nil
+	for _, action := range menu.all {
+		if strings.Contains(strings.ToLower(actionLabel(menu.config, action)), query) || strings.Contains(strings.ToLower(action), query) {
+			menu.actions = append(menu.actions, action)
+		}
+	}
+	menu.cursor = 0
+}
+
+func (menu actionChoice) Init() tea.Cmd {
+	return nil
+}
+
+func (menu actionChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return menu, tea.Quit
+		case "esc":
+			if menu.query == "" {
+				return menu, tea.Quit
+			}
+			menu.query = ""
+			menu.filter()
+		case "backspace":
+			if runes := []rune(menu.query); len(runes) > 0 {
+				menu.query = string(runes[:len(runes)-1])
+				menu.filter()
+			}
+		case "up":
+			menu.cursor--
+			if menu.cursor < 0 {
+				menu.cursor = len(menu.actions) - 1
+			}
+		case "down":
+			menu.cursor++
+			if menu.cursor >= len(menu.actions) {
+				menu.cursor = 0
+			}
+		case "enter":
+			if len(menu.actions) == 0 {
+				break
+			}
+			if actionUnavailable(menu.actions[menu.cursor], menu.selectedContainer) != "" {
+				break
+			}
+			menu.selectedAction = menu.actions[menu.cursor]
+			return menu, tea.Quit
+		default:
+			// q still leaves the menu until something is typed.
+			if msg.String() == "q" && menu.query == "" {
+				return menu, tea.Quit
+			}
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				menu.query += string(msg.Runes)
+				menu.filter()
+			}
+		}
+	}
+
+	return menu, nil
+}
+
+func (menu actionChoice) View() string {
+	s := renderHeader(menu.config)
+	s += tr("actions.title", menu.selectedContainer.Name) + "\n"
+	if container := menu.selectedContainer; container.isExited() {
+		line := tr("actions.exited", formatExit(container))
+		if container.failed() {
+			line = renderColor(line, menu.config.Ui.ExitErrorColor)
+		}
+		s += line + "\n"
+	}
+	s += "\n"
+	if menu.query != "" {
+		s += tr("actions.filter", menu.query) + "\n"
+		if len(menu.actions) == 0 {
+			s += renderColor(tr("actions.noMatch"), "2") + "\n"
+		}
+	}
+
+	b := newFrame()
+	b.WriteString(s)
+	group := 0
+	for i, action := range menu.actions {
+		if g := actionGroup(action); g != group {
+			group = g
+			b.WriteByte('\n')
+			b.colored(tr("actions.group."+actionGroups[g-1].name), "2")
+			b.WriteByte('\n')
+		}
+
+		cursor := " "
+		if menu.cursor == i {
+			cursor = renderCursor(menu.config)
+		}
+		b.WriteString(cursor + " ")
+
+		switch reason := actionUnavailable(action, menu.selectedContainer); {
+		case reason != "":
+			b.colored(actionLabel(menu.config, action)+" — "+reason, "2")
+		case isDestructive(action) && menu.cursor != i:
+			b.colored(actionLabel(menu.config, action), "31")
+		default:
+			b.WriteString(renderActionSelected(menu.config, actionLabel(menu.config, action), menu.cursor == i))
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.done()
+}
+
+// actionGroups are the sections of the action menu, in order. Actions in
+// none of them, like exit, come first without a heading.
+var actionGroups = []struct {
+	name    string
+	actions []string
+}{
+	{"lifecycle", []string{"start", "stop", "restart", "pause", "unpause", "wait", "playMacro"}},
+	{"inspect", []string{"logs", "healthcheck", "devPorts", "checkEnvironment", "copyId", "showProvenance", "editNote"}},
+	{"files", []string{"shell", "browseFiles", "devShell"}},
+	{"network", []string{"debugDns", "testConnectivity", "probeHttp", "forwardPort", "addHostsEntry", "removeHostsEntry", "showTraffic", "checkClock"}},
+	{"packs", nil},
+	{"danger", []string{"rescue", "endRescue", "editLabels", "editCommand", "kill", "rm"}},
+}
+
+// actionGroup returns the 1-based section of an action, 0 for none.
+func actionGroup(action string) int {
+	for i, group := range actionGroups {
+		if containsString(group.actions, action) || group.name == "packs" && isPackAction(action) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isDestructive reports the actions of the danger zone, which lose data or
+// the container itself.
+func isDestructive(action string) bool {
+	return actionGroup(action) == len(actionGroups)
+}
+
+// actionUnavailable explains why an action makes no sense in the current
+// state of the container, or returns "" when it can run.
+func actionUnavailable(action string, container Container) string {
+	if isPackAction(action) {
+		action = "shell"
+	}
+	switch canonicalAction(action) {
+	case "start":
+		if container.State == "running" {
+			return tr("hint.alreadyRunning")
+		}
+		if container.State == "paused" {
+			return tr("hint.paused")
+		}
+	case "stop", "kill", "wait", "devShell", "shell", "browseFiles", "debugDns", "testConnectivity", "probeHttp", "forwardPort", "addHostsEntry", "showTraffic", "checkClock":
+		if container.State == "paused" {
+			return tr("hint.paused")
+		}
+		if container.State != "running" && container.State != "restarting" {
+			return tr("hint.notRunning")
+		}
+	case "pause":
+		if container.State == "paused" {
+			return tr("hint.alreadyPaused")
+		}
+		if container.State != "running" {
+			return tr("hint.notRunning")
+		}
+	case "unpause":
+		if container.State != "paused" {
+			return tr("hint.notPaused")
+		}
+	case "restart":
+		if container.State == "paused" {
+			return tr("hint.paused")
+		}
+	case "rm":
+		if container.State == "running" || container.State == "paused" || container.State == "restarting" {
+			return tr("hint.stopFirst")
+		}
+	}
+	return ""
+}
+
+// actionLabel returns the translated menu entry for an action identifier.
+func actionLabel(cfg *Config, action string) string {
+	if isPackAction(action) {
+		return packActionLabel(cfg, action)
+	}
+	return tr("action." + action)
+}
+
+func renderActionSelected(cfg *Config, action string, isSelected bool) string {
+	if isSelected {
+		return fmt.Sprintf("\033[%sm%s\033[0m", paletteColor(cfg.Ui.ActionSelectedColor), action)
+	}
+	return action
+}
+
+func chooseAction(cfg *Config, container Container) (string, error) {
+	if plainMode {
+		return chooseActionPlain(cfg, container)
+	}
+
+	finalModel, err := runProgram(initialActionModel(cfg, container))
+	if err != nil {
+		return "", err
+	}
+
+	actionMenu := finalModel.(actionChoice)
+	return actionMenu.selectedAction, nil
+}
+
+func doAction(cfg *Config, action string, container Container) error {
+	switch action {
+	case "exit":
+		os.Exit(0)
+	case "copyId":
+		containerID := container.ID
+		err := copyContainerId(containerID)
+		if err != nil {
+			println(err)
+			os.Exit(1)
+		}
+	case "showProvenance":
+		return showProvenance(container.Image)
+	case "checkEnvironment":
+		return showSanity(container)
+	case "editNote":
+		return editNote(cfg, container)
+	case "playMacro":
+		return chooseMacro(cfg, container)
+	case "editLabels":
+		return editLabels(cfg, container)
+	case "editCommand":
+		return editCommand(cfg, container)
+	case "rescue":
+		return rescueContainer(container)
+	case "endRescue":
+		return endRescue(container)
+	case "healthcheck":
+		return showHealthcheck(cfg, container)
+	case "wait":
+		return waitAction(cfg, container)
+	case "devShell":
+		return openDevcontainerShell(container)
+	case "devPorts":
+		printDevcontainerPorts(container)
+	case "logs":
+		return showLogs(cfg, container)
+	case "shell":
+		return openShell(container)
+	case "browseFiles":
+		return browseFiles(cfg, tr("files.title", container.Name), container.ID, "/")
+	case "debugDns":
+		return debugDNS(cfg, container)
+	case "testConnectivity":
+		return testConnectivity(cfg, container)
+	case "probeHttp":
+		return probeHTTP(container)
+	case "forwardPort":
+		return forwardPort(cfg, container)
+	case "addHostsEntry":
+		return addHostsEntry(cfg, container)
+	case "removeHostsEntry":
+		return removeHostsEntry(cfg, container)
+	case "showTraffic":
+		return showTraffic(cfg, container)
+	case "checkClock":
+		return checkClock(cfg, container)
+	case "rm":
+		fmt.Print(tr("remove.prompt", container.Name))
+		answer, _ := plainInput.ReadString('\n')
+		if !isYes(answer) {
+			fmt.Println(tr("confirm.aborted"))
+			return errCancelled
+		}
+		fallthrough
+	case "start", "stop", "restart", "pause", "unpause":
+		err := runLifecycle(action, container)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("lifecycle.done."+lifecycleCommands[action]))
+	default:
+		if isPackAction(action) {
+			return runPackAction(cfg, action, container)
+		}
+	}
+
+	return nil
+}
+
+// returnsToList reports the actions after which the container list opens
+// again, the ones managing containers rather than leaving whale.
+func returnsToList(action string) bool {
+	switch action {
+	case "", "start", "stop", "restart", "pause", "unpause", "rm", "logs", "shell", "browseFiles", "debugDns", "showTraffic", "devShell", "playMacro":
+		return true
+	}
+	return isPackAction(action)
+}
+
+func copyContainerId(container string) error {
+	err := copyToClipboard(container)
+	if err != nil {
+		return fmt.Errorf("error copying container ID: %v", err)
+	}
+
+	println(tr("copy.idCopied"))
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"regexp"
+)
+
+// pinnedAPIVersion is the API version whale fell back to after the daemon
+// refused the one the CLI asked for, or "" when none was needed.
+var pinnedAPIVersion string
+
+// maximumAPIVersion matches the daemon's answer to a client that is too new,
+// e.g. "client version 1.45 is too new. Maximum supported API version is 1.41".
+var maximumAPIVersion = regexp.MustCompile(`(?i)maximum supported api version is ([0-9]+\.[0-9]+)`)
+
+// negotiateAPIVersion pins DOCKER_API_VERSION to the highest version the
+// daemon supports when it refused the client's, so every later docker call
+// works against older engines, and reports whether it did.
+func negotiateAPIVersion(err error) bool {
+	match := maximumAPIVersion.FindStringSubmatch(errorSummary(err))
+	if match == nil || os.Getenv("DOCKER_API_VERSION") == match[1] {
+		return false
+	}
+
+	os.Setenv("DOCKER_API_VERSION", match[1])
+	pinnedAPIVersion = match[1]
+	return true
+}
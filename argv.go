@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// argvOverrides are what the user sets on top of the image: --entrypoint,
+// the arguments after the image, -u and -w. Empty means the image's.
+type argvOverrides struct {
+	Entrypoint string
+	Cmd        []string
+	User       string
+	Workdir    string
+}
+
+// formatArgv renders an argv like the exec form of a Dockerfile, which
+// shows exactly where each argument starts and ends.
+func formatArgv(argv []string) string {
+	if len(argv) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(argv)
+	return string(data)
+}
+
+// isShellForm reports the entrypoints written in the shell form of a
+// Dockerfile, which run through sh -c and so never see CMD nor the
+// arguments given to docker run.
+func isShellForm(entrypoint []string) bool {
+	return len(entrypoint) == 3 && (entrypoint[0] == "/bin/sh" || entrypoint[0] == "sh") && entrypoint[1] == "-c"
+}
+
+// explainArgv tells how the entrypoint and cmd of the image combine with
+// the overrides into the process the container runs, along with its user
+// and working directory, the usual reasons a container doesn't start as
+// expected. The rules are docker's: --entrypoint drops the CMD of the
+// image, arguments after the image replace it.
+func explainArgv(image imageConfig, overrides argvOverrides) []string {
+	source := func(overridden bool) string {
+		if overridden {
+			return tr("argv.overridden")
+		}
+		return tr("argv.fromImage")
+	}
+
+	entrypoint := image.Entrypoint
+	if overrides.Entrypoint != "" {
+		entrypoint = []string{overrides.Entrypoint}
+	}
+	cmd := image.Cmd
+	cmdSource := source(len(overrides.Cmd) > 0)
+	switch {
+	case len(overrides.Cmd) > 0:
+		cmd = overrides.Cmd
+	case overrides.Entrypoint != "":
+		cmd = nil
+		if len(image.Cmd) > 0 {
+			cmdSource = tr("argv.cmdDropped", formatArgv(image.Cmd))
+		}
+	}
+	argv := append(append([]string{}, entrypoint...), cmd...)
+
+	user, userSource := overrides.User, source(true)
+	if user == "" {
+		user, userSource = image.User, source(false)
+	}
+	if user == "" {
+		user, userSource = "root", tr("argv.default")
+	}
+	workdir, workdirSource := overrides.Workdir, source(true)
+	if workdir == "" {
+		workdir, workdirSource = image.WorkingDir, source(false)
+	}
+	if workdir == "" {
+		workdir, workdirSource = "/", tr("argv.default")
+	}
+
+	table := [][]string{
+		{tr("argv.entrypoint"), formatArgv(entrypoint), source(overrides.Entrypoint != "")},
+		{tr("argv.cmd"), formatArgv(cmd), cmdSource},
+		{tr("argv.user"), user, userSource},
+		{tr("argv.workdir"), workdir, workdirSource},
+	}
+	lines := []string{tr("argv.title")}
+	for _, row := range alignColumns(table) {
+		lines = append(lines, "  "+strings.TrimRight(row, " "))
+	}
+	lines = append(lines, "  "+tr("argv.runs", formatArgv(argv)))
+
+	switch {
+	case len(argv) == 0:
+		lines = append(lines, renderColor(tr("argv.empty"), "31"))
+	case isShellForm(entrypoint) && len(cmd) > 0:
+		lines = append(lines, renderColor(tr("argv.shellForm"), "33"))
+	}
+	return lines
+}
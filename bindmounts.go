@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+var bindCheckable bool
+var bindCheckKnown bool
+
+// bindMountsCheckable reports whether the host paths of bind mounts can be
+// checked from here: the daemon runs on this machine, or in the VM of
+// Docker Desktop which shares its filesystem. Windows paths are translated
+// by Desktop, so they are not checked.
+func bindMountsCheckable() bool {
+	if bindCheckKnown {
+		return bindCheckable
+	}
+	bindCheckKnown = true
+
+	endpoint := dockerEndpoint()
+	bindCheckable = runtime.GOOS != "windows" && (endpoint == "" || strings.HasPrefix(endpoint, "unix://"))
+	return bindCheckable
+}
+
+// bindSource returns the absolute host path of a -v spec when it is a bind
+// mount rather than a named or anonymous volume.
+func bindSource(spec string) (string, bool) {
+	source, _, hasTarget := strings.Cut(spec, ":")
+	if !hasTarget {
+		return "", false
+	}
+
+	switch {
+	case strings.HasPrefix(source, "."):
+		absolute, err := filepath.Abs(source)
+		if err != nil {
+			return "", false
+		}
+		source = absolute
+	case !strings.HasPrefix(source, "/"):
+		return "", false
+	}
+	return source, true
+}
+
+// missingBindSources returns the host paths of bind mounts that don't
+// exist. The daemon creates them as empty directories owned by root, which
+// shows up as mysteriously empty directories in the container.
+func missingBindSources(sources []string) []string {
+	if !bindMountsCheckable() {
+		return nil
+	}
+
+	var missing []string
+	for _, source := range sources {
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			missing = append(missing, source)
+		}
+	}
+	return missing
+}
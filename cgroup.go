@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupStats is read from the cgroup v2 files of a container, which are
+// only reachable when the engine runs on this Linux host.
+type cgroupStats struct {
+	WorkingSet       int64
+	ThrottledPeriods int64
+	ThrottledUsec    int64
+}
+
+// cgroupDir finds the cgroup of a container under the systemd and cgroupfs
+// drivers, rootless setups included, or returns "".
+func cgroupDir(id string) string {
+	if runtime.GOOS != "linux" || id == "" {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return ""
+	}
+
+	patterns := []string{
+		filepath.Join(cgroupRoot, "system.slice", "docker-"+id+"*.scope"),
+		filepath.Join(cgroupRoot, "docker", id+"*"),
+		filepath.Join(cgroupRoot, "user.slice", "*", "*", "*", "docker-"+id+"*.scope"),
+	}
+	for _, pattern := range patterns {
+		if matches, _ := filepath.Glob(pattern); len(matches) == 1 {
+			return matches[0]
+		}
+	}
+	return ""
+}
+
+// readCgroupStats computes the working set the way the kernel's OOM killer
+// sees it: current usage minus the inactive page cache it can reclaim.
+func readCgroupStats(id string) (cgroupStats, bool) {
+	dir := cgroupDir(id)
+	if dir == "" {
+		return cgroupStats{}, false
+	}
+
+	current, err := readCgroupValue(filepath.Join(dir, "memory.current"))
+	if err != nil {
+		return cgroupStats{}, false
+	}
+
+	memory := readCgroupKeys(filepath.Join(dir, "memory.stat"))
+	cpu := readCgroupKeys(filepath.Join(dir, "cpu.stat"))
+
+	return cgroupStats{
+		WorkingSet:       max(current-memory["inactive_file"], 0),
+		ThrottledPeriods: cpu["nr_throttled"],
+		ThrottledUsec:    cpu["throttled_usec"],
+	}, true
+}
+
+func readCgroupValue(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readCgroupKeys parses the flat "key value" files like memory.stat.
+func readCgroupKeys(path string) map[string]int64 {
+	values := make(map[string]int64)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			continue
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			values[key] = n
+		}
+	}
+	return values
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// suggestion is one cleanup whale proposes, with the command applying it.
+type suggestion struct {
+	Kind   string
+	Name   string
+	Reason string
+	Args   []string
+}
+
+// cleanupSuggestions lists what can likely go: long-exited containers,
+// compose projects with nothing running, dangling images and unused volumes.
+func cleanupSuggestions(cfg *Config, containers []Container, now time.Time) ([]suggestion, error) {
+	var suggestions []suggestion
+
+	stopped := map[string]bool{}
+	for _, project := range groupComposeProjects(containers) {
+		if project.running() > 0 {
+			continue
+		}
+		stopped[project.Name] = true
+		suggestions = append(suggestions, suggestion{
+			Kind:   tr("cleanup.kindProject"),
+			Name:   project.Name,
+			Reason: tr("cleanup.projectStopped", len(project.Containers)),
+			Args:   []string{"compose", "-p", project.Name, "down"},
+		})
+	}
+
+	age := time.Duration(max(cfg.Cleanup.ExitedDays, 1)) * 24 * time.Hour
+	for _, container := range containers {
+		if !container.isExited() || stopped[container.Labels[composeProjectLabel]] {
+			continue
+		}
+		if container.FinishedAt.IsZero() || now.Sub(container.FinishedAt) < age {
+			continue
+		}
+		days := int(now.Sub(container.FinishedAt).Hours() / 24)
+		suggestions = append(suggestions, suggestion{
+			Kind:   tr("cleanup.kindContainer"),
+			Name:   container.Name,
+			Reason: tr("cleanup.exitedDaysAgo", days),
+			Args:   []string{"container", "rm", container.ID},
+		})
+	}
+
+	output, err := dockerRead("image", "ls", "--filter", "dangling=true", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var image imageLine
+		if json.Unmarshal([]byte(line), &image) != nil {
+			continue
+		}
+		if isProtectedImage(cfg, Image{ID: image.ID, Repository: image.Repository, Tag: image.Tag}) {
+			continue
+		}
+		suggestions = append(suggestions, suggestion{
+			Kind:   tr("cleanup.kindImage"),
+			Name:   shortID(strings.TrimPrefix(image.ID, "sha256:")),
+			Reason: tr("cleanup.dangling", image.Size, image.CreatedSince),
+			Args:   []string{"image", "rm", image.ID},
+		})
+	}
+
+	output, err = dockerRead("volume", "ls", "--filter", "dangling=true", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var volume volumeLine
+		if json.Unmarshal([]byte(line), &volume) != nil {
+			continue
+		}
+		suggestions = append(suggestions, suggestion{
+			Kind:   tr("cleanup.kindVolume"),
+			Name:   truncateMiddle(volume.Name, 40),
+			Reason: tr("cleanup.unusedVolume"),
+			Args:   []string{"volume", "rm", volume.Name},
+		})
+	}
+
+	return suggestions, nil
+}
+
+// cleanupCommand implements `whale cleanup`: shows the suggestions, all
+// checked except volumes since they hold data, and applies the chosen ones.
+func cleanupCommand(containers []Container) {
+	cfg := currentConfig()
+	requireAction(cfg, "cleanup")
+
+	suggestions, err := cleanupSuggestions(cfg, containers, time.Now())
+	if err != nil {
+		println(tr("error.cleanup"), err)
+		os.Exit(1)
+	}
+	if len(suggestions) == 0 {
+		fmt.Println(tr("cleanup.nothing"))
+		return
+	}
+
+	table := [][]string{{tr("cleanup.kind"), tr("column.name"), tr("cleanup.reason")}}
+	checked := make([]bool, len(suggestions))
+	for i, s := range suggestions {
+		table = append(table, []string{s.Kind, s.Name, s.Reason})
+		checked[i] = s.Args[0] != "volume"
+	}
+	rows := alignColumns(table)
+
+	chosen, err := chooseMany(cfg, tr("cleanup.title"), rows[0], rows[1:], checked)
+	if err != nil {
+		println(tr("error.cleanup"), err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, i := range chosen {
+		s := suggestions[i]
+		output, err := runWithSpinner(exec.Command("docker", s.Args...))
+		if err != nil {
+			failed = true
+			fmt.Printf("✗ %s %s: %v: %s\n", s.Kind, s.Name, err, strings.TrimSpace(string(output)))
+			continue
+		}
+		fmt.Printf("✓ %s %s\n", s.Kind, s.Name)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
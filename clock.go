@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checkClock compares the clock of the container with this machine's. The
+// container's time is read halfway through the exec, so the round trip only
+// blurs the result by its half, which is reported with it.
+func checkClock(cfg *Config, container Container) error {
+	before := time.Now()
+	output, err := runChild(exec.Command("docker", "exec", container.ID, "date", "+%s"), true)
+	after := time.Now()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unexpected answer from date: %q", strings.TrimSpace(string(output)))
+	}
+
+	local := before.Add(after.Sub(before) / 2)
+	// date only gives whole seconds: compare with the middle of its second.
+	drift := time.Unix(seconds, 0).Add(500 * time.Millisecond).Sub(local).Round(time.Second)
+	precision := (after.Sub(before)/2 + 500*time.Millisecond).Round(100 * time.Millisecond)
+	limit := time.Duration(max(cfg.Clock.MaxDriftSeconds, 1)) * time.Second
+
+	abs := drift
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs <= limit:
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("clock.inSync", drift, precision))
+	case drift > 0:
+		fmt.Printf("✗ %s: %s\n", container.Name, tr("clock.ahead", abs, limit))
+		fmt.Println(tr("clock.hint"))
+	default:
+		fmt.Printf("✗ %s: %s\n", container.Name, tr("clock.behind", abs, limit))
+		fmt.Println(tr("clock.hint"))
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type column struct {
+	Key   string
+	Value func(row columnRow) string
+}
+
+func (c column) title() string {
+	return tr("column." + c.Key)
+}
+
+// columnRow is everything a column needs to render one container.
+type columnRow struct {
+	Container Container
+	Stats     containerStats
+	HasStats  bool
+	Full      bool
+
+	ImageWidth int
+}
+
+var columns = []column{
+	{"id", func(r columnRow) string {
+		if r.Full {
+			return r.Container.ID
+		}
+		return shortID(r.Container.ID)
+	}},
+	{"name", func(r columnRow) string { return r.Container.Name }},
+	{"image", func(r columnRow) string {
+		if r.Full {
+			return r.Container.Image
+		}
+		return truncateImage(r.Container.Image, r.ImageWidth)
+	}},
+	{"status", func(r columnRow) string {
+		if r.Container.Emulated {
+			return r.Container.Status + " " + tr("list.emulated", r.Container.Platform)
+		}
+		return r.Container.Status
+	}},
+	{"ports", func(r columnRow) string { return r.Container.Ports }},
+	{"project", func(r columnRow) string { return r.Container.Labels[composeProjectLabel] }},
+	{"cpu", func(r columnRow) string {
+		if !r.HasStats {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", r.Stats.CPU)
+	}},
+	{"mem", func(r columnRow) string {
+		if !r.HasStats {
+			return "-"
+		}
+		return r.Stats.MemUsage
+	}},
+	{"restarts", func(r columnRow) string {
+		restarts := strconv.Itoa(r.Container.RestartCount)
+		if r.Container.CrashLoop {
+			restarts += " " + tr("list.crashLoop")
+		}
+		return restarts
+	}},
+	{"exit", func(r columnRow) string {
+		if !r.Container.isExited() {
+			return ""
+		}
+		return formatExit(r.Container)
+	}},
+	{"platform", func(r columnRow) string { return r.Container.Platform }},
+	{"gpu", func(r columnRow) string {
+		if r.Container.GPUs == "" {
+			return "-"
+		}
+		return r.Container.GPUs
+	}},
+	{"size", func(r columnRow) string {
+		if !r.Container.HasSize {
+			return "-"
+		}
+		return formatBytes(r.Container.SizeRw)
+	}},
+	{"created", func(r columnRow) string { return r.Container.Created }},
+}
+
+func findColumn(key string) (column, bool) {
+	for _, c := range columns {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// configuredColumns returns the list columns from the config, dropping
+// unknown keys and falling back to the defaults when nothing is left.
+func configuredColumns(cfg *Config) []string {
+	var keys []string
+	for _, key := range cfg.List.Columns {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := findColumn(key); ok && !containsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		keys = []string{"id", "name", "image", "status"}
+	}
+
+	if cfg.List.ShowStats {
+		keys = withStatsColumns(keys)
+	}
+
+	return keys
+}
+
+func withStatsColumns(keys []string) []string {
+	for _, key := range []string{"cpu", "mem"} {
+		if !containsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func withoutStatsColumns(keys []string) []string {
+	var kept []string
+	for _, key := range keys {
+		if key != "cpu" && key != "mem" {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
+
+func hasStatsColumns(keys []string) bool {
+	return containsString(keys, "cpu") || containsString(keys, "mem")
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+type columnToggle struct {
+	key     string
+	enabled bool
+}
+
+// columnPicker lets users enable and reorder list columns from the TUI.
+type columnPicker struct {
+	config  *Config
+	toggles []columnToggle
+	cursor  int
+	done    bool
+	apply   bool
+}
+
+func newColumnPicker(cfg *Config, active []string) *columnPicker {
+	picker := &columnPicker{config: cfg}
+	for _, key := range active {
+		picker.toggles = append(picker.toggles, columnToggle{key: key, enabled: true})
+	}
+	for _, c := range columns {
+		if !containsString(active, c.Key) {
+			picker.toggles = append(picker.toggles, columnToggle{key: c.Key})
+		}
+	}
+	return picker
+}
+
+func (picker *columnPicker) update(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc", "q":
+		picker.done = true
+	case "enter":
+		picker.done = true
+		picker.apply = true
+	case "up":
+		picker.cursor = (picker.cursor - 1 + len(picker.toggles)) % len(picker.toggles)
+	case "down":
+		picker.cursor = (picker.cursor + 1) % len(picker.toggles)
+	case " ", "x":
+		picker.toggles[picker.cursor].enabled = !picker.toggles[picker.cursor].enabled
+	case "shift+up", "K":
+		if picker.cursor > 0 {
+			picker.swap(picker.cursor, picker.cursor-1)
+			picker.cursor--
+		}
+	case "shift+down", "J":
+		if picker.cursor < len(picker.toggles)-1 {
+			picker.swap(picker.cursor, picker.cursor+1)
+			picker.cursor++
+		}
+	}
+}
+
+func (picker *columnPicker) swap(i, j int) {
+	picker.toggles[i], picker.toggles[j] = picker.toggles[j], picker.toggles[i]
+}
+
+func (picker *columnPicker) selected() []string {
+	var keys []string
+	for _, toggle := range picker.toggles {
+		if toggle.enabled {
+			keys = append(keys, toggle.key)
+		}
+	}
+	return keys
+}
+
+func (picker *columnPicker) view() string {
+	s := tr("picker.title") + "\n\n"
+
+	for i, toggle := range picker.toggles {
+		c, _ := findColumn(toggle.key)
+		box := "[ ]"
+		if toggle.enabled {
+			box = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s", box, c.title())
+		if picker.cursor == i {
+			s += fmt.Sprintf("%s %s\n", renderCursor(picker.config), renderContainerSelected(picker.config, line, true))
+		} else {
+			s += fmt.Sprintf("  %s\n", line)
+		}
+	}
+
+	s += "\n" + tr("picker.help") + "\n"
+	return s
+}
+
+// truncateImage shortens long image references around the middle, keeping
+// the registry hint and the repository name with its tag, which are the
+// parts that tell images apart:
+// registry.example.com/team/app:tag -> registry…/app:tag.
+func truncateImage(image string, width int) string {
+	if width <= 0 || len([]rune(image)) <= width {
+		return image
+	}
+
+	if first, rest, found := strings.Cut(image, "/"); found {
+		last := rest[strings.LastIndex(rest, "/")+1:]
+		host, _, _ := strings.Cut(first, ".")
+		short := host + "…/" + last
+		if len([]rune(short)) <= width {
+			return short
+		}
+	}
+
+	return truncateMiddle(image, width)
+}
+
+func truncateMiddle(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+
+	head := (width - 1) / 2
+	tail := width - 1 - head
+	return string(runes[:head]) + "…" + string(runes[len(runes)-tail:])
+}
+
+// formatExit renders the exit code and the local finish time of a container.
+func formatExit(container Container) string {
+	if container.FinishedAt.IsZero() {
+		return strconv.Itoa(container.ExitCode)
+	}
+	return fmt.Sprintf("%d %s", container.ExitCode, container.FinishedAt.Local().Format("2006-01-02 15:04"))
+}
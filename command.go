@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// editCommand relaunches a container with another entrypoint or command,
+// e.g. `sleep infinity` to keep a crash-looping one up long enough to look
+// inside. Like editLabels, it recreates the container with its other
+// settings kept, which loses its writable layer.
+func editCommand(cfg *Config, container Container) error {
+	spec, err := inspectSpec(container.ID)
+	if err != nil {
+		return err
+	}
+
+	entrypoint := formatCommandLine(spec.Config.Entrypoint)
+	command := formatCommandLine(spec.Config.Cmd)
+	fields, ok, err := fillForm(cfg, tr("command.title", container.Name), []formField{
+		{Label: tr("command.entrypoint"), Value: entrypoint, Hint: tr("command.entrypointHint")},
+		{Label: tr("command.command"), Value: command, Hint: tr("command.commandHint")},
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	newEntrypoint, err := splitCommandLine(fields[0].Value)
+	if err != nil {
+		return err
+	}
+	newCommand, err := splitCommandLine(fields[1].Value)
+	if err != nil {
+		return err
+	}
+	if formatCommandLine(newEntrypoint) == entrypoint && formatCommandLine(newCommand) == command {
+		fmt.Println(tr("command.unchanged"))
+		return nil
+	}
+
+	policy := pullPolicy(cfg)
+	fmt.Println(tr("command.summary", formatCommandLine(append(append([]string{}, newEntrypoint...), newCommand...))))
+	fmt.Println(renderColor(tr("recreate.warning", container.Name), "33"))
+	fmt.Println(pullSummary(policy, spec.Config.Image))
+	fmt.Print(tr("recreate.prompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		fmt.Println(tr("confirm.aborted"))
+		return nil
+	}
+
+	// An empty but non-nil entrypoint is passed as --entrypoint "", which
+	// drops the image's instead of falling back to it.
+	spec.Config.Entrypoint = append([]string{}, newEntrypoint...)
+	spec.Config.Cmd = newCommand
+	err = recreateContainer(spec, policy)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s: %s\n", container.Name, tr("command.done"))
+	return nil
+}
+
+// formatCommandLine joins args as a shell would read them back, quoting
+// only the ones that need it.
+func formatCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`*?&|;<>()[]{}#~") {
+			arg = shellQuote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// splitCommandLine splits s into arguments the way a POSIX shell does,
+// with single and double quotes and backslash escapes but no expansion.
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	quote := rune(0)
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("%s", tr("command.unterminated", s))
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const (
+	composeWorkingDirLabel  = "com.docker.compose.project.working_dir"
+	composeConfigFilesLabel = "com.docker.compose.project.config_files"
+	composeServiceLabel     = "com.docker.compose.service"
+)
+
+type composeProject struct {
+	Name        string
+	WorkingDir  string
+	ConfigFiles []string
+	Containers  []Container
+}
+
+// groupComposeProjects builds the compose projects from container labels.
+func groupComposeProjects(containers []Container) []composeProject {
+	byName := map[string]*composeProject{}
+	var names []string
+
+	for _, container := range containers {
+		name := container.Labels[composeProjectLabel]
+		if name == "" {
+			continue
+		}
+
+		project, ok := byName[name]
+		if !ok {
+			project = &composeProject{
+				Name:       name,
+				WorkingDir: container.Labels[composeWorkingDirLabel],
+			}
+			for _, file := range strings.Split(container.Labels[composeConfigFilesLabel], ",") {
+				if file != "" {
+					project.ConfigFiles = append(project.ConfigFiles, file)
+				}
+			}
+			byName[name] = project
+			names = append(names, name)
+		}
+		project.Containers = append(project.Containers, container)
+	}
+
+	sort.Strings(names)
+	projects := make([]composeProject, len(names))
+	for i, name := range names {
+		projects[i] = *byName[name]
+	}
+
+	return projects
+}
+
+func (project composeProject) running() int {
+	count := 0
+	for _, container := range project.Containers {
+		if container.State == "running" {
+			count++
+		}
+	}
+	return count
+}
+
+// composeCommand builds a `docker compose` invocation targeting the project
+// from wherever whale was started.
+func (project composeProject) composeCommand(args ...string) *exec.Cmd {
+	base := []string{"compose", "--project-name", project.Name}
+	if project.WorkingDir != "" {
+		base = append(base, "--project-directory", project.WorkingDir)
+	}
+	for _, file := range project.ConfigFiles {
+		base = append(base, "--file", file)
+	}
+
+	return exec.Command("docker", append(base, args...)...)
+}
+
+func chooseComposeProject(cfg *Config, projects []composeProject) (composeProject, error) {
+	if len(projects) == 0 {
+		fmt.Println(tr("compose.empty"))
+		return composeProject{}, nil
+	}
+
+	table := [][]string{{tr("column.project"), tr("column.services"), tr("column.directory")}}
+	for _, project := range projects {
+		table = append(table, []string{
+			project.Name,
+			fmt.Sprintf("%d/%d", project.running(), len(project.Containers)),
+			project.WorkingDir,
+		})
+	}
+	rows := alignColumns(table)
+
+	choice, err := chooseFromList(cfg, tr("compose.title"), rows[0], rows[1:])
+	if err != nil || choice < 0 {
+		return composeProject{}, err
+	}
+
+	return projects[choice], nil
+}
+
+// chooseComposeAction is the menu of the project, whose title lists the
+// services drifted from the compose file.
+func chooseComposeAction(cfg *Config, project composeProject, drift map[string][]string, driftErr error) (string, error) {
+	ids := []string{"exit", "composeUp", "composeWatch"}
+	if len(drift) > 0 {
+		ids = append(ids[:1], append([]string{"composeReconcile"}, ids[1:]...)...)
+	}
+	if project.running() > 0 {
+		ids = append(ids, "composeSuspend")
+	}
+	if isSuspended(project) {
+		ids = append(ids, "composeResume")
+	}
+	actions := allowedActions(cfg, ids)
+
+	var labels []string
+	for _, action := range actions {
+		labels = append(labels, actionLabel(cfg, action))
+	}
+
+	title := tr("compose.actionsTitle", project.Name)
+	for _, name := range driftedServices(drift) {
+		title += "\n" + renderColor(tr("drift.badge", name, strings.Join(drift[name], ", ")), "33")
+	}
+	if driftErr != nil {
+		title += "\n" + renderColor(tr("drift.unavailable", driftErr), "2")
+	}
+
+	choice, err := chooseFromList(cfg, title, "", labels)
+	if err != nil || choice < 0 {
+		return "", err
+	}
+
+	return actions[choice], nil
+}
+
+func composeMode(containers []Container) {
+	cfg := currentConfig()
+	project, err := chooseComposeProject(cfg, groupComposeProjects(containers))
+	if err != nil {
+		println(tr("error.chooseProject"), err)
+		os.Exit(1)
+	}
+	if project.Name == "" {
+		return
+	}
+
+	drift, driftErr := composeDrift(project)
+	action, err := chooseComposeAction(cfg, project, drift, driftErr)
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+
+	err = doComposeAction(cfg, action, project)
+	if err != nil {
+		println(tr("error.doAction"), err)
+		os.Exit(1)
+	}
+}
+
+func doComposeAction(cfg *Config, action string, project composeProject) error {
+	switch action {
+	case "composeUp":
+		return composeUp(cfg, tr("composeUp.title", project.Name), project.composeCommand, []string{"--build"}, nil)
+	case "composeReconcile":
+		drift, err := composeDrift(project)
+		if err != nil {
+			return err
+		}
+		services := driftedServices(drift)
+		if len(services) == 0 {
+			return nil
+		}
+		return composeUp(cfg, tr("compose.reconcileTitle", strings.Join(services, ", ")), project.composeCommand, []string{"--no-deps", "--force-recreate"}, services)
+	case "composeWatch":
+		return runStream(cfg, tr("compose.watchTitle", project.Name), project.composeCommand("watch"))
+	case "composeSuspend":
+		return suspendProject(project)
+	case "composeResume":
+		return resumeProject(project)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// composeSteps maps what `docker compose --progress plain` prints after a
+// service or container to the state shown for the service.
+var composeSteps = map[string]string{
+	"Pulling":   "pulling",
+	"Pulled":    "pulled",
+	"Building":  "building",
+	"Built":     "built",
+	"Creating":  "creating",
+	"Created":   "created",
+	"Recreate":  "creating",
+	"Recreated": "created",
+	"Starting":  "starting",
+	"Started":   "started",
+	"Running":   "started",
+	"Waiting":   "waiting",
+	"Healthy":   "healthy",
+	"Error":     "failed",
+}
+
+// buildStepPattern matches the BuildKit lines of a service build, such as
+// "#5 [web 2/4] RUN npm ci".
+var buildStepPattern = regexp.MustCompile(`^#\d+ \[([\w.-]+)[ \]]`)
+
+// containerNumberPattern is the replica suffix compose gives containers.
+var containerNumberPattern = regexp.MustCompile(`[-_]\d+$`)
+
+type serviceProgress struct {
+	name   string
+	state  string
+	output []string
+	logs   []string
+}
+
+type serviceLogsMsg struct {
+	service int
+	lines   []string
+}
+
+// composeUpView follows `docker compose up -d` service by service rather
+// than as the raw output, which interleaves pulls, builds and starts.
+type composeUpView struct {
+	config   *Config
+	title    string
+	summary  string
+	stream   *streamView
+	command  func(args ...string) *exec.Cmd
+	services []serviceProgress
+	other    []string
+	cursor   int
+	expanded map[int]bool
+	width    int
+	ended    bool
+	err      error
+}
+
+// serviceIndex finds the service a progress line is about, given either
+// the service or one of its containers, named project-service-1.
+func (view *composeUpView) serviceIndex(name string) int {
+	for i, service := range view.services {
+		if service.name == name {
+			return i
+		}
+	}
+	base := containerNumberPattern.ReplaceAllString(name, "")
+	for i, service := range view.services {
+		if strings.HasSuffix(base, "-"+service.name) || strings.HasSuffix(base, "_"+service.name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (view *composeUpView) apply(line string) {
+	if match := buildStepPattern.FindStringSubmatch(line); match != nil {
+		if i := view.serviceIndex(match[1]); i >= 0 {
+			if view.services[i].state == "pending" {
+				view.services[i].state = "building"
+			}
+			view.services[i].output = append(view.services[i].output, line)
+			return
+		}
+	}
+
+	fields := strings.Fields(strings.TrimLeft(line, " ✔✘⠿-"))
+	if len(fields) > 0 && (fields[0] == "Container" || fields[0] == "Service" || fields[0] == "Image") {
+		fields = fields[1:]
+	}
+	if len(fields) >= 2 {
+		if i := view.serviceIndex(fields[0]); i >= 0 {
+			if state, ok := composeSteps[fields[1]]; ok {
+				view.services[i].state = state
+			}
+			view.services[i].output = append(view.services[i].output, line)
+			return
+		}
+	}
+
+	if strings.TrimSpace(line) != "" {
+		view.other = append(view.other, line)
+	}
+}
+
+// finish marks the services left halfway as failed once compose gave up.
+func (view *composeUpView) finish() {
+	if view.err == nil {
+		return
+	}
+	for i, service := range view.services {
+		switch service.state {
+		case "pending", "started", "healthy", "created", "pulled", "built":
+		default:
+			view.services[i].state = "failed"
+		}
+	}
+}
+
+func (view *composeUpView) fetchLogs(i int) tea.Cmd {
+	name := view.services[i].name
+	return func() tea.Msg {
+		output, _ := runChild(view.command("logs", "--no-color", "--tail", "30", name), true)
+		return serviceLogsMsg{service: i, lines: strings.Split(strings.TrimRight(string(output), "\n"), "\n")}
+	}
+}
+
+func (view *composeUpView) Init() tea.Cmd {
+	return view.stream.wait()
+}
+
+func (view *composeUpView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case streamLineMsg:
+		view.apply(string(msg))
+		return view, view.stream.wait()
+	case streamEndMsg:
+		view.ended = true
+		view.err = msg.err
+		view.finish()
+	case serviceLogsMsg:
+		view.services[msg.service].logs = msg.lines
+	case tea.WindowSizeMsg:
+		view.width = msg.Width
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			view.stream.stop()
+			return view, tea.Quit
+		case "up", "k":
+			view.cursor = max(view.cursor-1, 0)
+		case "down", "j":
+			view.cursor = min(view.cursor+1, max(len(view.services)-1, 0))
+		case "enter", " ":
+			if len(view.services) == 0 {
+				break
+			}
+			view.expanded[view.cursor] = !view.expanded[view.cursor]
+			service := view.services[view.cursor]
+			if view.expanded[view.cursor] && service.state == "failed" && service.logs == nil {
+				return view, view.fetchLogs(view.cursor)
+			}
+		}
+	}
+	return view, nil
+}
+
+func serviceIcon(state string) string {
+	switch state {
+	case "started", "healthy":
+		return renderColor("✓", "32")
+	case "failed":
+		return renderColor("✗", "31")
+	case "pending":
+		return renderColor("·", "2")
+	}
+	return renderColor("…", "33")
+}
+
+func (view *composeUpView) View() string {
+	s := newFrame()
+	s.WriteString(renderHeader(view.config))
+	s.line(view.title)
+	if view.summary != "" {
+		s.colored(view.summary, "2")
+		s.WriteByte('\n')
+	}
+	s.WriteByte('\n')
+
+	width := 0
+	for _, service := range view.services {
+		width = max(width, len(service.name))
+	}
+	clip := func(line string) string {
+		return clipString(line, view.width-6)
+	}
+
+	for i, service := range view.services {
+		cursor := " "
+		if view.cursor == i {
+			cursor = renderCursor(view.config)
+		}
+		s.line(cursor, " ", serviceIcon(service.state), " ", fmt.Sprintf("%-*s", width, service.name), "  ", tr("composeUp.state."+service.state))
+		if !view.expanded[i] {
+			continue
+		}
+		for _, line := range service.output[max(len(service.output)-15, 0):] {
+			s.WriteString("      ")
+			s.colored(clip(line), "2")
+			s.WriteByte('\n')
+		}
+		if service.logs != nil {
+			s.line("      ", tr("composeUp.logs"))
+			for _, line := range service.logs {
+				s.line("      ", clip(line))
+			}
+		}
+	}
+
+	if view.err != nil {
+		s.WriteByte('\n')
+		for _, line := range view.other[max(len(view.other)-5, 0):] {
+			s.colored(clip(line), "31")
+			s.WriteByte('\n')
+		}
+	}
+
+	status := tr("composeUp.running")
+	if view.ended {
+		status = tr("composeUp.done")
+		if view.err != nil {
+			status = tr("composeUp.failed", view.err)
+		}
+	}
+	s.WriteByte('\n')
+	s.colored(status+"  "+tr("composeUp.help"), "2")
+	return s.done()
+}
+
+// composeUp runs `docker compose up -d` with the extra flags through
+// command, which prefixes the compose arguments targeting the project,
+// bringing up the given services or all of them. Outside plain mode, the
+// progress is shown per service.
+func composeUp(cfg *Config, title string, command func(args ...string) *exec.Cmd, extra []string, services []string) error {
+	policy := pullPolicy(cfg)
+	args := append([]string{"--progress", "plain", "up", "-d", "--pull", policy}, extra...)
+	args = append(args, services...)
+
+	if plainMode {
+		fmt.Println(composePullSummary(policy))
+		cmd := command(args...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		_, err := runChild(cmd, false)
+		return err
+	}
+
+	output, err := childOutput(command("config", "--services"))
+	if err != nil {
+		return fmt.Errorf("error reading the compose services: %v", err)
+	}
+	view := &composeUpView{
+		config:   cfg,
+		title:    title,
+		summary:  composePullSummary(policy),
+		command:  command,
+		expanded: map[int]bool{},
+	}
+	for _, name := range strings.Fields(string(output)) {
+		if len(services) > 0 && !containsString(services, name) {
+			continue
+		}
+		view.services = append(view.services, serviceProgress{name: name, state: "pending"})
+	}
+
+	view.stream = newStreamView(cfg, title, command(args...))
+	err = view.stream.start()
+	if err != nil {
+		return err
+	}
+
+	_, err = runProgram(view)
+	view.stream.stop()
+	if err != nil {
+		return err
+	}
+	return view.err
+}
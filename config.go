@@ -0,0 +1,193 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+//go:embed config.json
+var configFile string
+
+// settings is the Config in use. Screens and commands are handed the one
+// current when they start and keep it: loading the config again or applying
+// a host profile stores a new Config rather than changing the one they hold.
+var settings atomic.Pointer[Config]
+
+func init() {
+	settings.Store(defaultConfig())
+}
+
+// currentConfig is the Config to hand to what starts now.
+func currentConfig() *Config {
+	return settings.Load()
+}
+
+func useConfig(cfg *Config) {
+	settings.Store(cfg)
+}
+
+type Config struct {
+	Ui struct {
+		CursorColor            string `json:"cursorColor"`
+		BranchColor            string `json:"branchColor"`
+		ContainerSelectedColor string `json:"containerSelectedColor"`
+		ActionSelectedColor    string `json:"actionSelectedColor"`
+		Icons                  bool   `json:"icons"`
+		Locale                 string `json:"locale"`
+		CrashLoopColor         string `json:"crashLoopColor"`
+		ExitErrorColor         string `json:"exitErrorColor"`
+		Palette                string `json:"palette"`
+		HideUnavailableActions bool   `json:"hideUnavailableActions"`
+		RememberActions        bool   `json:"rememberActions"`
+	} `json:"Ui"`
+	List struct {
+		ShowStats     bool     `json:"showStats"`
+		StatsInterval int      `json:"statsInterval"`
+		Columns       []string `json:"columns"`
+		NarrowWidth   int      `json:"narrowWidth"`
+		ImageWidth    int      `json:"imageWidth"`
+
+		ShowDetails  bool `json:"showDetails"`
+		PreviewLines int  `json:"previewLines"`
+
+		CrashLoopRestarts int `json:"crashLoopRestarts"`
+		CrashLoopWindow   int `json:"crashLoopWindow"`
+
+		Ignore      IgnoreRules      `json:"ignore"`
+		LabelColors []LabelColorRule `json:"labelColors"`
+
+		EnterAction string `json:"enterAction"`
+		Recent      int    `json:"recent"`
+	} `json:"list"`
+	Logs struct {
+		Wrap       bool            `json:"wrap"`
+		Tail       int             `json:"tail"`
+		Highlights []HighlightRule `json:"highlights"`
+	} `json:"logs"`
+	Run struct {
+		Detach     bool   `json:"detach"`
+		PullPolicy string `json:"pullPolicy"`
+		NamePrefix string `json:"namePrefix"`
+	} `json:"run"`
+	Images struct {
+		Protected []string `json:"protected"`
+		KeepTags  int      `json:"keepTags"`
+	} `json:"images"`
+	Wait struct {
+		Timeout int `json:"timeout"`
+	} `json:"wait"`
+	Volumes struct {
+		HelperImage string `json:"helperImage"`
+	} `json:"volumes"`
+	Forward struct {
+		RelayImage string `json:"relayImage"`
+	} `json:"forward"`
+	HostsFile struct {
+		Enabled bool   `json:"enabled"`
+		Domain  string `json:"domain"`
+	} `json:"hostsFile"`
+	Clock struct {
+		MaxDriftSeconds int `json:"maxDriftSeconds"`
+	} `json:"clock"`
+	Cleanup struct {
+		ExitedDays int `json:"exitedDays"`
+	} `json:"cleanup"`
+	GC struct {
+		Enabled         bool        `json:"enabled"`
+		ExitedDays      int         `json:"exitedDays"`
+		Match           IgnoreRules `json:"match"`
+		IntervalMinutes int         `json:"intervalMinutes"`
+	} `json:"gc"`
+	Docker struct {
+		Host         string   `json:"host"`
+		ProbeSockets bool     `json:"probeSockets"`
+		TimeLayouts  []string `json:"timeLayouts"`
+	} `json:"docker"`
+	Packs struct {
+		Sources      []string `json:"sources"`
+		RefreshHours int      `json:"refreshHours"`
+	} `json:"packs"`
+	Keys struct {
+		Chords map[string]string `json:"chords"`
+	} `json:"keys"`
+	Macros   map[string][]string    `json:"macros"`
+	Timeouts map[string]string      `json:"timeouts"`
+	Webhooks []Webhook              `json:"webhooks"`
+	Hosts    map[string]HostProfile `json:"hosts"`
+	Actions  ActionRules            `json:"actions"`
+
+	highlighters []highlighter
+	timeouts     map[string]time.Duration
+	// host is the profile of the active host and readOnly whether it or
+	// the --read-only flag disable the mutating actions, merged in by
+	// applyHostProfile.
+	host     HostProfile
+	readOnly bool
+	// packs are the actions of the packs of Packs.Sources, shared by the
+	// copies of the config, which keep the same sources.
+	packs *packSet
+}
+
+// defaultConfig is the embedded config.json, which always parses.
+func defaultConfig() *Config {
+	cfg := &Config{packs: &packSet{}}
+	_ = json.Unmarshal([]byte(configFile), cfg)
+	return cfg
+}
+
+// loadConfig reads the embedded defaults, then overlays the user config file
+// when one exists so users only have to set the keys they want to change.
+func loadConfig() (*Config, error) {
+	cfg := &Config{packs: &packSet{}}
+	err := json.Unmarshal([]byte(configFile), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file: %v", err)
+	}
+
+	path, err := userConfigPath()
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file %s: %v", path, err)
+	}
+
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %v", path, err)
+	}
+
+	err = cfg.compileHighlights()
+	if err != nil {
+		return nil, fmt.Errorf("error in config file %s: %v", path, err)
+	}
+	err = cfg.parseTimeouts()
+	if err != nil {
+		return nil, fmt.Errorf("error in config file %s: %v", path, err)
+	}
+	err = cfg.checkPalette()
+	if err != nil {
+		return nil, fmt.Errorf("error in config file %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+func userConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "whale", "config.json"), nil
+}
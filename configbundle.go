@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bundleFormat identifies the files written by `whale config export`.
+const bundleFormat = "whale-config/1"
+
+// configBundle is a user config made portable: the sections of the config
+// file, like ui (theme), list (columns and presets), actions and hosts.
+type configBundle struct {
+	Format     string                     `json:"format"`
+	ExportedAt time.Time                  `json:"exportedAt"`
+	Config     map[string]json.RawMessage `json:"config"`
+}
+
+// configCommand implements `whale config export [FILE] [--only a,b]` and
+// `whale config import FILE [--replace]`, to sync the config across machines
+// or share it with a team.
+func configCommand(args []string) {
+	if len(args) == 0 {
+		println(tr("configBundle.usage"))
+		os.Exit(1)
+	}
+
+	var file string
+	var only []string
+	replace := false
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--only":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			only = splitList(args[i])
+		case "--replace":
+			replace = true
+		default:
+			file = args[i]
+		}
+	}
+
+	var err error
+	switch args[0] {
+	case "export":
+		err = exportConfig(file, only)
+	case "import":
+		if file == "" {
+			println(tr("configBundle.usage"))
+			os.Exit(1)
+		}
+		err = importConfig(file, only, replace)
+	default:
+		println(tr("configBundle.usage"))
+		os.Exit(1)
+	}
+	if err != nil {
+		println(tr("error.configBundle"), err.Error())
+		os.Exit(1)
+	}
+}
+
+// readUserConfig returns the sections of the user config file, empty when
+// there is none.
+func readUserConfig() (map[string]json.RawMessage, string, error) {
+	path, err := userConfigPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	sections := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sections, path, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = json.Unmarshal(data, &sections)
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing config file %s: %v", path, err)
+	}
+	return sections, path, nil
+}
+
+func exportConfig(file string, only []string) error {
+	sections, _, err := readUserConfig()
+	if err != nil {
+		return err
+	}
+
+	bundle := configBundle{
+		Format:     bundleFormat,
+		ExportedAt: time.Now().UTC(),
+		Config:     selectSections(sections, only),
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if file == "" || file == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	err = os.WriteFile(file, data, 0o644)
+	if err != nil {
+		return err
+	}
+	fmt.Println(tr("configBundle.exported", file, strings.Join(sectionNames(bundle.Config), ", ")))
+	return nil
+}
+
+// importConfig merges the sections of the bundle into the user config, or
+// swaps them in whole with replace, after checking the result still loads.
+// The previous file is kept next to it as config.json.bak.
+func importConfig(file string, only []string, replace bool) error {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+
+	var bundle configBundle
+	err = json.Unmarshal(data, &bundle)
+	if err != nil {
+		return fmt.Errorf("error parsing bundle %s: %v", file, err)
+	}
+	if bundle.Format != bundleFormat {
+		return fmt.Errorf("%s is not a whale config bundle", file)
+	}
+
+	sections, path, err := readUserConfig()
+	if err != nil {
+		return err
+	}
+
+	imported := selectSections(bundle.Config, only)
+	for name, section := range imported {
+		if !replace {
+			section, err = mergeJSON(sections[name], section)
+			if err != nil {
+				return fmt.Errorf("error merging section %s: %v", name, err)
+			}
+		}
+		sections[name] = section
+	}
+
+	merged, err := json.MarshalIndent(sections, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var check Config
+	err = json.Unmarshal(merged, &check)
+	if err != nil {
+		return fmt.Errorf("the imported config is invalid: %v", err)
+	}
+
+	err = writeUserConfig(sections, path)
+	if err != nil {
+		return err
+	}
+	fmt.Println(tr("configBundle.imported", strings.Join(sectionNames(imported), ", "), path))
+	return nil
+}
+
+// writeUserConfig replaces the user config file at path with sections,
+// keeping the previous file next to it as config.json.bak.
+func writeUserConfig(sections map[string]json.RawMessage, path string) error {
+	data, err := json.MarshalIndent(sections, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	if previous, err := os.ReadFile(path); err == nil {
+		err = os.WriteFile(path+".bak", previous, 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func selectSections(sections map[string]json.RawMessage, only []string) map[string]json.RawMessage {
+	if len(only) == 0 {
+		return sections
+	}
+
+	selected := map[string]json.RawMessage{}
+	for _, name := range only {
+		if section, ok := sections[name]; ok {
+			selected[name] = section
+		}
+	}
+	return selected
+}
+
+func sectionNames(sections map[string]json.RawMessage) []string {
+	var names []string
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeJSON overlays src onto dst: objects are merged key by key, anything
+// else, lists included, is replaced.
+func mergeJSON(dst json.RawMessage, src json.RawMessage) (json.RawMessage, error) {
+	var dstObject, srcObject map[string]json.RawMessage
+	if json.Unmarshal(dst, &dstObject) != nil || json.Unmarshal(src, &srcObject) != nil || dstObject == nil || srcObject == nil {
+		return src, nil
+	}
+
+	for key, value := range srcObject {
+		merged, err := mergeJSON(dstObject[key], value)
+		if err != nil {
+			return nil, err
+		}
+		dstObject[key] = merged
+	}
+	return json.Marshal(dstObject)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// confirmWord must be typed to confirm bulk removals and prunes.
+const confirmWord = "delete"
+
+// confirmPhrase guards destructive bulk operations by making the user type
+// a word rather than answering y/n, like cloud CLIs do. It reads a line
+// from stdin and only returns true on an exact match.
+func confirmPhrase(summary string, phrase string) (bool, error) {
+	fmt.Println(summary)
+	fmt.Print(tr("confirm.prompt", phrase))
+
+	answer, err := plainInput.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	if strings.TrimSpace(answer) != phrase {
+		fmt.Println(tr("confirm.aborted"))
+		return false, nil
+	}
+	return true, nil
+}
+
+// confirmBulk asks for the phrase unless --yes was given; non-interactive
+// runs must pass --yes since there is nobody to type it.
+func confirmBulk(summary string, phrase string, assumeYes bool) bool {
+	if assumeYes {
+		return true
+	}
+	if !isInteractive() {
+		println(tr("confirm.needsYes"))
+		return false
+	}
+
+	ok, err := confirmPhrase(summary, phrase)
+	if err != nil {
+		println(err.Error())
+		return false
+	}
+	return ok
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// probeScript tries to reach $1, on port $2 when given, with whatever the
+// image has, and ends with "OK <ms>" or "FAIL <reason>". Latency is only
+// known when date supports %N.
+const probeScript = `now() { t=$(date +%s%N 2>/dev/null); case "$t" in *N|"") echo 0;; *) echo $((t / 1000000));; esac; }
+target=$1; port=$2
+if [ -z "$port" ]; then
+	if ! command -v ping >/dev/null 2>&1; then echo "FAIL no ping in this image, give a port"; exit 0; fi
+	ping -c 3 -W 2 "$target" 2>&1 && echo "OK" || echo "FAIL unreachable"
+	exit 0
+fi
+start=$(now)
+if command -v nc >/dev/null 2>&1; then
+	out=$(nc -z -w 3 "$target" "$port" 2>&1); code=$?
+elif command -v bash >/dev/null 2>&1; then
+	out=$(timeout 3 bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$target" "$port" 2>&1); code=$?
+else
+	echo "FAIL no nc or bash in this image"; exit 0
+fi
+end=$(now)
+if [ "$code" -eq 0 ]; then
+	if [ "$start" -gt 0 ]; then echo "OK $((end - start))"; else echo "OK"; fi
+elif [ "$code" -eq 124 ]; then
+	echo "FAIL timed out"
+else
+	echo "FAIL $out" | tr '\n' ' '; echo
+fi`
+
+// testConnectivity checks that the container reaches another container, or
+// any host, on a port or with ping, and reports the latency or why not.
+func testConnectivity(cfg *Config, container Container) error {
+	networks, err := containerNetworks(container)
+	if err != nil {
+		return err
+	}
+	target := ""
+	if peers := peerNames(container, networks); len(peers) > 0 {
+		target = peers[0]
+	}
+
+	fields, ok, err := fillForm(cfg, tr("connect.title", container.Name), []formField{
+		{Label: tr("connect.target"), Value: target, Hint: tr("connect.targetHint")},
+		{Label: tr("connect.port"), Hint: tr("connect.portHint")},
+	})
+	if err != nil {
+		return err
+	}
+	target = strings.TrimSpace(fields[0].Value)
+	port := strings.TrimSpace(fields[1].Value)
+	if !ok || target == "" {
+		return nil
+	}
+
+	cmd := exec.Command("docker", "exec", container.ID, "sh", "-c", probeScript, "sh", target, port)
+	output, err := runWithSpinner(cmd)
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	result := lines[len(lines)-1]
+	// Ping prints its own round trip times.
+	if port == "" && len(lines) > 1 {
+		fmt.Println(strings.Join(lines[:len(lines)-1], "\n"))
+	}
+
+	destination := target
+	if port != "" {
+		destination += ":" + port
+	}
+	status, detail, _ := strings.Cut(result, " ")
+	switch {
+	case status == "OK" && detail != "":
+		fmt.Printf("✓ %s → %s: %s\n", container.Name, destination, tr("connect.reachedIn", detail))
+	case status == "OK":
+		fmt.Printf("✓ %s → %s: %s\n", container.Name, destination, tr("connect.reached"))
+	default:
+		fmt.Printf("✗ %s → %s: %s\n", container.Name, destination, probeFailure(detail))
+	}
+	return nil
+}
+
+// probeFailure turns the error of nc or bash into the likely cause.
+func probeFailure(detail string) string {
+	lower := strings.ToLower(detail)
+	switch {
+	case strings.Contains(lower, "bad address"), strings.Contains(lower, "not known"), strings.Contains(lower, "unknown host"), strings.Contains(lower, "name resolution"):
+		return tr("connect.dns", detail)
+	case strings.Contains(lower, "refused"):
+		return tr("connect.refused")
+	case strings.Contains(lower, "timed out"):
+		return tr("connect.timeout")
+	case strings.Contains(lower, "unreachable"):
+		return tr("connect.unreachable")
+	}
+	return strings.TrimSpace(detail)
+}
@@ -0,0 +1,788 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	sortNone = iota
+	sortCPU
+	sortMemory
+	sortSize
+)
+
+type containerChoice struct {
+	config            *Config
+	containers        []Container
+	cursor            int
+	selectedContainer Container
+	columns           []string
+	stats             map[string]containerStats
+	sortBy            int
+	picker            *columnPicker
+	width             int
+	fullValues        bool
+	showDetails       bool
+	logTail           logTailMsg
+
+	// shortcut is the action picked with a single key from the list, run
+	// on selectedContainer without going through the action menu.
+	shortcut   string
+	notice     string
+	lastAction string
+
+	// staleSince is when the containers shown were fetched, set while the
+	// daemon is unreachable.
+	staleSince   time.Time
+	reconnecting bool
+	refreshing   bool
+
+	// picking is set by `whale pick`, where the list only selects.
+	picking bool
+
+	// recent are the names of the recently used containers, grouped at the
+	// top of the list when it isn't sorted; recentShown is how many of them
+	// are in it.
+	recent      []string
+	recentShown int
+
+	// tabs is set when the list is the containers tab of the dashboard;
+	// switchTab is then the tab the user switched to.
+	tabs      bool
+	switchTab string
+
+	chord chord
+}
+
+func initialContainerModel(cfg *Config, containers []Container) containerChoice {
+	return containerChoice{
+		config:            cfg,
+		containers:        containers,
+		cursor:            len(containers) - 1,
+		selectedContainer: Container{},
+		columns:           configuredColumns(cfg),
+		showDetails:       cfg.List.ShowDetails,
+		stats:             map[string]containerStats{},
+		lastAction:        loadState().LastAction,
+		staleSince:        cachedAt,
+		reconnecting:      offline,
+	}
+}
+
+func (menu containerChoice) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	if menu.showStats() {
+		cmds = append(cmds, sampleStats())
+	}
+	if menu.showSizes() {
+		cmds = append(cmds, fetchSizes())
+	}
+	cmds = append(cmds, menu.refreshDetails(), scheduleAgeTick())
+	if offline {
+		cmds = append(cmds, scheduleReconnect())
+	}
+	return tea.Batch(cmds...)
+}
+
+// refreshDetails fetches the log preview of the container under the cursor,
+// and prefetches the inspect data of the rows around it.
+func (menu containerChoice) refreshDetails() tea.Cmd {
+	if len(menu.containers) == 0 {
+		return nil
+	}
+
+	var ids []string
+	for i := max(menu.cursor-2, 0); i <= min(menu.cursor+2, len(menu.containers)-1); i++ {
+		ids = append(ids, menu.containers[i].ID)
+	}
+	prefetch := prefetchInspect(ids)
+
+	if !menu.showDetails {
+		return prefetch
+	}
+	return tea.Batch(prefetch, fetchLogTail(menu.containers[menu.cursor].ID, max(menu.config.List.PreviewLines, 1)))
+}
+
+func (menu containerChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statsMsg:
+		if msg != nil {
+			menu.stats = msg
+			menu.sortContainers()
+		}
+		if offline {
+			break
+		}
+		if msg == nil {
+			return menu, tea.Batch(checkDaemon(), scheduleStats(menu.config))
+		}
+		if menu.showStats() {
+			return menu, scheduleStats(menu.config)
+		}
+	case statsTickMsg:
+		if menu.showStats() {
+			return menu, sampleStats()
+		}
+	case sizesMsg:
+		for i, container := range menu.containers {
+			if size, ok := msg[container.ID]; ok {
+				menu.containers[i].SizeRw = size.Rw
+				menu.containers[i].SizeRootFs = size.RootFs
+				menu.containers[i].HasSize = true
+			}
+		}
+		menu.sortContainers()
+	case daemonLostMsg:
+		if menu.reconnecting {
+			break
+		}
+		offline = true
+		menu.reconnecting = true
+		if menu.staleSince.IsZero() {
+			menu.staleSince = time.Now()
+		}
+		return menu, scheduleReconnect()
+	case reconnectTickMsg:
+		return menu, reconnect(menu.config)
+	case reconnectFailedMsg:
+		return menu, scheduleReconnect()
+	case ageTickMsg:
+		return menu, scheduleAgeTick()
+	case refreshFailedMsg:
+		menu.refreshing = false
+		menu.notice = tr("refresh.failed", msg.err)
+	case reloadMsg:
+		if menu.refreshing {
+			menu.refreshing = false
+			menu.notice = ""
+		}
+		offline = false
+		menu.reconnecting = false
+		menu.staleSince = time.Time{}
+		menu.containers = msg
+		menu.cursor = min(max(menu.cursor, 0), len(menu.containers)-1)
+		menu.sortContainers()
+		cmds := []tea.Cmd{menu.refreshDetails()}
+		if menu.showStats() {
+			cmds = append(cmds, sampleStats())
+		}
+		return menu, tea.Batch(cmds...)
+	case logTailMsg:
+		menu.logTail = msg
+		if msg.err != nil && !offline {
+			return menu, checkDaemon()
+		}
+	case tea.WindowSizeMsg:
+		menu.width = msg.Width
+	case tea.KeyMsg:
+		if menu.picker != nil {
+			return menu.updatePicker(msg)
+		}
+		menu.notice = ""
+		if target, ok := menu.chord.press(menu.config, msg.String()); ok {
+			return menu.runChord(target)
+		}
+		if tab := switchTab(msg.String(), "containers"); menu.tabs && tab != "" {
+			menu.switchTab = tab
+			return menu, tea.Quit
+		}
+		if menu.tabs && msg.String() == "ctrl+f" {
+			menu.switchTab = "search"
+			return menu, tea.Quit
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return menu, tea.Quit
+		case "f5", "ctrl+r":
+			if offline || menu.refreshing {
+				break
+			}
+			menu.refreshing = true
+			menu.notice = tr("refresh.running")
+			return menu, refreshContainerList(menu.config)
+		case "up":
+			menu.cursor--
+			if menu.cursor < 0 {
+				menu.cursor = len(menu.containers) - 1
+			}
+			return menu, menu.refreshDetails()
+		case "down":
+			menu.cursor++
+			if menu.cursor >= len(menu.containers) {
+				menu.cursor = 0
+			}
+			return menu, menu.refreshDetails()
+		case "p":
+			menu.showDetails = !menu.showDetails
+			return menu, menu.refreshDetails()
+		case "t":
+			if menu.showStats() {
+				menu.columns = withoutStatsColumns(menu.columns)
+				menu.resetSort()
+				break
+			}
+			menu.columns = withStatsColumns(menu.columns)
+			return menu, sampleStats()
+		case "f":
+			menu.fullValues = !menu.fullValues
+		case "e", "E":
+			format := "csv"
+			if msg.String() == "E" {
+				format = "markdown"
+			}
+			path, err := exportList(format, menu.cells(menu.columns, nil, true))
+			menu.notice = tr("list.exported", len(menu.containers), path)
+			if err != nil {
+				menu.notice = tr("list.exportFailed", err)
+			}
+		case "c":
+			menu.picker = newColumnPicker(menu.config, menu.columns)
+		case "o":
+			options := menu.sortOptions()
+			for i, option := range options {
+				if option == menu.sortBy {
+					menu.sortBy = options[(i+1)%len(options)]
+					break
+				}
+			}
+			menu.sortContainers()
+		case "enter":
+			if len(menu.containers) == 0 {
+				break
+			}
+			menu.selectedContainer = menu.containers[menu.cursor]
+			if action := menu.config.List.EnterAction; !menu.picking && action != "" && action != "menu" {
+				// The menu stays the fallback when the action can't run.
+				container := menu.selectedContainer
+				if offersAction(menu.config, container, action) && isActionAllowed(menu.config, action) && actionUnavailable(action, container) == "" {
+					menu.shortcut = action
+				}
+			}
+			return menu, tea.Quit
+		case "s", "r", "l", "d":
+			if len(menu.containers) == 0 || menu.picking {
+				break
+			}
+			return menu.runShortcut(msg.String())
+		case ".":
+			if len(menu.containers) == 0 || menu.picking || menu.lastAction == "" {
+				break
+			}
+			return menu.repeatAction()
+		case "m":
+			if menu.picking || !menu.tabs {
+				break
+			}
+			if recorder == nil {
+				recorder = &macroRecorder{}
+				menu.notice = tr("macro.started")
+				break
+			}
+			// The list leaves to ask the name of the macro.
+			menu.selectedContainer = Container{ID: "-"}
+			menu.shortcut = "saveMacro"
+			return menu, tea.Quit
+		case "M":
+			if len(menu.containers) == 0 || menu.picking {
+				break
+			}
+			return menu.quickAction(menu.containers[menu.cursor], "playMacro")
+		}
+	}
+
+	return menu, nil
+}
+
+// listShortcuts maps the keys of the list to the action they run, "s"
+// toggling between start and stop.
+var listShortcuts = map[string]string{
+	"r": "restart",
+	"l": "logs",
+	"d": "rm",
+}
+
+// runShortcut selects the container under the cursor with the action bound
+// to key.
+func (menu containerChoice) runShortcut(key string) (tea.Model, tea.Cmd) {
+	container := menu.containers[menu.cursor]
+	action := listShortcuts[key]
+	if key == "s" {
+		action = "start"
+		if container.State == "running" || container.State == "restarting" {
+			action = "stop"
+		}
+	}
+
+	return menu.quickAction(container, action)
+}
+
+// repeatAction runs the last action again, on the container under the
+// cursor, provided that container offers it.
+func (menu containerChoice) repeatAction() (tea.Model, tea.Cmd) {
+	container := menu.containers[menu.cursor]
+	action := menu.lastAction
+
+	if !offersAction(menu.config, container, action) {
+		menu.notice = tr("list.repeatUnavailable", actionLabel(menu.config, action), container.Name)
+		return menu, nil
+	}
+
+	return menu.quickAction(container, action)
+}
+
+// runChord goes to the tab a chord is bound to, or runs its action on the
+// container under the cursor.
+func (menu containerChoice) runChord(target string) (tea.Model, tea.Cmd) {
+	switch {
+	case target == "":
+		return menu, nil
+	case isDashboardTab(target):
+		if menu.tabs && target != "containers" {
+			menu.switchTab = target
+			return menu, tea.Quit
+		}
+		return menu, nil
+	case len(menu.containers) == 0 || menu.picking:
+		return menu, nil
+	}
+
+	container := menu.containers[menu.cursor]
+	if !offersAction(menu.config, container, target) {
+		menu.notice = tr("keys.unavailable", actionLabel(menu.config, target), container.Name)
+		return menu, nil
+	}
+	return menu.quickAction(container, target)
+}
+
+// offersAction reports whether action exists for container, in its menu or
+// as a list shortcut.
+func offersAction(cfg *Config, container Container, action string) bool {
+	if action == "start" || action == "stop" {
+		return true
+	}
+	for _, shortcut := range listShortcuts {
+		if shortcut == action {
+			return true
+		}
+	}
+	return containsString(initialActionModel(cfg, container).actions, action)
+}
+
+// quickAction selects action on container straight from the list, or
+// explains in the footer why it can't run.
+func (menu containerChoice) quickAction(container Container, action string) (tea.Model, tea.Cmd) {
+	if !isActionAllowed(menu.config, action) {
+		menu.notice = tr("permissions.denied", action)
+		return menu, nil
+	}
+	if reason := actionUnavailable(action, container); reason != "" {
+		menu.notice = fmt.Sprintf("%s: %s", actionLabel(menu.config, action), reason)
+		return menu, nil
+	}
+
+	menu.selectedContainer = container
+	menu.shortcut = action
+	return menu, tea.Quit
+}
+
+func (menu containerChoice) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu.picker.update(msg)
+	if !menu.picker.done {
+		return menu, nil
+	}
+
+	hadStats := menu.showStats()
+	hadSizes := menu.showSizes()
+	if selected := menu.picker.selected(); menu.picker.apply && len(selected) > 0 {
+		menu.columns = selected
+	}
+	menu.picker = nil
+	menu.resetSort()
+
+	var cmds []tea.Cmd
+	if menu.showStats() && !hadStats {
+		cmds = append(cmds, sampleStats())
+	}
+	if menu.showSizes() && !hadSizes {
+		cmds = append(cmds, fetchSizes())
+	}
+
+	return menu, tea.Batch(cmds...)
+}
+
+func (menu containerChoice) showStats() bool {
+	return hasStatsColumns(menu.columns)
+}
+
+func (menu containerChoice) showSizes() bool {
+	return containsString(menu.columns, "size")
+}
+
+// sortOptions are the orders available with the columns shown.
+func (menu containerChoice) sortOptions() []int {
+	options := []int{sortNone}
+	if menu.showStats() {
+		options = append(options, sortCPU, sortMemory)
+	}
+	if menu.showSizes() {
+		options = append(options, sortSize)
+	}
+	return options
+}
+
+// resetSort drops the sort order when its column was hidden.
+func (menu *containerChoice) resetSort() {
+	for _, option := range menu.sortOptions() {
+		if option == menu.sortBy {
+			return
+		}
+	}
+	menu.sortBy = sortNone
+}
+
+// sortContainers orders the list by the active stats column, heaviest first,
+// keeping the cursor on the same container.
+func (menu *containerChoice) sortContainers() {
+	if menu.sortBy == sortNone {
+		menu.groupRecent()
+		return
+	}
+	if len(menu.containers) == 0 {
+		return
+	}
+
+	current := menu.containers[menu.cursor].ID
+	sort.SliceStable(menu.containers, func(i, j int) bool {
+		a := menu.stats[shortID(menu.containers[i].ID)]
+		b := menu.stats[shortID(menu.containers[j].ID)]
+		switch menu.sortBy {
+		case sortCPU:
+			return a.CPU > b.CPU
+		case sortSize:
+			return menu.containers[i].SizeRw > menu.containers[j].SizeRw
+		}
+		return a.Memory > b.Memory
+	})
+
+	for i, container := range menu.containers {
+		if container.ID == current {
+			menu.cursor = i
+		}
+	}
+}
+
+func (menu containerChoice) View() string {
+	if menu.picker != nil {
+		return menu.picker.view()
+	}
+
+	s := newFrame()
+	s.WriteString(renderHeader(menu.config))
+	if menu.tabs {
+		s.WriteString(renderTabs(menu.config, "containers"))
+	}
+	if offline {
+		s.colored(tr("offline.banner", menu.staleSince.Local().Format("2006-01-02 15:04")), "33")
+		s.WriteString("\n\n")
+	}
+	s.WriteString(tr("list.title") + "\n\n")
+
+	rows := menu.rows()
+	s.line("  ", rows[0])
+	for i, row := range rows[1:] {
+		if sections := menu.sortBy == sortNone && menu.recentShown > 0; sections && i == 0 {
+			s.colored("  "+tr("list.recent"), "2")
+			s.WriteByte('\n')
+		} else if sections && i == menu.recentShown {
+			s.colored("  "+tr("list.others"), "2")
+			s.WriteByte('\n')
+		}
+		if menu.cursor == i {
+			s.line(renderCursor(menu.config), " ", renderContainerSelected(menu.config, row, true))
+		} else if color := rowColor(menu.config, menu.containers[i]); color != "" {
+			s.WriteString(statusMarker(menu.containers[i]) + " ")
+			s.colored(row, color)
+			s.WriteByte('\n')
+		} else {
+			s.line(statusMarker(menu.containers[i])+" ", row)
+		}
+	}
+
+	if len(menu.sortOptions()) > 1 {
+		s.line("\n", tr("list.sortFooter", sortLabel(menu.sortBy)))
+	}
+	if pending := menu.chord.footer(); pending != "" {
+		s.WriteByte('\n')
+		s.colored(pending, "2")
+		s.WriteByte('\n')
+	} else if !menu.picking {
+		shortcuts := tr("list.shortcuts")
+		if menu.lastAction != "" {
+			shortcuts += " · " + tr("list.repeat", actionLabel(menu.config, menu.lastAction))
+		}
+		if menu.tabs {
+			shortcuts += " · " + tr("tabs.help")
+		}
+		s.WriteByte('\n')
+		s.colored(shortcuts, "2")
+		s.WriteByte('\n')
+	}
+	if menu.notice != "" {
+		s.colored(menu.notice, "33")
+		s.WriteByte('\n')
+	}
+	if recorder != nil {
+		s.colored(tr("macro.recording", len(recorder.steps)), "31")
+		s.WriteByte('\n')
+	}
+
+	if menu.showDetails && len(menu.containers) > 0 {
+		s.line()
+		s.WriteString(renderDetails(menu.config, menu.containers[menu.cursor], menu.logTail, menu.width))
+	}
+
+	return s.done()
+}
+
+// rows formats a header followed by each container as aligned columns,
+// adapting the columns to the terminal width so lines never wrap.
+func (menu containerChoice) rows() []string {
+	if menu.width <= 0 {
+		return menu.table(menu.columns)
+	}
+
+	available := menu.width - 2
+	keys := menu.columns
+	if menu.width < menu.config.List.NarrowWidth {
+		keys = []string{"name", "status"}
+	}
+
+	rows := menu.table(keys)
+	for len(keys) > 1 && tableWidth(rows) > available {
+		keys = dropLastColumn(keys)
+		rows = menu.table(keys)
+	}
+
+	for i, row := range rows {
+		rows[i] = clipString(row, available)
+	}
+
+	return rows
+}
+
+func (menu containerChoice) table(keys []string) []string {
+	return alignColumns(menu.cells(keys, activeIcons(menu.config), menu.fullValues))
+}
+
+// cells returns the header and the values of each container for the
+// columns keys, led by the icons unless icons is nil.
+func (menu containerChoice) cells(keys []string, icons *iconSet, full bool) [][]string {
+	// Looked up once rather than for every cell.
+	columns := make([]column, len(keys))
+	header := make([]string, 0, len(keys)+1)
+	for i, key := range keys {
+		columns[i], _ = findColumn(key)
+		header = append(header, columns[i].title())
+	}
+
+	if icons != nil {
+		header = append([]string{""}, header...)
+	}
+
+	table := make([][]string, 0, len(menu.containers)+1)
+	table = append(table, header)
+	for _, container := range menu.containers {
+		stats, ok := menu.stats[shortID(container.ID)]
+
+		row := make([]string, 0, len(header))
+		if icons != nil {
+			row = append(row, icons.render(container))
+		}
+		for _, c := range columns {
+			row = append(row, c.Value(columnRow{
+				Container: container,
+				Stats:     stats,
+				HasStats:  ok,
+				Full:      full,
+
+				ImageWidth: menu.config.List.ImageWidth,
+			}))
+		}
+		table = append(table, row)
+	}
+
+	return table
+}
+
+// dropLastColumn removes the right-most column, keeping the name column for
+// last since it is the only one identifying containers to most users.
+func dropLastColumn(keys []string) []string {
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i] != "name" {
+			return append(append([]string{}, keys[:i]...), keys[i+1:]...)
+		}
+	}
+	return keys[:len(keys)-1]
+}
+
+func tableWidth(rows []string) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row))
+	}
+	return width
+}
+
+func clipString(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func alignColumns(table [][]string) []string {
+	var widths []int
+	for _, columns := range table {
+		for i, column := range columns {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(column))
+		}
+	}
+
+	total := 0
+	for _, width := range widths {
+		total += width + 2
+	}
+
+	rows := make([]string, len(table))
+	for i, columns := range table {
+		var b strings.Builder
+		b.Grow(total)
+		for j, column := range columns {
+			if j > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(column)
+			if j < len(columns)-1 {
+				for pad := widths[j] - utf8.RuneCountInString(column); pad > 0; pad-- {
+					b.WriteByte(' ')
+				}
+			}
+		}
+		rows[i] = b.String()
+	}
+
+	return rows
+}
+
+func sortLabel(sortBy int) string {
+	switch sortBy {
+	case sortCPU:
+		return tr("sort.cpu")
+	case sortMemory:
+		return tr("sort.memory")
+	case sortSize:
+		return tr("sort.size")
+	}
+	return tr("sort.none")
+}
+
+func chooseContainer(cfg *Config, containers []Container) (Container, error) {
+	if plainMode {
+		return chooseContainerPlain(cfg, containers)
+	}
+
+	menu, err := runContainerList(initialContainerModel(cfg, containers))
+	return menu.selectedContainer, err
+}
+
+// chooseContainerOrShortcut is the containers tab of the dashboard. It also
+// returns the action picked with a list shortcut, or "" when the container
+// was chosen with enter, and the tab the user switched to. The notice is
+// shown under the list, e.g. the outcome of the previous action.
+func chooseContainerOrShortcut(cfg *Config, containers []Container, notice string) (Container, string, string, error) {
+	if plainMode {
+		if notice != "" {
+			fmt.Println(notice)
+		}
+		container, err := chooseContainerPlain(cfg, containers)
+		return container, "", "", err
+	}
+
+	menu := initialContainerModel(cfg, containers)
+	menu.notice = notice
+	menu.tabs = true
+	if menu.config.List.Recent > 0 {
+		menu.recent = loadState().Recent
+		menu.groupRecent()
+		if menu.recentShown > 0 {
+			menu.cursor = 0
+		}
+	}
+	ids := make([]string, len(menu.containers))
+	for i, container := range menu.containers {
+		ids[i] = container.ID
+	}
+	menu.cursor = takeFocus(ids, menu.cursor)
+	menu, err := runContainerList(menu)
+	if err != nil {
+		return Container{}, "", "", err
+	}
+
+	return menu.selectedContainer, menu.shortcut, menu.switchTab, nil
+}
+
+func runContainerList(menu containerChoice) (containerChoice, error) {
+	stopWatching := watchInspectEvents()
+	finalModel, err := runProgram(menu)
+	stopWatching()
+	if err != nil {
+		return containerChoice{}, err
+	}
+
+	return finalModel.(containerChoice), nil
+}
+
+// LabelColorRule colors the rows of containers carrying a label, given as
+// "key=value" or just "key".
+type LabelColorRule struct {
+	Label string `json:"label"`
+	Color string `json:"color"`
+}
+
+// rowColor highlights containers needing attention, then applies the first
+// matching label rule, or returns "".
+func rowColor(cfg *Config, container Container) string {
+	if container.CrashLoop {
+		return cfg.Ui.CrashLoopColor
+	}
+	if container.failed() {
+		return cfg.Ui.ExitErrorColor
+	}
+	for _, rule := range cfg.List.LabelColors {
+		if container.hasLabel(rule.Label) {
+			return rule.Color
+		}
+	}
+	return ""
+}
+
+func renderContainerSelected(cfg *Config, container string, isSelected bool) string {
+	if isSelected {
+		return fmt.Sprintf("\033[%sm%s\033[0m", paletteColor(cfg.Ui.ContainerSelectedColor), container)
+	}
+	return container
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BenchmarkListView draws a list of 1000 containers while moving the
+// cursor, a frame per iteration: what keeps scrolling smooth on big hosts.
+func BenchmarkListView(b *testing.B) {
+	useConfig(defaultConfig())
+	containers := make([]Container, 1000)
+	for i := range containers {
+		containers[i] = Container{
+			ID:     fmt.Sprintf("%012x", i*7919),
+			Name:   fmt.Sprintf("service-%04d", i),
+			Image:  "registry.example.com/team/app:1.2.3",
+			Status: "Up 3 hours",
+			State:  "running",
+			Ports:  "0.0.0.0:8080->80/tcp",
+			Labels: map[string]string{},
+		}
+	}
+
+	var model tea.Model = initialContainerModel(defaultConfig(), containers)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model, _ = model.Update(up)
+		_ = model.View()
+	}
+}
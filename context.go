@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// HostProfile overrides settings when whale talks to a given Docker context
+// or DOCKER_HOST, so e.g. production engines can be told apart at a glance.
+type HostProfile struct {
+	Label                  string      `json:"label"`
+	HeaderColor            string      `json:"headerColor"`
+	CursorColor            string      `json:"cursorColor"`
+	ContainerSelectedColor string      `json:"containerSelectedColor"`
+	ActionSelectedColor    string      `json:"actionSelectedColor"`
+	ReadOnly               bool        `json:"readOnly"`
+	Actions                ActionRules `json:"actions"`
+}
+
+var activeHost string
+
+// currentDockerContext resolves the engine the docker CLI will talk to, the
+// same way the CLI does: DOCKER_HOST, then DOCKER_CONTEXT, then the current
+// context of the CLI config.
+func currentDockerContext() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	if context := os.Getenv("DOCKER_CONTEXT"); context != "" {
+		return context
+	}
+
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return "default"
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return "default"
+	}
+
+	var cliConfig struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if json.Unmarshal(data, &cliConfig) != nil || cliConfig.CurrentContext == "" {
+		return "default"
+	}
+
+	return cliConfig.CurrentContext
+}
+
+// dockerConfigDir is where the docker CLI keeps its config.json.
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// applyHostProfile returns cfg with the profile of the active context and
+// the --read-only flag merged into it, leaving cfg itself as it was.
+func applyHostProfile(cfg *Config) *Config {
+	activeHost = currentDockerContext()
+
+	merged := *cfg
+	merged.readOnly = readOnly
+	profile, ok := cfg.Hosts[activeHost]
+	if !ok {
+		return &merged
+	}
+	merged.host = profile
+	merged.readOnly = readOnly || profile.ReadOnly
+
+	if profile.CursorColor != "" {
+		merged.Ui.CursorColor = profile.CursorColor
+	}
+	if profile.ContainerSelectedColor != "" {
+		merged.Ui.ContainerSelectedColor = profile.ContainerSelectedColor
+	}
+	if profile.ActionSelectedColor != "" {
+		merged.Ui.ActionSelectedColor = profile.ActionSelectedColor
+	}
+	return &merged
+}
+
+// renderHeader returns the banner naming the active host when its profile
+// sets a label or a header color or when whale is read-only, or "" otherwise.
+func renderHeader(cfg *Config) string {
+	banner := ""
+	if pinnedAPIVersion != "" {
+		banner = renderColor(tr("api.pinned", pinnedAPIVersion), "33") + "\n\n"
+	}
+
+	age, stale := dataAge()
+	if stale {
+		age = renderColor(age, "33")
+	}
+
+	if cfg.host.Label == "" && cfg.host.HeaderColor == "" && !cfg.readOnly {
+		if stale {
+			return age + "\n\n" + banner
+		}
+		return banner
+	}
+
+	label := cfg.host.Label
+	if label == "" {
+		label = activeHost
+	}
+
+	header := tr("header.host", label)
+	if cfg.readOnly {
+		header += "  " + tr("header.readOnly")
+	}
+
+	header = renderColor(" "+header+" ", cfg.host.HeaderColor)
+	if age != "" {
+		header += "  " + age
+	}
+	return header + "\n\n" + banner
+}
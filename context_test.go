@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+// TestApplyHostProfile checks that the action rules and read-only setting
+// of the active host reach the config, and only the returned one.
+func TestApplyHostProfile(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "ssh://prod")
+	cfg := defaultConfig()
+	cfg.Hosts = map[string]HostProfile{"ssh://prod": {ReadOnly: true, Actions: ActionRules{Disabled: []string{"logs"}}}}
+
+	merged := applyHostProfile(cfg)
+	for _, action := range []string{"logs", "stop"} {
+		if isActionAllowed(merged, action) {
+			t.Errorf("%s allowed on the read-only host", action)
+		}
+		if !isActionAllowed(cfg, action) {
+			t.Errorf("%s refused by the config the profile was merged from", action)
+		}
+	}
+	if !isActionAllowed(merged, "inspect") {
+		t.Error("inspect refused on the read-only host")
+	}
+}
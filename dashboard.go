@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// dashboardTabs are the resources the interactive mode switches between,
+// with 1-4 or tab/shift+tab, in order.
+var dashboardTabs = []string{"containers", "images", "volumes", "networks"}
+
+func isDashboardTab(name string) bool {
+	return containsString(dashboardTabs, name)
+}
+
+// switchTab returns the tab key moves to from current, or "" when the key
+// doesn't switch tabs.
+func switchTab(key string, current string) string {
+	index := 0
+	for i, tab := range dashboardTabs {
+		if tab == current {
+			index = i
+		}
+	}
+
+	switch key {
+	case "tab":
+		return dashboardTabs[(index+1)%len(dashboardTabs)]
+	case "shift+tab":
+		return dashboardTabs[(index+len(dashboardTabs)-1)%len(dashboardTabs)]
+	}
+
+	number, err := strconv.Atoi(key)
+	if err != nil || number < 1 || number > len(dashboardTabs) || number-1 == index {
+		return ""
+	}
+	return dashboardTabs[number-1]
+}
+
+func renderTabs(cfg *Config, current string) string {
+	var tabs []string
+	for i, tab := range dashboardTabs {
+		label := fmt.Sprintf("%d %s", i+1, tr("tabs."+tab))
+		if tab == current {
+			tabs = append(tabs, renderActionSelected(cfg, "["+label+"]", true))
+		} else {
+			tabs = append(tabs, renderColor(" "+label+" ", "2"))
+		}
+	}
+	return strings.Join(tabs, " ") + "\n\n"
+}
+
+// dashboard is the interactive mode: the list of a tab, then an action on
+// the chosen item, coming back to the tab, showing notice first, until the
+// user quits.
+func dashboard(containers []Container, tab string, notice string) {
+	from := tab
+	for tab != "" {
+		if tab == "search" {
+			tab = searchTab(from)
+			continue
+		}
+		from = tab
+
+		if tab == "containers" {
+			tab, containers, notice = containersTab(containers, notice)
+			continue
+		}
+
+		tab, notice = resourceTab(tab, notice)
+		if tab == "containers" && !offline {
+			// Actions on images can create containers.
+			containers = refreshContainers(currentConfig())
+		}
+	}
+}
+
+func containersTab(containers []Container, notice string) (string, []Container, string) {
+	cfg := currentConfig()
+	container, actionSelected, next, err := chooseContainerOrShortcut(cfg, containers, notice)
+	if err != nil {
+		println(tr("error.chooseContainer"), err)
+		os.Exit(1)
+	}
+
+	if next != "" {
+		return next, containers, ""
+	}
+	if container.ID == "" {
+		return "", containers, ""
+	}
+
+	if actionSelected == "saveMacro" {
+		return "containers", containers, stopRecording(cfg)
+	}
+	if actionSelected == "" {
+		actionSelected, err = chooseAction(cfg, container)
+		if err != nil {
+			println(tr("error.chooseAction"), err)
+			os.Exit(1)
+		}
+	}
+
+	return containerAction(cfg, container, actionSelected, containers)
+}
+
+// containerAction runs the action chosen on container and returns where
+// the dashboard goes next, like containersTab.
+func containerAction(cfg *Config, container Container, actionSelected string, containers []Container) (string, []Container, string) {
+	rememberAction(cfg, container, actionSelected)
+	err := doAction(cfg, actionSelected, container)
+	recordAction(actionSelected, "container", container.Name, err)
+	if err == nil && recorder != nil {
+		recorder.add(actionSelected)
+	}
+	if !returnsToList(actionSelected) {
+		if err != nil {
+			println(tr("error.doAction"), err)
+			if hint := errorHint(err, containers); hint != "" {
+				println("→ " + hint)
+			}
+			os.Exit(1)
+		}
+		return "", containers, ""
+	}
+
+	// Managing containers is a loop: show the list again, refreshed, with
+	// the outcome of the action.
+	notice := ""
+	if err != nil && !errors.Is(err, errCancelled) {
+		notice = errorNotice(container.Name, err, containers)
+	} else if command, ok := lifecycleCommands[actionSelected]; ok {
+		notice = fmt.Sprintf("✓ %s: %s", container.Name, tr("lifecycle.done."+command))
+	}
+
+	if !offline {
+		containers = refreshContainers(cfg)
+	}
+	return "containers", containers, notice
+}
+
+func refreshContainers(cfg *Config) []Container {
+	containers, err := getContainers(cfg)
+	if err != nil {
+		println(tr("error.getContainers"), err)
+		os.Exit(1)
+	}
+	cacheContainers(containers)
+	if matches := findContainers(containers, listQuery); listQuery != "" && len(matches) > 0 {
+		return matches
+	}
+	return containers
+}
+
+// listQuery is the name given to `whale NAME` when it matched several
+// containers, the list then only shows those.
+var listQuery string
+
+// jumpCommand implements `whale NAME`: the action menu of the container
+// NAME designates when it is the only one, without going through the list,
+// else the list of the containers it matches. Either way, the dashboard
+// goes on from there.
+func jumpCommand(containers []Container, query string) {
+	cfg := currentConfig()
+	matches := findContainers(containers, query)
+	switch len(matches) {
+	case 0:
+		println(tr("match.none", query))
+		os.Exit(1)
+	case 1:
+		container := matches[0]
+		actionSelected, err := chooseAction(cfg, container)
+		if err != nil {
+			println(tr("error.chooseAction"), err)
+			os.Exit(1)
+		}
+		if actionSelected == "" {
+			return
+		}
+		tab, containers, notice := containerAction(cfg, container, actionSelected, containers)
+		dashboard(containers, tab, notice)
+	default:
+		listQuery = query
+		dashboard(matches, "containers", "")
+	}
+}
+
+// resourceTab shows the images, volumes or networks tab once and runs the
+// chosen action, returning the next tab and the outcome to show in it.
+func resourceTab(tab string, notice string) (string, string) {
+	switch tab {
+	case "images":
+		return imagesTab(notice)
+	case "volumes":
+		return volumesTab(notice)
+	case "networks":
+		return networksTab(notice)
+	}
+	return "", ""
+}
+
+// chooseInTab returns the chosen row, or -1, and the tab the user switched
+// to instead. keys identify the rows, for the search to open the tab on
+// one of them.
+func chooseInTab(cfg *Config, tab string, title string, header string, items []string, keys []string, notice string) (int, string, error) {
+	cursor := takeFocus(keys, 0)
+	if plainMode {
+		if notice != "" {
+			fmt.Println(notice)
+		}
+		choice, err := choosePlain(title, header, items)
+		return choice, "", err
+	}
+
+	menu := initialListModel(cfg, title, header, items)
+	menu.tab = tab
+	menu.notice = notice
+	menu.cursor = cursor
+	finalModel, err := runProgram(menu)
+	if err != nil {
+		return -1, "", err
+	}
+
+	menu = finalModel.(listChoice)
+	return menu.selected, menu.switchTab, nil
+}
+
+// chooseResourceAction is the action menu of an image, volume or network.
+func chooseResourceAction(cfg *Config, title string, actions []string) (string, error) {
+	actions = allowedActions(cfg, actions)
+
+	var labels []string
+	for _, action := range actions {
+		labels = append(labels, actionLabel(cfg, action))
+	}
+
+	choice, err := chooseFromList(cfg, title, "", labels)
+	if err != nil || choice < 0 {
+		return "", err
+	}
+
+	return actions[choice], nil
+}
+
+// errCancelled is returned by tab actions the user declined to confirm.
+var errCancelled = errors.New("cancelled")
+
+// tabActions are the actions after which the tab opens again, with their
+// outcome as a tabs.done notice.
+var tabActions = []string{"pullImage", "tagImage", "removeImage", "inspectVolume", "browseVolume", "backupVolume", "restoreVolume", "removeVolume", "removeOrphanVolumes", "pruneVolumes", "inspectNetwork", "createNetwork", "removeNetwork", "pruneNetworks"}
+
+// returnsToTab reports the actions after which the tab opens again; the
+// others print their result and leave whale, like outside returnsToList.
+func returnsToTab(action string) bool {
+	return action == "" || containsString(tabActions, action)
+}
+
+// tabOutcome runs the action chosen in a tab on the item called name and
+// returns the next tab and the notice to show in it, or exits when the action
+// doesn't come back to the tab.
+func tabOutcome(tab string, name string, action string, run func() error) (string, string) {
+	err := run()
+	recordAction(action, strings.TrimSuffix(tab, "s"), name, err)
+	if !returnsToTab(action) {
+		if err != nil {
+			println(tr("error.doAction"), err)
+			os.Exit(1)
+		}
+		return "", ""
+	}
+
+	switch {
+	case action == "", errors.Is(err, errCancelled):
+		return tab, ""
+	case err != nil:
+		return tab, errorNotice(name, err, nil)
+	}
+	return tab, fmt.Sprintf("✓ %s: %s", name, tr("tabs.done."+action))
+}
+
+// confirmRemoval asks y/N before removing a single resource.
+func confirmRemoval(name string) bool {
+	fmt.Print(tr("remove.prompt", name))
+	answer, _ := plainInput.ReadString('\n')
+	return isYes(answer)
+}
@@ -0,0 +1,15 @@
+package main
+
+import "testing"
+
+// TestTabNotices checks that every action coming back to its tab has the
+// notice it is reported with, in every language.
+func TestTabNotices(t *testing.T) {
+	for language, catalog := range catalogs {
+		for _, action := range tabActions {
+			if _, ok := catalog["tabs.done."+action]; !ok {
+				t.Errorf("%s: no tabs.done.%s", language, action)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// dockerTimeLayouts are the ways docker-compatible CLIs print times in
+// their JSON output: Go's time.String() for docker, with fractional seconds
+// or a numeric zone for podman and nerdctl, and RFC 3339 for inspect.
+var dockerTimeLayouts = []string{
+	"2006-01-02 15:04:05 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05 -0700 -0700",
+	"2006-01-02 15:04:05.999999999 -0700 -0700",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+}
+
+// parseDockerTime parses a time printed by the CLI, trying the layouts of
+// docker.timeLayouts first. The monotonic suffix time.String() may add
+// ("m=+0.001") is dropped.
+func parseDockerTime(cfg *Config, s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range append(cfg.Docker.TimeLayouts, dockerTimeLayouts...) {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
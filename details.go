@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abroudoux/whale/pkg/whale"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type logTailMsg struct {
+	id    string
+	lines []string
+	err   error
+}
+
+// fetchLogTail reads the last lines of a container's logs for the preview.
+func fetchLogTail(id string, lines int) tea.Cmd {
+	return func() tea.Msg {
+		output, err := readLogs(id, whale.LogOptions{Tail: lines})
+		if err != nil {
+			return logTailMsg{id: id, err: err}
+		}
+
+		text := strings.TrimRight(string(output), "\n")
+		if text == "" {
+			return logTailMsg{id: id}
+		}
+		return logTailMsg{id: id, lines: strings.Split(text, "\n")}
+	}
+}
+
+// renderDetails draws the panel under the container list.
+func renderDetails(cfg *Config, container Container, logs logTailMsg, width int) string {
+	dim := func(s string) string { return renderColor(s, "2") }
+
+	s := dim(strings.Repeat("─", max(min(width, 80), 20))) + "\n"
+	s += fmt.Sprintf("%s  %s  %s\n", container.Name, container.Image, container.Status)
+	if note := noteFor(container); note != "" {
+		s += renderColor(tr("details.note", note), "36") + "\n"
+	}
+	if container.Ports != "" {
+		s += fmt.Sprintf("%s %s\n", tr("details.ports"), container.Ports)
+	}
+	if container.HasSize {
+		s += tr("details.size", formatBytes(container.SizeRw), formatBytes(container.SizeRootFs)) + "\n"
+	}
+	if isDevcontainer(container) {
+		s += tr("details.devcontainer", container.Labels[devcontainerFolderLabel]) + "\n"
+	}
+	if container.GPUs != "" {
+		s += tr("details.gpus", container.GPUs) + "\n"
+	} else if strings.Contains(container.Runtime, "nvidia") {
+		s += tr("details.gpuRuntime", container.Runtime) + "\n"
+	}
+	for _, bind := range container.MissingBinds {
+		s += renderColor(tr("details.missingBind", bind), "33") + "\n"
+	}
+	if container.Emulated {
+		s += renderColor(tr("details.emulated", container.Platform, hostArchitecture), "33") + "\n"
+	}
+	if container.isExited() {
+		line := tr("actions.exited", formatExit(container))
+		if container.failed() {
+			line = renderColor(line, cfg.Ui.ExitErrorColor)
+		}
+		s += line + "\n"
+	}
+
+	s += "\n"
+	switch {
+	case logs.id != container.ID:
+		s += dim(tr("details.loading")) + "\n"
+	case logs.err != nil:
+		s += dim(tr("details.logsError", logs.err)) + "\n"
+	case len(logs.lines) == 0:
+		s += dim(tr("details.noLogs")) + "\n"
+	default:
+		for _, line := range logs.lines {
+			if width > 0 {
+				line = clipString(line, width)
+			}
+			s += line + "\n"
+		}
+	}
+
+	return s
+}
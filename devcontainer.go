@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Labels set by the Dev Containers tooling (VS Code, the devcontainer CLI).
+const (
+	devcontainerFolderLabel   = "devcontainer.local_folder"
+	devcontainerMetadataLabel = "devcontainer.metadata"
+)
+
+// devcontainerMetadata is the merged subset of the devcontainer.json entries
+// stored in the metadata label.
+type devcontainerMetadata struct {
+	RemoteUser    string
+	ContainerUser string
+	ForwardPorts  []string
+}
+
+func isDevcontainer(container Container) bool {
+	return container.Labels[devcontainerFolderLabel] != ""
+}
+
+// parseDevcontainerMetadata merges the label's array of devcontainer.json
+// fragments, later entries winning like the tooling does.
+func parseDevcontainerMetadata(label string) devcontainerMetadata {
+	var metadata devcontainerMetadata
+
+	var entries []struct {
+		RemoteUser    string `json:"remoteUser"`
+		ContainerUser string `json:"containerUser"`
+		ForwardPorts  []any  `json:"forwardPorts"`
+	}
+	if json.Unmarshal([]byte(label), &entries) != nil {
+		return metadata
+	}
+
+	for _, entry := range entries {
+		if entry.RemoteUser != "" {
+			metadata.RemoteUser = entry.RemoteUser
+		}
+		if entry.ContainerUser != "" {
+			metadata.ContainerUser = entry.ContainerUser
+		}
+		for _, port := range entry.ForwardPorts {
+			metadata.ForwardPorts = append(metadata.ForwardPorts, fmt.Sprint(port))
+		}
+	}
+	return metadata
+}
+
+// getDevcontainerMetadata reads the full metadata label, which the `ls`
+// label summary truncates.
+func getDevcontainerMetadata(container Container) devcontainerMetadata {
+	output, err := cachedInspect(container.ID)
+	if err != nil {
+		return devcontainerMetadata{}
+	}
+
+	var inspect struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"Config"`
+	}
+	if json.Unmarshal(output, &inspect) != nil {
+		return devcontainerMetadata{}
+	}
+	return parseDevcontainerMetadata(inspect.Config.Labels[devcontainerMetadataLabel])
+}
+
+// devcontainerWorkspace returns where the local folder is mounted in the
+// container, or "" to let the shell start in the default directory.
+func devcontainerWorkspace(container Container) string {
+	output, err := cachedInspect(container.ID)
+	if err != nil {
+		return ""
+	}
+
+	var inspect struct {
+		Mounts []struct {
+			Source      string `json:"Source"`
+			Destination string `json:"Destination"`
+		} `json:"Mounts"`
+	}
+	if json.Unmarshal(output, &inspect) != nil {
+		return ""
+	}
+
+	folder := container.Labels[devcontainerFolderLabel]
+	for _, mount := range inspect.Mounts {
+		if mount.Source == folder {
+			return mount.Destination
+		}
+	}
+	return ""
+}
+
+// openDevcontainerShell opens an interactive shell as the user the editor
+// would use, in the workspace folder.
+func openDevcontainerShell(container Container) error {
+	metadata := getDevcontainerMetadata(container)
+
+	args := []string{"exec", "-it"}
+	user := metadata.RemoteUser
+	if user == "" {
+		user = metadata.ContainerUser
+	}
+	if user != "" {
+		args = append(args, "-u", user)
+	}
+	if workspace := devcontainerWorkspace(container); workspace != "" {
+		args = append(args, "-w", workspace)
+	}
+	args = append(args, container.ID, "sh", "-c", "command -v bash >/dev/null && exec bash -l || exec sh -l")
+
+	cmd := exec.Command("docker", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func printDevcontainerPorts(container Container) {
+	metadata := getDevcontainerMetadata(container)
+
+	fmt.Println(tr("devcontainer.portsTitle", container.Name, container.Labels[devcontainerFolderLabel]))
+	if len(metadata.ForwardPorts) == 0 && container.Ports == "" {
+		fmt.Println(tr("devcontainer.noPorts"))
+		return
+	}
+	for _, port := range metadata.ForwardPorts {
+		fmt.Println(tr("devcontainer.forwarded", port))
+	}
+	if container.Ports != "" {
+		fmt.Println(tr("devcontainer.published", container.Ports))
+	}
+}
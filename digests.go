@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// startRecord is the image a container was started from, kept in the state
+// the first time whale sees it running so that a tag moving later can be
+// told apart from the image the container actually runs.
+type startRecord struct {
+	Name      string    `json:"name"`
+	Reference string    `json:"reference"`
+	ImageID   string    `json:"imageId"`
+	Digest    string    `json:"digest,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// startInspect is the subset of `docker container inspect` a start record
+// is made of.
+type startInspect struct {
+	Name   string `json:"Name"`
+	Image  string `json:"Image"`
+	Config struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+	State struct {
+		Running   bool      `json:"Running"`
+		StartedAt time.Time `json:"StartedAt"`
+	} `json:"State"`
+}
+
+// inspectStart builds the start record of a running container, with the
+// registry digest of its image when it was pulled rather than built here.
+func inspectStart(id string) (startRecord, bool) {
+	output, err := dockerRead("container", "inspect", "--format", "{{json .}}", id)
+	if err != nil {
+		return startRecord{}, false
+	}
+	var inspect startInspect
+	if json.Unmarshal(output, &inspect) != nil || !inspect.State.Running {
+		return startRecord{}, false
+	}
+
+	record := startRecord{
+		Name:      strings.TrimPrefix(inspect.Name, "/"),
+		Reference: inspect.Config.Image,
+		ImageID:   inspect.Image,
+		StartedAt: inspect.State.StartedAt,
+	}
+	if image, err := inspectImage(inspect.Image); err == nil {
+		record.Digest = repoDigest(image.RepoDigests, record.Reference)
+	}
+	return record, true
+}
+
+// repoDigest picks the digest of reference's repository among the repo
+// digests of an image, as they read "repository@sha256:...".
+func repoDigest(digests []string, reference string) string {
+	repository := repositoryName(reference)
+	for _, digest := range digests {
+		name, hash, _ := strings.Cut(digest, "@")
+		if name == repository || repositoryName(name) == repository {
+			return hash
+		}
+	}
+	return ""
+}
+
+// repositoryName is imageName without the default registry, which docker
+// leaves out of the names of its repo digests.
+func repositoryName(reference string) string {
+	name := strings.TrimPrefix(imageName(reference), "docker.io/")
+	return strings.TrimPrefix(name, "library/")
+}
+
+// recordStart remembers the image a container was just started from, for
+// `whale watch` which sees every start.
+func recordStart(id string) {
+	record, ok := inspectStart(id)
+	if !ok {
+		return
+	}
+	s := loadState()
+	if s.Starts == nil {
+		s.Starts = map[string]startRecord{}
+	}
+	s.Starts[id] = record
+	saveState(s)
+}
+
+// registryDigest is the digest a tag points to in the registry right now.
+func registryDigest(reference string) (string, error) {
+	output, err := dockerRead("buildx", "imagetools", "inspect", reference, "--format", "{{json .Manifest}}")
+	if err != nil {
+		return "", err
+	}
+	var manifest struct {
+		Digest string `json:"digest"`
+	}
+	err = json.Unmarshal(output, &manifest)
+	if err != nil || manifest.Digest == "" {
+		return "", fmt.Errorf("no manifest digest for %s", reference)
+	}
+	return manifest.Digest, nil
+}
+
+// digestStatus compares what a container was started from with where its
+// tag points now, locally and in the registry. moved is set when the tag
+// no longer points to the image the container runs.
+func digestStatus(record startRecord) (status string, moved bool) {
+	if strings.Contains(record.Reference, "@") {
+		return tr("digests.pinned"), false
+	}
+
+	if local, err := inspectImage(record.Reference); err == nil && local.ID != record.ImageID {
+		return tr("digests.pulledLocally", shortDigest(local.ID)), true
+	}
+	if record.Digest == "" {
+		return tr("digests.noDigest"), false
+	}
+
+	current, err := registryDigest(record.Reference)
+	if err != nil {
+		return tr("digests.unreachable"), false
+	}
+	if current != record.Digest {
+		return tr("digests.moved", shortDigest(current)), true
+	}
+	return tr("digests.unchanged"), false
+}
+
+// digestsCommand implements `whale digests`: records the digest each running
+// container was started from, then warns about the tags that moved under
+// them in the registry since. It exits with 1 when one did, for cron jobs.
+func digestsCommand(containers []Container) {
+	cfg := currentConfig()
+	requireAction(cfg, "digests")
+	s := loadState()
+	starts := map[string]startRecord{}
+
+	var records []startRecord
+	for _, container := range containers {
+		if container.State != "running" {
+			continue
+		}
+		record, ok := s.Starts[container.ID]
+		if !ok || (!container.StartedAt.IsZero() && !record.StartedAt.Equal(container.StartedAt)) {
+			record, ok = inspectStart(container.ID)
+			if !ok {
+				continue
+			}
+		}
+		starts[container.ID] = record
+		records = append(records, record)
+	}
+
+	// Containers gone since are forgotten along the way.
+	s.Starts = starts
+	err := saveState(s)
+	if err != nil {
+		warn(tr("digests.saveFailed", err))
+	}
+
+	if len(records) == 0 {
+		fmt.Println(tr("digests.none"))
+		return
+	}
+
+	anyMoved := false
+	table := [][]string{{tr("column.name"), tr("column.image"), tr("digests.startedFrom"), tr("column.status")}}
+	for _, record := range records {
+		status, moved := digestStatus(record)
+		if moved {
+			anyMoved = true
+			status = "⚠ " + status
+		}
+		table = append(table, []string{record.Name, truncateImage(record.Reference, cfg.List.ImageWidth), orNone(shortDigest(record.Digest)), status})
+	}
+
+	rows := alignColumns(table)
+	fmt.Println(rows[0])
+	for i, row := range rows[1:] {
+		if strings.Contains(table[i+1][3], "⚠") {
+			row = renderColor(row, "33")
+		}
+		fmt.Println(row)
+	}
+
+	if anyMoved {
+		fmt.Println()
+		fmt.Println(tr("digests.movedHint"))
+		os.Exit(1)
+	}
+}
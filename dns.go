@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// embeddedDNS is the resolver docker runs for user-defined networks, which
+// answers for container and service names.
+const embeddedDNS = "127.0.0.11"
+
+// dnsScript prints the resolver config, then looks each name given as an
+// argument up with the tools the image happens to have.
+const dnsScript = `echo "== /etc/resolv.conf"; cat /etc/resolv.conf; echo
+for name in "$@"; do
+	echo "== $name"
+	found=
+	if command -v getent >/dev/null 2>&1; then found=1; echo "-- getent hosts"; getent hosts "$name" || echo "(no answer)"; fi
+	if command -v nslookup >/dev/null 2>&1; then found=1; echo "-- nslookup $name ` + embeddedDNS + `"; nslookup "$name" ` + embeddedDNS + ` 2>&1; fi
+	if command -v dig >/dev/null 2>&1; then found=1; echo "-- dig @` + embeddedDNS + `"; dig +short @` + embeddedDNS + ` "$name" 2>&1; fi
+	[ -n "$found" ] || echo "(no getent, nslookup or dig in this image)"
+	echo
+done`
+
+// containerNetwork is an entry of NetworkSettings.Networks.
+type containerNetwork struct {
+	NetworkID string   `json:"NetworkID"`
+	Aliases   []string `json:"Aliases"`
+	DNSNames  []string `json:"DNSNames"`
+	IPAddress string   `json:"IPAddress"`
+}
+
+func containerNetworks(container Container) (map[string]containerNetwork, error) {
+	output, err := dockerRead("container", "inspect", "--format", "{{json .NetworkSettings.Networks}}", container.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := map[string]containerNetwork{}
+	err = json.Unmarshal(output, &networks)
+	return networks, err
+}
+
+// peerNames are the names the container should be able to resolve: the
+// other containers of its user-defined networks and their aliases, compose
+// service names included. The default bridge has no embedded DNS, so its
+// peers are left out.
+func peerNames(container Container, networks map[string]containerNetwork) []string {
+	seen := map[string]bool{}
+	var names []string
+	for name, network := range networks {
+		if containsString(builtinNetworks, name) {
+			continue
+		}
+
+		output, err := dockerRead("network", "inspect", "--format", "{{range .Containers}}{{.Name}} {{end}}", network.NetworkID)
+		if err != nil {
+			continue
+		}
+		for _, peer := range strings.Fields(string(output)) {
+			if peer != container.Name && !seen[peer] {
+				seen[peer] = true
+				names = append(names, peer)
+			}
+		}
+	}
+
+	if service := container.Labels["com.docker.compose.service"]; service != "" && !seen[service] {
+		names = append(names, service)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// debugDNS looks up the peers of the container, and the names the user adds,
+// from inside it, to tell whether service names resolve.
+func debugDNS(cfg *Config, container Container) error {
+	networks, err := containerNetworks(container)
+	if err != nil {
+		return err
+	}
+
+	userDefined := false
+	for name := range networks {
+		if !containsString(builtinNetworks, name) {
+			userDefined = true
+		}
+	}
+
+	fields, ok, err := fillForm(cfg, tr("dns.title", container.Name), []formField{
+		{Label: tr("dns.names"), Value: strings.Join(append(peerNames(container, networks), "example.com"), ", "), Hint: tr("dns.namesHint")},
+	})
+	if err != nil {
+		return err
+	}
+	names := splitList(fields[0].Value)
+	if !ok || len(names) == 0 {
+		return nil
+	}
+
+	title := tr("dns.title", container.Name)
+	if !userDefined {
+		title += " " + renderColor(tr("dns.defaultBridge"), "33")
+	}
+	args := append([]string{"exec", container.ID, "sh", "-c", dnsScript, "sh"}, names...)
+	return runStream(cfg, title, exec.Command("docker", args...))
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+type Container struct {
+	ID      string
+	Image   string
+	Command string
+	Created string
+	Status  string
+	State   string
+	Ports   string
+	Name    string
+	Labels  map[string]string
+
+	// CreatedAt is zero when the CLI printed it in a layout whale doesn't
+	// know, see docker.timeLayouts.
+	CreatedAt    time.Time
+	RestartCount int
+	StartedAt    time.Time
+	CrashLoop    bool
+	ExitCode     int
+	FinishedAt   time.Time
+	Platform     string
+	Emulated     bool
+	GPUs         string
+	Runtime      string
+	Healthcheck  bool
+
+	// SizeRw is the writable layer and SizeRootFs the whole filesystem,
+	// known once HasSize is set by the size column.
+	SizeRw     int64
+	SizeRootFs int64
+	HasSize    bool
+
+	// MissingBinds are the bind mounts whose host path doesn't exist, as
+	// "source → destination".
+	MissingBinds []string
+}
+
+func (container Container) isExited() bool {
+	return container.State == "exited" || container.State == "dead"
+}
+
+// failed reports exited containers whose last run ended with an error.
+func (container Container) failed() bool {
+	return container.isExited() && container.ExitCode != 0
+}
+
+// hasLabel matches a "key=value" or "key" label selector.
+func (container Container) hasLabel(selector string) bool {
+	key, value, hasValue := strings.Cut(selector, "=")
+	actual, ok := container.Labels[key]
+	return ok && (!hasValue || actual == value)
+}
+
+const composeProjectLabel = "com.docker.compose.project"
+
+// containerInspect is the subset of `docker container inspect` that the list
+// needs on top of `docker container ls`.
+type containerInspect struct {
+	ID           string `json:"Id"`
+	Image        string `json:"Image"`
+	RestartCount int    `json:"RestartCount"`
+	State        struct {
+		Status     string    `json:"Status"`
+		Restarting bool      `json:"Restarting"`
+		StartedAt  time.Time `json:"StartedAt"`
+		FinishedAt time.Time `json:"FinishedAt"`
+		ExitCode   int       `json:"ExitCode"`
+	} `json:"State"`
+	Config struct {
+		Healthcheck *struct {
+			Test []string `json:"Test"`
+		} `json:"Healthcheck"`
+	} `json:"Config"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+	HostConfig struct {
+		Runtime           string          `json:"Runtime"`
+		DeviceRequests    []deviceRequest `json:"DeviceRequests"`
+		Memory            int64           `json:"Memory"`
+		MemoryReservation int64           `json:"MemoryReservation"`
+		NanoCpus          int64           `json:"NanoCpus"`
+		CpuQuota          int64           `json:"CpuQuota"`
+		CpuPeriod         int64           `json:"CpuPeriod"`
+	} `json:"HostConfig"`
+}
+
+// daemonVersion is what the daemon answered to the startup ping, reused
+// instead of asking again for its architecture.
+var daemonVersion dockerVersion
+
+// pingDaemon checks both that the CLI is installed and that the daemon
+// answers, with a single `docker version`. It returns why the daemon can't
+// be reached, wrapping exec.ErrNotFound when docker is not in PATH.
+func pingDaemon() error {
+	if engine != nil {
+		version, err := engine.ping()
+		if err != nil {
+			return err
+		}
+		daemonVersion = version
+		return nil
+	}
+
+	// Not dockerRead: a daemon that is down is the answer, not worth retrying.
+	output, err := childOutput(exec.Command("docker", "version", "--format", "{{json .}}"))
+	_ = json.Unmarshal(output, &daemonVersion)
+	if err != nil {
+		return err
+	}
+	if daemonVersion.Server == nil {
+		return errors.New("the daemon did not report its version")
+	}
+	return nil
+}
+
+// containerFilters are `docker ps --filter` expressions applied to the list,
+// from the --filter flags given on launch.
+var containerFilters []string
+
+func getContainers(cfg *Config) ([]Container, error) {
+	containers, err := listContainers(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enrichContainers(cfg, containers)
+	if err != nil {
+		return nil, err
+	}
+
+	markFetched()
+	return containers, nil
+}
+
+// listContainers returns the containers with only what `docker container ls`
+// knows about them, for callers that don't need the inspect fields.
+func listContainers(cfg *Config) ([]Container, error) {
+	if engine != nil {
+		done := track("GET /containers/json")
+		containers, err := engine.containers(containerFilters)
+		done()
+		if err != nil {
+			return nil, err
+		}
+		return filterIgnored(cfg, containers), nil
+	}
+
+	done := track("docker container ls")
+	var list []whale.Container
+	_, err := retryRead("container ls", func() ([]byte, error) {
+		var err error
+		list, err = daemon.List(context.Background(), whale.ListOptions{All: true, Filters: containerFilters})
+		return nil, err
+	})
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, len(list))
+	for i, container := range list {
+		containers[i] = fromWhale(container)
+	}
+	return filterIgnored(cfg, containers), nil
+}
+
+// inspectWorkers bounds the inspect requests sent to the engine at once.
+const inspectWorkers = 8
+
+// engineInspect fetches the inspect documents of the containers through the
+// API, a few at a time, leaving out those removed since they were listed.
+func engineInspect(ids []string) ([][]byte, error) {
+	documents := make([][]byte, len(ids))
+	errs := make([]error, len(ids))
+	jobs := make(chan int)
+	var workers sync.WaitGroup
+	for w := 0; w < min(inspectWorkers, len(ids)); w++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range jobs {
+				documents[i], errs[i] = engine.inspect(ids[i])
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	workers.Wait()
+
+	var found [][]byte
+	for i, document := range documents {
+		switch {
+		case isNotFound(errs[i]):
+		case errs[i] != nil:
+			return nil, errs[i]
+		default:
+			cacheInspect(ids[i], document)
+			found = append(found, document)
+		}
+	}
+	return found, nil
+}
+
+// cliInspect inspects the containers ids with the CLI in one call, again
+// without the ones removed since they were listed, like engineInspect
+// skips them.
+func cliInspect(ids []string) ([]byte, error) {
+	for {
+		output, err := dockerRead(append([]string{"container", "inspect"}, ids...)...)
+		remaining := ids
+		for _, id := range missingContainers(err) {
+			remaining = removeString(remaining, id)
+		}
+		switch {
+		case err == nil || len(remaining) == len(ids):
+			return output, err
+		case len(remaining) == 0:
+			return []byte("[]"), nil
+		}
+		ids = remaining
+	}
+}
+
+// missingContainers are the containers a failed inspect couldn't find.
+func missingContainers(err error) []string {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil
+	}
+	var missing []string
+	for _, line := range strings.Split(string(exitErr.Stderr), "\n") {
+		if _, id, ok := strings.Cut(line, "No such container: "); ok {
+			missing = append(missing, strings.TrimSpace(id))
+		}
+	}
+	return missing
+}
+
+func inspectContainers(ids []string) (map[string]containerInspect, error) {
+	inspects := make(map[string]containerInspect)
+	if len(ids) == 0 {
+		return inspects, nil
+	}
+
+	var list []containerInspect
+	if engine != nil {
+		documents, err := engineInspect(ids)
+		if err != nil {
+			return nil, fmt.Errorf("error inspecting containers: %v", err)
+		}
+		for _, document := range documents {
+			var inspect containerInspect
+			err = json.Unmarshal(document, &inspect)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing containers inspect: %v", err)
+			}
+			list = append(list, inspect)
+		}
+	} else {
+		output, err := cliInspect(ids)
+		if err != nil {
+			return nil, fmt.Errorf("error inspecting containers: %v", err)
+		}
+
+		err = json.Unmarshal(output, &list)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing containers inspect: %v", err)
+		}
+	}
+
+	for _, inspect := range list {
+		inspects[inspect.ID] = inspect
+	}
+
+	return inspects, nil
+}
+
+// enrichContainers fills in the fields only available through inspect, with
+// a single call for the whole list.
+func enrichContainers(cfg *Config, containers []Container) error {
+	ids := make([]string, len(containers))
+	for i, container := range containers {
+		ids[i] = container.ID
+	}
+
+	done := track("docker container inspect")
+	inspects, err := inspectContainers(ids)
+	done()
+	if err != nil {
+		return err
+	}
+
+	imageIDs := make(map[string]string)
+	for i := range containers {
+		inspect, ok := inspects[containers[i].ID]
+		if !ok {
+			continue
+		}
+		imageIDs[containers[i].ID] = inspect.Image
+
+		containers[i].RestartCount = inspect.RestartCount
+		containers[i].StartedAt = inspect.State.StartedAt
+		containers[i].CrashLoop = isCrashLooping(cfg, inspect, time.Now())
+		containers[i].ExitCode = inspect.State.ExitCode
+		containers[i].FinishedAt = inspect.State.FinishedAt
+		containers[i].GPUs = describeGPUs(inspect.HostConfig.DeviceRequests)
+		containers[i].Runtime = inspect.HostConfig.Runtime
+		if check := inspect.Config.Healthcheck; check != nil {
+			containers[i].Healthcheck = len(check.Test) > 0 && check.Test[0] != "NONE"
+		}
+		for _, mount := range inspect.Mounts {
+			if mount.Type == "bind" && len(missingBindSources([]string{mount.Source})) > 0 {
+				containers[i].MissingBinds = append(containers[i].MissingBinds, mount.Source+" → "+mount.Destination)
+			}
+		}
+	}
+
+	done = track("platforms")
+	detectEmulation(containers, imageIDs)
+	done()
+
+	return nil
+}
+
+// isCrashLooping reports containers restarting over and over: either the
+// daemon is restarting them right now, or they restarted several times and
+// the current run started only moments ago.
+func isCrashLooping(cfg *Config, inspect containerInspect, now time.Time) bool {
+	threshold := max(cfg.List.CrashLoopRestarts, 1)
+	if inspect.RestartCount < threshold {
+		return false
+	}
+	if inspect.State.Restarting {
+		return true
+	}
+
+	window := time.Duration(cfg.List.CrashLoopWindow) * time.Second
+	return inspect.State.Status == "running" && now.Sub(inspect.State.StartedAt) < window
+}
+
+// convertJSONToContainer parses a line of `docker container ls --format
+// '{{json .}}'` read from elsewhere than the daemon, like a pipe.
+func convertJSONToContainer(cfg *Config, line string) (Container, error) {
+	container, err := newDockerClient(cfg, "").ParseContainer(line)
+	if err != nil {
+		return Container{}, err
+	}
+	return fromWhale(container), nil
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
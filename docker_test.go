@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// The test binary stands in for the docker CLI when fakeDockerEnv is set:
+// it appends its arguments to the file the variable names, inspects every
+// container as one called web but those whose ID starts with "gone", and
+// succeeds silently for everything else.
+const fakeDockerEnv = "WHALE_FAKE_DOCKER"
+
+func TestMain(m *testing.M) {
+	if log := os.Getenv(fakeDockerEnv); log != "" {
+		os.Exit(fakeDockerCLI(log, os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func fakeDockerCLI(log string, args []string) int {
+	file, err := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 2
+	}
+	fmt.Fprintln(file, strings.Join(args, " "))
+	file.Close()
+
+	if len(args) < 2 || args[0] != "container" || args[1] != "inspect" {
+		return 0
+	}
+
+	format := ""
+	var documents []map[string]any
+	status := 0
+	for i := 2; i < len(args); i++ {
+		if args[i] == "--format" {
+			i++
+			format = args[i]
+			continue
+		}
+		id := args[i]
+		if strings.HasPrefix(id, "gone") {
+			fmt.Fprintln(os.Stderr, "Error response from daemon: No such container: "+id)
+			status = 1
+			continue
+		}
+		documents = append(documents, map[string]any{
+			"Id":     id,
+			"Name":   "/web",
+			"Config": map[string]any{"Image": "nginx:latest"},
+			"State":  map[string]any{"Status": "exited"},
+		})
+	}
+
+	if format != "" {
+		for _, document := range documents {
+			data, _ := json.Marshal(document)
+			fmt.Println(string(data))
+		}
+	} else {
+		data, _ := json.Marshal(documents)
+		fmt.Println(string(data))
+	}
+	return status
+}
+
+// useFakeDockerCLI puts the fake docker first on the PATH for the test, and
+// returns a function giving the command lines it was run with.
+func useFakeDockerCLI(t *testing.T) func() []string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker CLI is a symlink to the test binary")
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := t.TempDir()
+	err = os.Symlink(executable, filepath.Join(bin, "docker"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := filepath.Join(t.TempDir(), "calls")
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(fakeDockerEnv, log)
+
+	return func() []string {
+		data, err := os.ReadFile(log)
+		if err != nil {
+			return nil
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+}
+
+// TestInspectContainersSkipsRemoved checks that the CLI inspect of the list
+// leaves out the containers removed since they were listed instead of
+// failing the whole list.
+func TestInspectContainersSkipsRemoved(t *testing.T) {
+	calls := useFakeDockerCLI(t)
+	defer func(previous *engineClient) { engine = previous }(engine)
+	engine = nil
+
+	inspects, err := inspectContainers([]string{"web-id", "gone-1", "db-id", "gone-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inspects) != 2 || inspects["web-id"].ID != "web-id" || inspects["db-id"].ID != "db-id" {
+		t.Errorf("inspects %v, want web-id and db-id", inspects)
+	}
+	if got := calls(); len(got) != 2 || got[1] != "container inspect web-id db-id" {
+		t.Errorf("docker run with %q, want a second inspect without the removed containers", got)
+	}
+
+	inspects, err = inspectContainers([]string{"gone-3"})
+	if err != nil || len(inspects) != 0 {
+		t.Errorf("inspects %v, error %v, want none without error", inspects, err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// dockerClient is what whale asks the docker CLI for the list and the
+// lifecycle actions: the containers, the actions, the events and the logs.
+// It is the *whale.Client other tools use, and a fake in the tests.
+type dockerClient interface {
+	List(ctx context.Context, options whale.ListOptions) ([]whale.Container, error)
+	Action(ctx context.Context, action whale.Action, ids ...string) error
+	Watch(ctx context.Context, options whale.WatchOptions) (<-chan whale.Event, <-chan error)
+	Logs(ctx context.Context, id string, options whale.LogOptions) (io.ReadCloser, error)
+}
+
+// daemon is the client of the active host, set up by connectDocker once
+// the config and the host are known.
+var daemon dockerClient = newDockerClient(defaultConfig(), "")
+
+// connectDocker sets up how whale runs the docker CLI with cfg: the client
+// of the active host, and the timeouts and spinner of the other commands.
+func connectDocker(cfg *Config) {
+	daemon = newDockerClient(cfg, "")
+	commandTimeouts = cfg.timeouts
+	spinnerColor = cfg.Ui.CursorColor
+}
+
+// newDockerClient returns a client for host, "" for the active one, whose
+// commands run like the others of whale: under their timeout and killed
+// if whale is interrupted.
+func newDockerClient(cfg *Config, host string) *whale.Client {
+	return &whale.Client{
+		Host: host,
+		Exec: func(cmd *exec.Cmd) error {
+			_, err := runChild(cmd, false)
+			return err
+		},
+		TimeLayouts: append(append([]string{}, cfg.Docker.TimeLayouts...), dockerTimeLayouts...),
+	}
+}
+
+// fromWhale is a container as pkg/whale lists it, with only what `docker
+// container ls` knows about it.
+func fromWhale(container whale.Container) Container {
+	// Docker-compatible CLIs don't all fill RunningFor, the age can be
+	// told from CreatedAt then.
+	created := container.RunningFor
+	if created == "" && !container.CreatedAt.IsZero() {
+		created = humanAge(time.Since(container.CreatedAt))
+	}
+
+	return Container{
+		ID:        container.ID,
+		Image:     container.Image,
+		Command:   container.Command,
+		Created:   created,
+		CreatedAt: container.CreatedAt,
+		Status:    container.Status,
+		State:     container.State,
+		Ports:     container.Ports,
+		Name:      container.Name,
+		Labels:    container.Labels,
+	}
+}
+
+// fromWhaleEvent is an event as pkg/whale streams it.
+func fromWhaleEvent(event whale.Event) containerEvent {
+	converted := containerEvent{Action: event.Action, TimeNano: event.Time.UnixNano()}
+	converted.Actor.ID = event.ID
+	converted.Actor.Attributes = event.Attributes
+	return converted
+}
+
+// readLogs returns the logs of the container id, stdout and stderr
+// interleaved, for the reads that don't follow them.
+func readLogs(id string, options whale.LogOptions) ([]byte, error) {
+	reader, err := daemon.Logs(context.Background(), id, options)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// pastEvents returns the container events matching filters from since to
+// until, as far back as the daemon still has them.
+func pastEvents(filters []string, since time.Time, until time.Time) ([]containerEvent, error) {
+	stream, errs := daemon.Watch(context.Background(), whale.WatchOptions{Filters: filters, Since: since, Until: until})
+	var events []containerEvent
+	for event := range stream {
+		events = append(events, fromWhaleEvent(event))
+	}
+	return events, streamError(errs)
+}
+
+// streamError is the error a closed event stream ended with, nil when it
+// was just over.
+func streamError(errs <-chan error) error {
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
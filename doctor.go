@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	diagnosticOK = iota
+	diagnosticWarn
+	diagnosticFail
+)
+
+type diagnostic struct {
+	Name   string
+	Status int
+	Detail string
+	Hint   string
+}
+
+// dockerVersion mirrors `docker version --format '{{json .}}'`.
+type dockerVersion struct {
+	Client struct {
+		Version    string `json:"Version"`
+		ApiVersion string `json:"ApiVersion"`
+		Context    string `json:"Context"`
+		Os         string `json:"Os"`
+		Arch       string `json:"Arch"`
+	} `json:"Client"`
+	Server *dockerServerVersion `json:"Server"`
+}
+
+// dockerServerVersion is the daemon part, also what GET /version returns.
+type dockerServerVersion struct {
+	Version       string `json:"Version"`
+	ApiVersion    string `json:"ApiVersion"`
+	MinAPIVersion string `json:"MinAPIVersion"`
+	Os            string `json:"Os"`
+	Arch          string `json:"Arch"`
+}
+
+// runDiagnostics checks everything whale needs to talk to the engine, from
+// the CLI binary to the daemon socket.
+func runDiagnostics() []diagnostic {
+	var diagnostics []diagnostic
+
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return append(diagnostics, diagnostic{
+			Name:   tr("doctor.binary"),
+			Status: diagnosticFail,
+			Detail: tr("doctor.binaryMissing"),
+			Hint:   tr("doctor.binaryHint"),
+		})
+	}
+
+	// docker version prints the client part and exits with an error when
+	// the daemon can't be reached, so the output is parsed either way.
+	output, versionErr := childOutput(exec.Command("docker", "version", "--format", "{{json .}}"))
+	var version dockerVersion
+	_ = json.Unmarshal(output, &version)
+
+	diagnostics = append(diagnostics, diagnostic{
+		Name:   tr("doctor.binary"),
+		Status: diagnosticOK,
+		Detail: fmt.Sprintf("%s (%s, API %s)", version.Client.Version, path, version.Client.ApiVersion),
+	})
+
+	context := currentDockerContext()
+	diagnostics = append(diagnostics, diagnostic{
+		Name:   tr("doctor.context"),
+		Status: diagnosticOK,
+		Detail: context,
+	})
+
+	if socket := localSocketPath(); socket != "" {
+		diagnostics = append(diagnostics, checkSocket(socket))
+	}
+
+	if version.Server == nil {
+		detail := tr("doctor.daemonDown")
+		if versionErr != nil {
+			detail = errorSummary(versionErr)
+		}
+		return append(diagnostics, diagnostic{
+			Name:   tr("doctor.daemon"),
+			Status: diagnosticFail,
+			Detail: detail,
+			Hint:   tr("doctor.daemonHint"),
+		})
+	}
+
+	diagnostics = append(diagnostics, diagnostic{
+		Name:   tr("doctor.daemon"),
+		Status: diagnosticOK,
+		Detail: fmt.Sprintf("%s (%s/%s)", version.Server.Version, version.Server.Os, version.Server.Arch),
+	})
+
+	diagnostics = append(diagnostics, checkAPIVersion(version), checkGPURuntime())
+
+	if info, err := getNetworkInfo(); err == nil {
+		diagnostics = append(diagnostics, checkProxy(info), checkInsecureRegistries(info))
+	}
+
+	return diagnostics
+}
+
+// checkAPIVersion compares the client API version with the range the daemon
+// supports. A newer client is negotiated down, an older one is refused.
+func checkAPIVersion(version dockerVersion) diagnostic {
+	client := version.Client.ApiVersion
+	server := version.Server.ApiVersion
+	minimum := version.Server.MinAPIVersion
+
+	result := diagnostic{
+		Name:   tr("doctor.api"),
+		Status: diagnosticOK,
+		Detail: tr("doctor.apiRange", client, minimum, server),
+	}
+
+	switch {
+	case minimum != "" && compareVersions(client, minimum) < 0:
+		result.Status = diagnosticFail
+		result.Hint = tr("doctor.apiTooOld")
+	case compareVersions(client, server) > 0:
+		result.Status = diagnosticWarn
+		result.Hint = tr("doctor.apiNegotiated", server)
+	}
+
+	return result
+}
+
+// localSocketPath returns the unix socket the CLI will use, or "" when the
+// engine is reached another way.
+func localSocketPath() string {
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		if os.Getenv("DOCKER_CONTEXT") != "" || currentDockerContext() != "default" {
+			return ""
+		}
+		if runtime.GOOS == "windows" {
+			return defaultPipe
+		}
+		return defaultSocket
+	}
+	if strings.HasPrefix(host, "unix://") {
+		return strings.TrimPrefix(host, "unix://")
+	}
+	if strings.HasPrefix(host, "npipe://") {
+		return filepath.FromSlash(strings.TrimPrefix(host, "npipe://"))
+	}
+	return ""
+}
+
+func checkSocket(socket string) diagnostic {
+	result := diagnostic{Name: tr("doctor.socket"), Status: diagnosticOK, Detail: socket}
+
+	if _, err := os.Stat(socket); err != nil {
+		result.Status = diagnosticFail
+		result.Detail = tr("doctor.socketMissing", socket)
+		result.Hint = tr("doctor.daemonHint")
+		return result
+	}
+
+	// Named pipes can't be dialed without extra dependencies, their
+	// existence is as far as the check goes on Windows.
+	if runtime.GOOS == "windows" {
+		return result
+	}
+
+	conn, err := net.DialTimeout("unix", socket, 2*time.Second)
+	if err != nil {
+		result.Status = diagnosticFail
+		result.Detail = fmt.Sprintf("%s: %v", socket, err)
+		if errors.Is(err, os.ErrPermission) {
+			result.Hint = tr("doctor.socketPermissionHint")
+			if member, active := dockerGroupMembership(); member && !active {
+				result.Hint = tr("doctor.socketStaleSessionHint")
+			}
+		}
+		return result
+	}
+	conn.Close()
+
+	return result
+}
+
+// compareVersions compares dotted numeric versions like "1.45" and "1.24".
+func compareVersions(a string, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func printDiagnostics(diagnostics []diagnostic) {
+	for _, d := range diagnostics {
+		mark := renderColor("✓", "32")
+		switch d.Status {
+		case diagnosticWarn:
+			mark = renderColor("!", "33")
+		case diagnosticFail:
+			mark = renderColor("✗", "31")
+		}
+
+		fmt.Printf("%s %-16s %s\n", mark, d.Name, d.Detail)
+		if d.Hint != "" {
+			fmt.Printf("  %-16s %s\n", "", renderColor(d.Hint, "2"))
+		}
+	}
+}
+
+func hasFailure(diagnostics []diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Status == diagnosticFail {
+			return true
+		}
+	}
+	return false
+}
+
+// doctorCommand implements `whale doctor`.
+func doctorCommand() {
+	diagnostics := runDiagnostics()
+	printDiagnostics(diagnostics)
+	if hasFailure(diagnostics) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// countingReader tracks how many bytes went over the wire.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// dockerEndpoint returns the engine address of the active host, e.g.
+// ssh://user@server or unix:///var/run/docker.sock.
+func dockerEndpoint() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+
+	output, err := dockerRead("context", "inspect", "--format", "{{.Endpoints.docker.Host}}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// sshCommand builds the ssh invocation running command on the engine host
+// of an ssh:// endpoint, or returns nil for any other endpoint.
+func sshCommand(endpoint string, command string) *exec.Cmd {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "ssh" || u.Hostname() == "" {
+		return nil
+	}
+
+	target := u.Hostname()
+	if u.User != nil {
+		target = u.User.Username() + "@" + target
+	}
+
+	args := []string{}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, target, command)
+	return exec.Command("ssh", args...)
+}
+
+// downloadLogs saves the logs of a container to path. Over ssh:// hosts the
+// logs are gzipped on the server and decompressed here, which is much
+// faster than streaming them raw through the docker socket forwarding.
+func downloadLogs(container Container, tail string, path string, compress bool) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var cmd *exec.Cmd
+	if compress {
+		remote := fmt.Sprintf("docker logs --tail %s %s 2>&1 | gzip -c", shellQuote(tail), shellQuote(container.ID))
+		cmd = sshCommand(dockerEndpoint(), remote)
+		if cmd == nil {
+			println(tr("logs.compressLocal"))
+		}
+	}
+
+	if cmd == nil {
+		cmd = exec.Command("docker", "logs", "--tail", tail, container.ID)
+		cmd.Stdout = file
+		cmd.Stderr = file
+		err = cmd.Run()
+		if err != nil {
+			return err
+		}
+		return printDownloaded(file, path, 0)
+	}
+
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	wire := &countingReader{reader: stdout}
+	reader, err := gzip.NewReader(wire)
+	if err != nil {
+		cmd.Wait()
+		return err
+	}
+	_, err = io.Copy(file, reader)
+	if waitErr := cmd.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return printDownloaded(file, path, wire.count)
+}
+
+func printDownloaded(file *os.File, path string, transferred int64) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if transferred > 0 {
+		fmt.Println(tr("logs.downloadedCompressed", path, formatBytes(info.Size()), formatBytes(transferred)))
+	} else {
+		fmt.Println(tr("logs.downloaded", path, formatBytes(info.Size())))
+	}
+	return nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
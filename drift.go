@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// composeModel is the part of `docker compose config --format json` the
+// drift check compares with the running containers.
+type composeModel struct {
+	Services map[string]struct {
+		Image       string             `json:"image"`
+		Environment map[string]*string `json:"environment"`
+		Ports       []struct {
+			Target    int    `json:"target"`
+			Published string `json:"published"`
+			Protocol  string `json:"protocol"`
+		} `json:"ports"`
+	} `json:"services"`
+}
+
+// serviceInspect is the part of `docker container inspect` the compose file
+// decides.
+type serviceInspect struct {
+	Config struct {
+		Image  string            `json:"Image"`
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		PortBindings map[string][]struct {
+			HostPort string `json:"HostPort"`
+		} `json:"PortBindings"`
+	} `json:"HostConfig"`
+}
+
+// composeDrift returns, for each service of the project whose containers
+// no longer match the compose file, what differs: the image, the
+// environment variables or the published ports. Those services need to be
+// recreated for the file to apply.
+func composeDrift(project composeProject) (map[string][]string, error) {
+	output, err := childOutput(project.composeCommand("config", "--format", "json"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the compose file: %v", err)
+	}
+	var model composeModel
+	err = json.Unmarshal(output, &model)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the compose file: %v", err)
+	}
+
+	var ids []string
+	for _, container := range project.Containers {
+		ids = append(ids, container.ID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	output, err = dockerRead(append([]string{"container", "inspect"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	var inspects []serviceInspect
+	err = json.Unmarshal(output, &inspects)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing containers inspect: %v", err)
+	}
+
+	drift := map[string][]string{}
+	for _, inspect := range inspects {
+		name := inspect.Config.Labels[composeServiceLabel]
+		service, ok := model.Services[name]
+		if !ok {
+			continue
+		}
+
+		var reasons []string
+		if service.Image != "" && service.Image != inspect.Config.Image {
+			reasons = append(reasons, tr("drift.image", inspect.Config.Image, service.Image))
+		}
+
+		env := map[string]string{}
+		for _, pair := range inspect.Config.Env {
+			key, value, _ := strings.Cut(pair, "=")
+			env[key] = value
+		}
+		var keys []string
+		for key, value := range service.Environment {
+			// Null values come from the shell running compose, unknown here.
+			if value == nil {
+				continue
+			}
+			if actual, ok := env[key]; !ok || actual != *value {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			reasons = append(reasons, tr("drift.env", strings.Join(keys, ", ")))
+		}
+
+		want := map[string]bool{}
+		for _, port := range service.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			want[port.Published+":"+strconv.Itoa(port.Target)+"/"+protocol] = true
+		}
+		have := map[string]bool{}
+		for port, bindings := range inspect.HostConfig.PortBindings {
+			for _, binding := range bindings {
+				have[binding.HostPort+":"+port] = true
+			}
+		}
+		if !samePorts(want, have) {
+			reasons = append(reasons, tr("drift.ports"))
+		}
+
+		if len(reasons) > 0 && drift[name] == nil {
+			drift[name] = reasons
+		}
+	}
+	return drift, nil
+}
+
+// samePorts compares published ports, a port compose leaves to the daemon
+// to choose matching whichever host port it got.
+func samePorts(want map[string]bool, have map[string]bool) bool {
+	if len(want) != len(have) {
+		return false
+	}
+	for port := range want {
+		if have[port] {
+			continue
+		}
+		if !strings.HasPrefix(port, ":") {
+			return false
+		}
+		found := false
+		for other := range have {
+			if strings.HasSuffix(other, port) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func driftedServices(drift map[string][]string) []string {
+	var names []string
+	for name := range drift {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// engine talks to the Docker Engine API directly for the reads the list
+// needs, so they come back as structured data instead of CLI output. It is
+// nil when the endpoint can't be reached natively (Windows named pipes),
+// in which case everything goes through the docker CLI as before.
+var engine *engineClient
+
+type engineClient struct {
+	client  *http.Client
+	host    string
+	base    string
+	version string
+	// timeouts are those of the config, bounding each request like the
+	// CLI command it stands for.
+	timeouts map[string]time.Duration
+}
+
+// engineError is an error status the API answered with.
+type engineError struct {
+	Status  int
+	Message string
+}
+
+func (err *engineError) Error() string {
+	return err.Message
+}
+
+// isNotFound reports whether err is the API not finding what was asked.
+func isNotFound(err error) bool {
+	var apiErr *engineError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// engineEndpoint is where the API lives, with the TLS settings needed to
+// reach it.
+type engineEndpoint struct {
+	Host          string
+	TLSDir        string
+	SkipTLSVerify bool
+}
+
+// resolveEngineEndpoint finds the engine the docker CLI would talk to:
+// DOCKER_HOST, then the endpoint of DOCKER_CONTEXT or of the current
+// context, then the default socket.
+func resolveEngineEndpoint() (engineEndpoint, error) {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		endpoint := engineEndpoint{Host: host}
+		if os.Getenv("DOCKER_TLS_VERIFY") != "" || os.Getenv("DOCKER_CERT_PATH") != "" {
+			endpoint.TLSDir = os.Getenv("DOCKER_CERT_PATH")
+			if endpoint.TLSDir == "" {
+				endpoint.TLSDir, _ = dockerConfigDir()
+			}
+			endpoint.SkipTLSVerify = os.Getenv("DOCKER_TLS_VERIFY") == ""
+		}
+		return endpoint, nil
+	}
+
+	name := currentDockerContext()
+	if name == "default" {
+		if runtime.GOOS == "windows" {
+			return engineEndpoint{Host: "npipe:////./pipe/docker_engine"}, nil
+		}
+		return engineEndpoint{Host: "unix://" + defaultSocket}, nil
+	}
+
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return engineEndpoint{}, err
+	}
+
+	// Contexts are stored under the sha256 of their name.
+	sum := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(sum[:])
+	data, err := os.ReadFile(filepath.Join(dir, "contexts", "meta", id, "meta.json"))
+	if err != nil {
+		return engineEndpoint{}, fmt.Errorf("error reading context %s: %v", name, err)
+	}
+
+	var meta struct {
+		Endpoints struct {
+			Docker struct {
+				Host          string `json:"Host"`
+				SkipTLSVerify bool   `json:"SkipTLSVerify"`
+			} `json:"docker"`
+		} `json:"Endpoints"`
+	}
+	err = json.Unmarshal(data, &meta)
+	if err != nil {
+		return engineEndpoint{}, fmt.Errorf("error parsing context %s: %v", name, err)
+	}
+
+	endpoint := engineEndpoint{
+		Host:          meta.Endpoints.Docker.Host,
+		SkipTLSVerify: meta.Endpoints.Docker.SkipTLSVerify,
+	}
+	tlsDir := filepath.Join(dir, "contexts", "tls", id, "docker")
+	if _, err := os.Stat(tlsDir); err == nil {
+		endpoint.TLSDir = tlsDir
+	}
+	return endpoint, nil
+}
+
+// newEngineClient builds a client for unix sockets, tcp with or without
+// TLS, and ssh, where the connection goes through `docker system
+// dial-stdio` on the remote host like the CLI does.
+func newEngineClient(endpoint engineEndpoint) (*engineClient, error) {
+	u, err := url.Parse(endpoint.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing docker host %s: %v", endpoint.Host, err)
+	}
+
+	transport := &http.Transport{}
+	base := "http://docker"
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	case "tcp", "http", "https":
+		base = "http://" + u.Host
+		if endpoint.TLSDir != "" || u.Scheme == "https" {
+			tlsConfig, err := engineTLSConfig(endpoint)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tlsConfig
+			base = "https://" + u.Host
+		}
+	case "ssh":
+		host := endpoint.Host
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			cmd := sshCommand(host, "docker system dial-stdio")
+			if cmd == nil {
+				return nil, fmt.Errorf("invalid ssh host %s", host)
+			}
+			return dialCommand(cmd)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported docker host %s", endpoint.Host)
+	}
+
+	return &engineClient{
+		client: &http.Client{Transport: transport},
+		host:   endpoint.Host,
+		base:   base,
+	}, nil
+}
+
+func engineTLSConfig(endpoint engineEndpoint) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: endpoint.SkipTLSVerify}
+	if endpoint.TLSDir == "" {
+		return tlsConfig, nil
+	}
+
+	if ca, err := os.ReadFile(filepath.Join(endpoint.TLSDir, "ca.pem")); err == nil {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(ca)
+		tlsConfig.RootCAs = pool
+	}
+
+	certFile := filepath.Join(endpoint.TLSDir, "cert.pem")
+	keyFile := filepath.Join(endpoint.TLSDir, "key.pem")
+	if _, err := os.Stat(certFile); err == nil {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS certificate from %s: %v", endpoint.TLSDir, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// connectEngine sets up the API client, leaving engine nil when the
+// endpoint needs the CLI.
+func connectEngine(cfg *Config) {
+	endpoint, err := resolveEngineEndpoint()
+	if err != nil || strings.HasPrefix(endpoint.Host, "npipe://") {
+		return
+	}
+	client, err := newEngineClient(endpoint)
+	if err != nil {
+		return
+	}
+	client.timeouts = cfg.timeouts
+	engine = client
+}
+
+// get fetches an API path, negotiated version first, and returns the body.
+// operation is the CLI command the request stands for, like "container
+// ls", whose timeout bounds it; transient errors are retried like those of
+// dockerRead.
+func (client *engineClient) get(operation string, path string, query url.Values) ([]byte, error) {
+	target := client.base
+	if client.version != "" {
+		target += "/v" + client.version
+	}
+	target += path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	key, timeout := operationTimeout(client.timeouts, operationKeys(strings.Fields(operation)))
+	return retryRead(operation, func() ([]byte, error) {
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := client.do(request, path)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s", tr("timeout.expired", "GET "+path, timeout, key))
+		}
+		return body, err
+	})
+}
+
+// do sends request and returns the body, or the error the API answered.
+func (client *engineClient) do(request *http.Request, path string) ([]byte, error) {
+	response, err := client.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 400 {
+		apiErr := &engineError{Status: response.StatusCode, Message: fmt.Sprintf("%s: %s", path, response.Status)}
+		var answer struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &answer) == nil && answer.Message != "" {
+			apiErr.Message = answer.Message
+		}
+		return nil, apiErr
+	}
+	return body, nil
+}
+
+// ping checks the daemon answers and settles on an API version: the one
+// pinned with DOCKER_API_VERSION, otherwise the daemon's own.
+func (client *engineClient) ping() (dockerVersion, error) {
+	var version dockerVersion
+
+	request, _ := http.NewRequest(http.MethodGet, client.base+"/_ping", nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	response, err := client.client.Do(request.WithContext(ctx))
+	if errors.Is(err, os.ErrPermission) {
+		// Worded like the CLI, which the socket permission help looks for.
+		return version, fmt.Errorf("permission denied while trying to connect to the docker daemon socket at %s", client.host)
+	}
+	if err != nil {
+		return version, fmt.Errorf("cannot connect to the docker daemon at %s: %v", client.host, err)
+	}
+	response.Body.Close()
+
+	client.version = os.Getenv("DOCKER_API_VERSION")
+	if client.version == "" {
+		client.version = response.Header.Get("Api-Version")
+	}
+
+	body, err := client.get("version", "/version", nil)
+	if err != nil {
+		return version, err
+	}
+	version.Server = &dockerServerVersion{}
+	err = json.Unmarshal(body, version.Server)
+	return version, err
+}
+
+// apiContainer mirrors one entry of GET /containers/json.
+type apiContainer struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Image   string            `json:"Image"`
+	Command string            `json:"Command"`
+	Created int64             `json:"Created"`
+	State   string            `json:"State"`
+	Status  string            `json:"Status"`
+	Labels  map[string]string `json:"Labels"`
+	Ports   []struct {
+		IP          string `json:"IP"`
+		PrivatePort int    `json:"PrivatePort"`
+		PublicPort  int    `json:"PublicPort"`
+		Type        string `json:"Type"`
+	} `json:"Ports"`
+}
+
+// containers lists every container, applying `docker ps --filter`
+// expressions.
+func (client *engineClient) containers(filters []string) ([]Container, error) {
+	query := url.Values{"all": {"1"}}
+	if len(filters) > 0 {
+		byKey := map[string][]string{}
+		for _, filter := range filters {
+			key, value, _ := strings.Cut(filter, "=")
+			byKey[key] = append(byKey[key], value)
+		}
+		encoded, _ := json.Marshal(byKey)
+		query.Set("filters", string(encoded))
+	}
+
+	body, err := client.get("container ls", "/containers/json", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []apiContainer
+	err = json.Unmarshal(body, &list)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing containers: %v", err)
+	}
+
+	containers := make([]Container, len(list))
+	for i, c := range list {
+		name := ""
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		var ports []string
+		for _, port := range c.Ports {
+			if port.PublicPort == 0 {
+				ports = append(ports, fmt.Sprintf("%d/%s", port.PrivatePort, port.Type))
+				continue
+			}
+			ports = append(ports, fmt.Sprintf("%s->%d/%s", net.JoinHostPort(port.IP, fmt.Sprint(port.PublicPort)), port.PrivatePort, port.Type))
+		}
+
+		labels := c.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		containers[i] = Container{
+			ID:        c.ID,
+			Image:     c.Image,
+			Command:   c.Command,
+			Created:   humanAge(time.Since(time.Unix(c.Created, 0))),
+			CreatedAt: time.Unix(c.Created, 0),
+			Status:    c.Status,
+			State:     c.State,
+			Ports:     strings.Join(ports, ", "),
+			Name:      name,
+			Labels:    labels,
+		}
+	}
+	return containers, nil
+}
+
+// inspect returns the inspect document of a container, as the CLI prints it
+// for a single container with --format '{{json .}}'.
+func (client *engineClient) inspect(id string) ([]byte, error) {
+	return client.get("container inspect", "/containers/"+url.PathEscape(id)+"/json", nil)
+}
+
+// humanAge renders an age the way `docker ps` does in its CREATED column.
+func humanAge(d time.Duration) string {
+	switch seconds := int(d.Seconds()); {
+	case seconds < 1:
+		return "Less than a second ago"
+	case seconds == 1:
+		return "1 second ago"
+	case seconds < 60:
+		return fmt.Sprintf("%d seconds ago", seconds)
+	}
+
+	switch minutes := int(d.Minutes()); {
+	case minutes == 1:
+		return "About a minute ago"
+	case minutes < 60:
+		return fmt.Sprintf("%d minutes ago", minutes)
+	}
+
+	switch hours := int(d.Hours() + 0.5); {
+	case hours == 1:
+		return "About an hour ago"
+	case hours < 48:
+		return fmt.Sprintf("%d hours ago", hours)
+	case hours < 24*7*2:
+		return fmt.Sprintf("%d days ago", hours/24)
+	case hours < 24*30*2:
+		return fmt.Sprintf("%d weeks ago", hours/24/7)
+	case hours < 24*365*2:
+		return fmt.Sprintf("%d months ago", hours/24/30)
+	}
+	return fmt.Sprintf("%d years ago", int(d.Hours())/24/365)
+}
+
+// commandConn is a connection over the stdio of a command, used to reach
+// remote engines through ssh.
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+}
+
+func dialCommand(cmd *exec.Cmd) (net.Conn, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+	return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+func (conn *commandConn) Read(p []byte) (int, error)  { return conn.stdout.Read(p) }
+func (conn *commandConn) Write(p []byte) (int, error) { return conn.stdin.Write(p) }
+
+func (conn *commandConn) Close() error {
+	conn.stdin.Close()
+	conn.cmd.Process.Kill()
+	return conn.cmd.Wait()
+}
+
+func (conn *commandConn) LocalAddr() net.Addr              { return commandAddr{} }
+func (conn *commandConn) RemoteAddr() net.Addr             { return commandAddr{} }
+func (conn *commandConn) SetDeadline(time.Time) error      { return nil }
+func (conn *commandConn) SetReadDeadline(time.Time) error  { return nil }
+func (conn *commandConn) SetWriteDeadline(time.Time) error { return nil }
+
+type commandAddr struct{}
+
+func (commandAddr) Network() string { return "command" }
+func (commandAddr) String() string  { return "command" }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	nameConflictPattern = regexp.MustCompile(`container name "/?([^"]+)" is already in use`)
+	portInUsePattern    = regexp.MustCompile(`(?:Bind for |listen tcp[46]? )\S*:(\d+)`)
+	manifestPattern     = regexp.MustCompile(`manifest for (\S+) not found`)
+)
+
+// errorHint turns the docker errors people hit most into what to do next
+// in whale, or "" for the others. containers, when known, tell who holds a
+// port.
+func errorHint(err error, containers []Container) string {
+	message := err.Error()
+	lower := strings.ToLower(message)
+
+	switch {
+	case nameConflictPattern.MatchString(message):
+		return tr("errhint.nameConflict", nameConflictPattern.FindStringSubmatch(message)[1])
+	case strings.Contains(lower, "port is already allocated"), strings.Contains(lower, "address already in use"):
+		port := "?"
+		if match := portInUsePattern.FindStringSubmatch(message); match != nil {
+			port = match[1]
+		}
+		for _, container := range containers {
+			for _, published := range publishedTCPPorts(container.Ports) {
+				if published.HostPort == port {
+					return tr("errhint.portContainer", port, container.Name)
+				}
+			}
+		}
+		return tr("errhint.portHost", port)
+	case strings.Contains(lower, "no space left on device"):
+		return tr("errhint.noSpace")
+	case manifestPattern.MatchString(message):
+		return tr("errhint.manifest", manifestPattern.FindStringSubmatch(message)[1])
+	case strings.Contains(lower, "manifest unknown"):
+		return tr("errhint.manifestUnknown")
+	case strings.Contains(lower, "pull access denied"), strings.Contains(lower, "repository does not exist"):
+		return tr("errhint.accessDenied")
+	case strings.Contains(lower, "cannot connect to the docker daemon"), strings.Contains(lower, "permission denied while trying to connect"):
+		return tr("errhint.daemon")
+	}
+	return ""
+}
+
+// errorNotice is the outcome line of a failed action, with the hint below
+// it when there is one.
+func errorNotice(name string, err error, containers []Container) string {
+	notice := fmt.Sprintf("✗ %s: %v", name, err)
+	if hint := errorHint(err, containers); hint != "" {
+		notice += "\n→ " + hint
+	}
+	return notice
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// fanoutHosts are the engines `whale ps --all-hosts` queries: the ones
+// with a profile in the hosts section and the active one, sorted.
+func fanoutHosts(cfg *Config) []string {
+	hosts := []string{currentDockerContext()}
+	for host := range cfg.Hosts {
+		if !containsString(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// hostLabel is how a host shows in the host column, its profile label
+// when it has one.
+func hostLabel(cfg *Config, host string) string {
+	if label := cfg.Hosts[host].Label; label != "" {
+		return label
+	}
+	return host
+}
+
+// hostContainers is the outcome of listing the containers of a host.
+type hostContainers struct {
+	host       string
+	containers []Container
+	err        error
+}
+
+func listHostContainers(cfg *Config, host string) hostContainers {
+	list, err := newDockerClient(cfg, host).List(context.Background(), whale.ListOptions{All: true})
+	if err != nil {
+		return hostContainers{host: host, err: err}
+	}
+
+	containers := make([]Container, len(list))
+	for i, container := range list {
+		containers[i] = fromWhale(container)
+	}
+	return hostContainers{host: host, containers: filterIgnored(cfg, containers)}
+}
+
+// psRow is a line of `whale ps --json`.
+type psRow struct {
+	Host   string `json:"host"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+	Ports  string `json:"ports"`
+}
+
+// psCommand implements `whale ps [--all-hosts] [--json]`: the containers of
+// the active host, or with --all-hosts of every host of the config queried
+// in parallel, with a host column. A host that can't be reached is
+// reported and skipped, whale only exits with 1 when none could be.
+func psCommand(args []string) {
+	allHosts := false
+	asJSON := false
+	for _, arg := range args {
+		switch arg {
+		case "--all-hosts", "-A":
+			allHosts = true
+		case "--json":
+			asJSON = true
+		}
+	}
+
+	cfg := currentConfig()
+	hosts := []string{currentDockerContext()}
+	if allHosts {
+		hosts = fanoutHosts(cfg)
+	}
+
+	results := make([]hostContainers, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = listHostContainers(cfg, host)
+		}(i, host)
+	}
+	wg.Wait()
+
+	var rows []psRow
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, renderColor(fmt.Sprintf("✗ %s: %v", hostLabel(cfg, result.host), result.err), "31"))
+			continue
+		}
+		for _, container := range result.containers {
+			rows = append(rows, psRow{Host: hostLabel(cfg, result.host), ID: container.ID, Name: container.Name, Image: container.Image, State: container.State, Status: container.Status, Ports: container.Ports})
+		}
+	}
+
+	if asJSON {
+		data, _ := json.MarshalIndent(rows, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		table := [][]string{{tr("column.name"), tr("column.image"), tr("column.status"), tr("column.ports")}}
+		if allHosts {
+			table[0] = append([]string{tr("column.host")}, table[0]...)
+		}
+		for _, row := range rows {
+			line := []string{row.Name, truncateImage(row.Image, cfg.List.ImageWidth), row.Status, row.Ports}
+			if allHosts {
+				line = append([]string{row.Host}, line...)
+			}
+			table = append(table, line)
+		}
+		for _, line := range alignColumns(table) {
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+	}
+
+	if failed == len(hosts) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listScript prints one "type|size|mtime|name" line per entry of the
+// directory given as $1, hidden files included, with what busybox offers.
+const listScript = `cd "$1" || exit 1; for f in * .[!.]* ..?*; do if [ -e "$f" ] || [ -L "$f" ]; then stat -c '%F|%s|%Y|%n' -- "$f"; fi; done`
+
+type fileEntry struct {
+	Name     string
+	Dir      bool
+	Link     bool
+	Size     int64
+	Modified time.Time
+}
+
+type dirMsg struct {
+	dir     string
+	entries []fileEntry
+	err     error
+}
+
+// listDir lists a directory of a running container through docker exec.
+func listDir(target string, dir string) ([]fileEntry, error) {
+	output, err := runChild(exec.Command("docker", "exec", target, "sh", "-c", listScript, "sh", dir), true)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	var entries []fileEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "|", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		modified, _ := strconv.ParseInt(fields[2], 10, 64)
+		entries = append(entries, fileEntry{
+			Name:     fields[3],
+			Dir:      fields[0] == "directory",
+			Link:     fields[0] == "symbolic link",
+			Size:     size,
+			Modified: time.Unix(modified, 0),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// fileBrowser walks the filesystem of a running container, never above
+// root. Viewing or copying a file quits the program, with open or copy set,
+// so that the caller runs it and opens the browser again where it was.
+type fileBrowser struct {
+	config  *Config
+	title   string
+	target  string
+	root    string
+	dir     string
+	entries []fileEntry
+	cursor  int
+	height  int
+	loading bool
+	err     error
+	notice  string
+
+	open string
+	copy string
+}
+
+func newFileBrowser(cfg *Config, title string, target string, root string, dir string) fileBrowser {
+	return fileBrowser{config: cfg, title: title, target: target, root: root, dir: dir, height: 24, loading: true}
+}
+
+func (browser fileBrowser) load(dir string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := listDir(browser.target, dir)
+		return dirMsg{dir: dir, entries: entries, err: err}
+	}
+}
+
+func (browser fileBrowser) Init() tea.Cmd {
+	return browser.load(browser.dir)
+}
+
+func (browser fileBrowser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dirMsg:
+		browser.loading = false
+		browser.err = msg.err
+		if msg.err != nil {
+			break
+		}
+		previous := browser.dir
+		browser.entries = msg.entries
+		if msg.dir == previous {
+			// Opened again after viewing a file: stay where we were.
+			browser.cursor = min(browser.cursor, max(len(msg.entries)-1, 0))
+			break
+		}
+		browser.dir = msg.dir
+		browser.cursor = 0
+		// Coming back up, land on the directory we left.
+		for i, entry := range msg.entries {
+			if path.Join(msg.dir, entry.Name) == previous {
+				browser.cursor = i
+			}
+		}
+	case tea.WindowSizeMsg:
+		browser.height = msg.Height
+	case tea.KeyMsg:
+		browser.notice = ""
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return browser, tea.Quit
+		case "up", "k":
+			if browser.cursor > 0 {
+				browser.cursor--
+			}
+		case "down", "j":
+			if browser.cursor < len(browser.entries)-1 {
+				browser.cursor++
+			}
+		case "left", "h", "backspace":
+			if browser.dir != browser.root {
+				browser.loading = true
+				return browser, browser.load(path.Dir(browser.dir))
+			}
+		case "enter", "right", "l":
+			if len(browser.entries) == 0 {
+				break
+			}
+			entry := browser.entries[browser.cursor]
+			target := path.Join(browser.dir, entry.Name)
+			if entry.Dir {
+				browser.loading = true
+				return browser, browser.load(target)
+			}
+			browser.open = target
+			return browser, tea.Quit
+		case "c":
+			if len(browser.entries) > 0 {
+				browser.copy = path.Join(browser.dir, browser.entries[browser.cursor].Name)
+				return browser, tea.Quit
+			}
+		}
+	}
+
+	return browser, nil
+}
+
+func (browser fileBrowser) View() string {
+	s := renderHeader(browser.config)
+	s += browser.title + "\n"
+	s += renderColor(browser.dir, "2") + "\n\n"
+
+	switch {
+	case browser.loading:
+		s += tr("files.loading") + "\n"
+	case browser.err != nil:
+		s += renderColor(browser.err.Error(), "31") + "\n"
+	case len(browser.entries) == 0:
+		s += tr("files.empty") + "\n"
+	}
+
+	// Keep the cursor in view, leaving room for the header and footer.
+	visible := max(browser.height-8, 5)
+	start := min(max(browser.cursor-visible/2, 0), max(len(browser.entries)-visible, 0))
+	end := min(start+visible, len(browser.entries))
+
+	var table [][]string
+	for _, entry := range browser.entries[start:end] {
+		name := entry.Name
+		size := formatBytes(entry.Size)
+		switch {
+		case entry.Dir:
+			name += "/"
+			size = ""
+		case entry.Link:
+			name += "@"
+		}
+		table = append(table, []string{name, size, entry.Modified.Local().Format("2006-01-02 15:04")})
+	}
+	for i, row := range alignColumns(table) {
+		if start+i == browser.cursor {
+			s += fmt.Sprintf("%s %s\n", renderCursor(browser.config), renderActionSelected(browser.config, row, true))
+		} else {
+			s += fmt.Sprintf("  %s\n", row)
+		}
+	}
+
+	s += "\n" + renderColor(tr("files.help"), "2") + "\n"
+	if browser.notice != "" {
+		s += renderColor(browser.notice, "33") + "\n"
+	}
+	return s
+}
+
+// browseFiles runs the file browser on target from root, showing files in
+// the log viewer and copying them to the current directory with docker cp.
+func browseFiles(cfg *Config, title string, target string, root string) error {
+	if plainMode {
+		return browseFilesPlain(cfg, title, target, root)
+	}
+
+	dir := root
+	cursor := 0
+	notice := ""
+	for {
+		browser := newFileBrowser(cfg, title, target, root, dir)
+		browser.cursor = cursor
+		browser.notice = notice
+		finalModel, err := runProgram(browser)
+		if err != nil {
+			return err
+		}
+
+		browser = finalModel.(fileBrowser)
+		dir, cursor = browser.dir, browser.cursor
+		notice = ""
+		switch {
+		case browser.open != "":
+			err = runStream(cfg, browser.open, exec.Command("docker", "exec", target, "cat", browser.open))
+			if err != nil {
+				notice = fmt.Sprintf("✗ %s: %v", browser.open, err)
+			}
+		case browser.copy != "":
+			notice = copyFromContainer(target, browser.copy)
+		default:
+			return nil
+		}
+	}
+}
+
+// copyFromContainer copies a file or directory to the current directory
+// and returns the outcome to show in the browser.
+func copyFromContainer(target string, source string) string {
+	_, err := runWithSpinner(exec.Command("docker", "cp", target+":"+source, "."))
+	if err != nil {
+		return fmt.Sprintf("✗ %s: %v", source, err)
+	}
+	return fmt.Sprintf("✓ %s: %s", source, tr("files.copied", "./"+path.Base(source)))
+}
+
+// browseFilesPlain is the numbered version of the browser: choosing a
+// directory enters it, choosing a file prints it.
+func browseFilesPlain(cfg *Config, title string, target string, root string) error {
+	dir := root
+	for {
+		entries, err := listDir(target, dir)
+		if err != nil {
+			return err
+		}
+
+		var items []string
+		if dir != root {
+			items = append(items, "../")
+		}
+		for _, entry := range entries {
+			name := entry.Name
+			if entry.Dir {
+				name += "/"
+			}
+			items = append(items, name)
+		}
+
+		choice, err := choosePlain(title+" "+dir, "", items)
+		if err != nil || choice < 0 {
+			return err
+		}
+		if dir != root {
+			if choice == 0 {
+				dir = path.Dir(dir)
+				continue
+			}
+			choice--
+		}
+
+		entry := entries[choice]
+		if entry.Dir {
+			dir = path.Join(dir, entry.Name)
+			continue
+		}
+		err = runStream(cfg, entry.Name, exec.Command("docker", "exec", target, "cat", path.Join(dir, entry.Name)))
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/abroudoux/whale/pkg/whale"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startQueue collects the start events of matching containers while the
+// logs of the previous one are shown. ready holds a token once there is
+// one to take.
+type startQueue struct {
+	sync.Mutex
+	starts []containerEvent
+	ended  bool
+	ready  chan struct{}
+}
+
+func (queue *startQueue) push(event containerEvent) {
+	queue.Lock()
+	queue.starts = append(queue.starts, event)
+	queue.Unlock()
+	queue.signal()
+}
+
+func (queue *startQueue) end() {
+	queue.Lock()
+	queue.ended = true
+	queue.Unlock()
+	queue.signal()
+}
+
+func (queue *startQueue) signal() {
+	select {
+	case queue.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the latest start, dropping the ones it replaces: only the
+// newest container is worth following.
+func (queue *startQueue) pop() (containerEvent, bool, bool) {
+	queue.Lock()
+	defer queue.Unlock()
+	if len(queue.starts) == 0 {
+		return containerEvent{}, false, queue.ended
+	}
+	event := queue.starts[len(queue.starts)-1]
+	queue.starts = nil
+	return event, true, queue.ended
+}
+
+// followMatch tells the containers `whale follow` opens: name and image are
+// prefixes, any container matching when both are empty.
+type followMatch struct {
+	name  string
+	image string
+}
+
+func (match followMatch) matches(event containerEvent) bool {
+	name := event.Actor.Attributes["name"]
+	image := event.Actor.Attributes["image"]
+	switch {
+	case match.name != "" && match.image != "":
+		return strings.HasPrefix(name, match.name) && strings.HasPrefix(image, match.image)
+	case match.name != "":
+		return strings.HasPrefix(name, match.name)
+	case match.image != "":
+		return strings.HasPrefix(image, match.image)
+	}
+	return true
+}
+
+func (match followMatch) String() string {
+	var parts []string
+	if match.name != "" {
+		parts = append(parts, tr("follow.byName", match.name))
+	}
+	if match.image != "" {
+		parts = append(parts, tr("follow.byImage", match.image))
+	}
+	if len(parts) == 0 {
+		return tr("follow.any")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// followCommand implements `whale follow [prefix] [--name P] [--image P]`:
+// waits for a matching container to start and opens its logs, switching to
+// the next one as soon as it starts, e.g. while iterating on `docker run`
+// in another terminal, until the user quits the logs. A bare prefix matches
+// the name or the image.
+func followCommand(args []string) {
+	cfg := currentConfig()
+	requireAction(cfg, "logs")
+
+	var match followMatch
+	prefix := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "--image":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			if args[i] == "--name" {
+				match.name = args[i+1]
+			} else {
+				match.image = args[i+1]
+			}
+			i++
+		default:
+			prefix = args[i]
+		}
+	}
+
+	events, errs := daemon.Watch(context.Background(), whale.WatchOptions{Filters: []string{"type=container", "event=start"}})
+	queue := &startQueue{ready: make(chan struct{}, 1)}
+	go func() {
+		for streamed := range events {
+			event := fromWhaleEvent(streamed)
+			matched := match.matches(event)
+			if prefix != "" {
+				matched = matched && (strings.HasPrefix(event.Actor.Attributes["name"], prefix) || strings.HasPrefix(event.Actor.Attributes["image"], prefix))
+			}
+			if matched {
+				queue.push(event)
+			}
+		}
+		queue.end()
+	}()
+
+	what := match.String()
+	if prefix != "" {
+		what = tr("follow.byNameOrImage", prefix)
+	}
+	fmt.Println(tr("follow.waiting", what))
+	for {
+		event, ok, ended := queue.pop()
+		if !ok {
+			if ended {
+				reason := tr("follow.eventsEnded")
+				if err := streamError(errs); err != nil {
+					reason = err.Error()
+				}
+				println(tr("error.follow"), reason)
+				os.Exit(1)
+			}
+			<-queue.ready
+			continue
+		}
+
+		name := event.Actor.Attributes["name"]
+		switched, err := followLogs(cfg, queue, name, event.Actor.ID)
+		if err != nil {
+			println(tr("error.follow"), err.Error())
+			os.Exit(1)
+		}
+		if !switched && !plainMode {
+			return
+		}
+		if !switched {
+			fmt.Println(tr("follow.waiting", what))
+		}
+	}
+}
+
+type followStartMsg struct{}
+
+// followView is the log viewer of the followed container, quitting as soon
+// as the next matching container starts.
+type followView struct {
+	*streamView
+	queue    *startQueue
+	done     chan struct{}
+	switched bool
+}
+
+func (view followView) Init() tea.Cmd {
+	return tea.Batch(view.streamView.Init(), view.waitStart())
+}
+
+func (view followView) waitStart() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-view.queue.ready:
+			return followStartMsg{}
+		case <-view.done:
+			return nil
+		}
+	}
+}
+
+func (view followView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(followStartMsg); ok {
+		view.queue.Lock()
+		pending := len(view.queue.starts) > 0
+		view.queue.Unlock()
+		if !pending {
+			return view, view.waitStart()
+		}
+		view.stop()
+		view.switched = true
+		return view, tea.Quit
+	}
+
+	_, cmd := view.streamView.Update(msg)
+	return view, cmd
+}
+
+// followLogs shows the logs of the container until the user quits or the
+// next matching one starts, which it reports. In plain mode, the logs are
+// printed until the container stops.
+func followLogs(cfg *Config, queue *startQueue, name string, id string) (bool, error) {
+	cmd := exec.Command("docker", "logs", "--follow", id)
+	title := tr("follow.title", name)
+	if plainMode {
+		fmt.Println(title)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		_, err := runChild(cmd, false)
+		return false, err
+	}
+
+	stream := newStreamView(cfg, title, cmd)
+	stream.split = true
+	err := stream.start()
+	if err != nil {
+		return false, err
+	}
+
+	view := followView{streamView: stream, queue: queue, done: make(chan struct{})}
+	finalModel, err := runProgram(view)
+	stream.stop()
+	close(view.done)
+	if err != nil {
+		return false, err
+	}
+	return finalModel.(followView).switched, nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type formField struct {
+	Label string
+	Value string
+	Hint  string
+	// Generate, when set, gives another value for the field on ctrl+g.
+	Generate func() string
+	// Check, when set, returns why the value can't be submitted, "" when
+	// it can.
+	Check func(string) string
+}
+
+// checkFields returns the index of the first field whose value doesn't pass its
+// check and why, -1 when they all do.
+func checkFields(fields []formField) (int, string) {
+	for i, field := range fields {
+		if field.Check == nil {
+			continue
+		}
+		if message := field.Check(field.Value); message != "" {
+			return i, message
+		}
+	}
+	return -1, ""
+}
+
+// formModel is a minimal multi-field text form used by the wizards.
+type formModel struct {
+	config    *Config
+	title     string
+	fields    []formField
+	cursor    int
+	submitted bool
+	// message is why the form couldn't be submitted.
+	message string
+}
+
+func initialFormModel(cfg *Config, title string, fields []formField) formModel {
+	return formModel{
+		config: cfg,
+		title:  title,
+		fields: fields,
+	}
+}
+
+func (form formModel) Init() tea.Cmd {
+	return nil
+}
+
+func (form formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		field := &form.fields[form.cursor]
+		form.message = ""
+
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyCtrlC:
+			return form, tea.Quit
+		case tea.KeyUp, tea.KeyShiftTab:
+			form.cursor = (form.cursor - 1 + len(form.fields)) % len(form.fields)
+		case tea.KeyDown, tea.KeyTab:
+			form.cursor = (form.cursor + 1) % len(form.fields)
+		case tea.KeyEnter:
+			if form.cursor == len(form.fields)-1 {
+				return form.submit()
+			}
+			form.cursor++
+		case tea.KeyCtrlS:
+			return form.submit()
+		case tea.KeyCtrlG:
+			if field.Generate != nil {
+				field.Value = field.Generate()
+			}
+		case tea.KeyBackspace:
+			runes := []rune(field.Value)
+			if len(runes) > 0 {
+				field.Value = string(runes[:len(runes)-1])
+			}
+		case tea.KeyCtrlU:
+			field.Value = ""
+		case tea.KeySpace:
+			field.Value += " "
+		case tea.KeyRunes:
+			field.Value += string(msg.Runes)
+		}
+	}
+
+	return form, nil
+}
+
+// submit quits with the fields, unless one doesn't pass its check: the
+// cursor goes to it and the form says why.
+func (form formModel) submit() (tea.Model, tea.Cmd) {
+	if i, message := checkFields(form.fields); i >= 0 {
+		form.cursor = i
+		form.message = message
+		return form, nil
+	}
+	form.submitted = true
+	return form, tea.Quit
+}
+
+func (form formModel) View() string {
+	s := renderHeader(form.config)
+	s += form.title + "\n\n"
+
+	for i, field := range form.fields {
+		if form.cursor == i {
+			s += fmt.Sprintf("%s %s: %s_\n", renderCursor(form.config), field.Label, renderActionSelected(form.config, field.Value, true))
+		} else {
+			s += fmt.Sprintf("  %s: %s\n", field.Label, field.Value)
+		}
+		if field.Hint != "" {
+			s += fmt.Sprintf("    %s\n", field.Hint)
+		}
+	}
+
+	if form.message != "" {
+		s += "\n" + renderColor(form.message, "31") + "\n"
+	}
+	s += "\n" + tr("form.help") + "\n"
+	return s
+}
+
+// fillForm lets the user edit the fields, returning false when cancelled.
+func fillForm(cfg *Config, title string, fields []formField) ([]formField, bool, error) {
+	if plainMode {
+		return fillFormPlain(title, fields)
+	}
+
+	finalModel, err := runProgram(initialFormModel(cfg, title, fields))
+	if err != nil {
+		return nil, false, err
+	}
+
+	form := finalModel.(formModel)
+	return form.fields, form.submitted, nil
+}
+
+func fillFormPlain(title string, fields []formField) ([]formField, bool, error) {
+	fmt.Println(title)
+	fmt.Println(tr("form.plainHelp"))
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field.Hint != "" {
+			fmt.Printf("  %s\n", field.Hint)
+		}
+		fmt.Printf("%s [%s]: ", field.Label, field.Value)
+
+		answer, err := plainInput.ReadString('\n')
+		if err == io.EOF && answer == "" {
+			fmt.Println()
+			return nil, false, nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+
+		answer = strings.TrimSpace(answer)
+		switch answer {
+		case "":
+		case "-":
+			fields[i].Value = ""
+		default:
+			fields[i].Value = answer
+		}
+
+		// Asked again until it passes, like the form wouldn't submit it.
+		if field.Check != nil {
+			if message := field.Check(fields[i].Value); message != "" {
+				fmt.Println(message)
+				i--
+			}
+		}
+	}
+
+	return fields, true, nil
+}
+
+// splitList splits a comma separated form value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
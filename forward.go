@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// forwardLabel marks the relay containers of Forward port with the ID of
+// the container they forward to.
+const forwardLabel = "whale.forward"
+
+// exposedTCPPorts are the TCP ports the image of the container declares
+// with EXPOSE, or that were added with --expose, sorted.
+func exposedTCPPorts(container Container) ([]int, error) {
+	output, err := dockerRead("container", "inspect", "--format", "{{json .Config.ExposedPorts}}", container.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var exposed map[string]struct{}
+	err = json.Unmarshal(output, &exposed)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []int
+	for port := range exposed {
+		number, protocol, _ := strings.Cut(port, "/")
+		if n, err := strconv.Atoi(number); err == nil && protocol != "udp" {
+			ports = append(ports, n)
+		}
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
+
+// parsePort reads a TCP port number, 1 to 65535.
+func parsePort(value string) (int, bool) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	return port, err == nil && port > 0 && port < 65536
+}
+
+// relayTarget is the network the relay joins and the address of the
+// container on it, the first network by name. Containers on the host
+// network need no relay, and the ones sharing the network of another
+// container have no address of their own.
+func relayTarget(container Container) (string, string, error) {
+	networks, err := containerNetworks(container)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := networks["host"]; ok {
+		return "", "", fmt.Errorf("%s", tr("forward.hostNetwork", container.Name))
+	}
+
+	var names []string
+	for name, network := range networks {
+		if network.IPAddress != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", "", fmt.Errorf("%s", tr("forward.noAddress", container.Name))
+	}
+	sort.Strings(names)
+	return names[0], networks[names[0]].IPAddress, nil
+}
+
+// removeRelays removes the relays left forwarding to the container, by a
+// whale that was killed before it could clean up.
+func removeRelays(container Container) {
+	output, err := dockerRead("container", "ls", "-aq", "--filter", "label="+forwardLabel+"="+container.ID)
+	if err != nil {
+		return
+	}
+	if ids := strings.Fields(string(output)); len(ids) > 0 {
+		exec.Command("docker", append([]string{"rm", "-f"}, ids...)...).Run()
+	}
+}
+
+// forwardPort publishes an internal port of the container on the loopback
+// of the daemon host through a throwaway socat container joining one of
+// its networks, until enter is pressed.
+func forwardPort(cfg *Config, container Container) error {
+	network, address, err := relayTarget(container)
+	if err != nil {
+		return err
+	}
+	exposed, err := exposedTCPPorts(container)
+	if err != nil {
+		return err
+	}
+
+	defaultPort, hint := "", tr("forward.portHint")
+	if len(exposed) > 0 {
+		defaultPort = strconv.Itoa(exposed[0])
+		var list []string
+		for _, port := range exposed {
+			list = append(list, strconv.Itoa(port))
+		}
+		hint = tr("forward.exposedHint", strings.Join(list, ", "))
+	}
+	fields, ok, err := fillForm(cfg, tr("forward.title", container.Name), []formField{
+		{Label: tr("forward.port"), Value: defaultPort, Hint: hint},
+		{Label: tr("forward.localPort"), Hint: tr("forward.localPortHint")},
+	})
+	if err != nil {
+		return err
+	}
+	if !ok || strings.TrimSpace(fields[0].Value) == "" {
+		return errCancelled
+	}
+	port, valid := parsePort(fields[0].Value)
+	if !valid {
+		return fmt.Errorf("%s", tr("forward.invalidPort", fields[0].Value))
+	}
+	localPort := port
+	if value := strings.TrimSpace(fields[1].Value); value != "" {
+		localPort, valid = parsePort(value)
+		if !valid {
+			return fmt.Errorf("%s", tr("forward.invalidPort", value))
+		}
+	}
+
+	removeRelays(container)
+	output, err := runWithSpinner(exec.Command("docker", "run", "-d", "--rm",
+		"--label", forwardLabel+"="+container.ID,
+		"--network", network,
+		"-p", fmt.Sprintf("127.0.0.1:%d:%d", localPort, port),
+		cfg.Forward.RelayImage,
+		fmt.Sprintf("TCP-LISTEN:%d,fork,reuseaddr", port),
+		fmt.Sprintf("TCP:%s:%d", address, port)))
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	// The ID comes last, after the pull progress when the image was missing.
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	id := lines[len(lines)-1]
+	defer exec.Command("docker", "rm", "-f", id).Run()
+
+	fmt.Printf("✓ %s\n", tr("forward.active", localPort, container.Name, port))
+	if u, err := url.Parse(dockerEndpoint()); err == nil && (u.Scheme == "ssh" || u.Scheme == "tcp") && u.Hostname() != "" {
+		fmt.Println(tr("forward.remote", u.Hostname(), localPort))
+	}
+	fmt.Print(tr("forward.stop"))
+	plainInput.ReadString('\n')
+	return nil
+}
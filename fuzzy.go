@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// noInteractive disables the pickers that resolve ambiguous CLI arguments.
+var noInteractive bool
+
+const (
+	matchNone = iota
+	matchFuzzy
+	matchSubstring
+	matchPrefix
+	matchExact
+)
+
+// matchScore ranks how well query matches a container name or ID.
+func matchScore(container Container, query string) int {
+	query = strings.ToLower(query)
+	name := strings.ToLower(container.Name)
+	id := strings.ToLower(container.ID)
+
+	switch {
+	case name == query || id == query:
+		return matchExact
+	case strings.HasPrefix(name, query) || strings.HasPrefix(id, query):
+		return matchPrefix
+	case strings.Contains(name, query):
+		return matchSubstring
+	case isSubsequence(query, name):
+		return matchFuzzy
+	}
+	return matchNone
+}
+
+// isSubsequence reports whether all runes of query appear in s in order,
+// so "wdb" matches "web-db".
+func isSubsequence(query string, s string) bool {
+	runes := []rune(query)
+	if len(runes) == 0 {
+		return true
+	}
+
+	i := 0
+	for _, r := range s {
+		if r == runes[i] {
+			i++
+			if i == len(runes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findContainers returns the containers matching query in their best match
+// tier: an exact name beats prefixes, which beat substrings and so on.
+func findContainers(containers []Container, query string) []Container {
+	best := matchNone
+	var matches []Container
+
+	for _, container := range containers {
+		score := matchScore(container, query)
+		if score == matchNone || score < best {
+			continue
+		}
+		if score > best {
+			best = score
+			matches = nil
+		}
+		matches = append(matches, container)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// resolveContainer finds the single container designated by query. When it
+// is ambiguous, the user picks among the candidates in the container list,
+// unless whale runs non-interactively, in which case the error lists them.
+func resolveContainer(cfg *Config, containers []Container, query string) (Container, error) {
+	matches := findContainers(containers, query)
+
+	switch len(matches) {
+	case 0:
+		return Container{}, fmt.Errorf("%s", tr("match.none", query))
+	case 1:
+		return matches[0], nil
+	}
+
+	if isInteractive() {
+		container, err := chooseContainer(cfg, matches)
+		if err != nil {
+			return Container{}, err
+		}
+		if container.ID == "" {
+			return Container{}, fmt.Errorf("%s", tr("match.cancelled", query))
+		}
+		return container, nil
+	}
+
+	var names []string
+	for _, match := range matches {
+		names = append(names, match.Name)
+	}
+	return Container{}, fmt.Errorf("%s", tr("match.ambiguous", query, strings.Join(names, ", ")))
+}
+
+// isInteractive reports whether prompts can be shown: a terminal on both
+// ends and no --no-interactive flag.
+func isInteractive() bool {
+	return !noInteractive && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gcCandidates are the containers the gc policy removes: exited more than
+// gc.exitedDays ago and matching gc.match. Without match rules, none are.
+func gcCandidates(cfg *Config, containers []Container, now time.Time) []Container {
+	rules := cfg.GC.Match
+	if len(rules.Images) == 0 && len(rules.Labels) == 0 {
+		return nil
+	}
+
+	age := time.Duration(max(cfg.GC.ExitedDays, 1)) * 24 * time.Hour
+	var candidates []Container
+	for _, container := range containers {
+		if !container.isExited() || container.FinishedAt.IsZero() || now.Sub(container.FinishedAt) < age {
+			continue
+		}
+		if rules.matches(container) {
+			candidates = append(candidates, container)
+		}
+	}
+	return candidates
+}
+
+// gcCommand implements `whale gc [--apply]`: lists what the gc policy
+// removes, and removes it with --apply. The list is what gc.enabled would
+// have `whale watch` remove as containers age.
+func gcCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	requireAction(cfg, "gc")
+	apply := len(args) > 0 && args[0] == "--apply"
+
+	if len(cfg.GC.Match.Images) == 0 && len(cfg.GC.Match.Labels) == 0 {
+		fmt.Println(tr("gc.noRules"))
+		return
+	}
+
+	now := time.Now()
+	candidates := gcCandidates(cfg, containers, now)
+	if len(candidates) == 0 {
+		fmt.Println(tr("gc.nothing", max(cfg.GC.ExitedDays, 1)))
+		return
+	}
+
+	table := [][]string{{tr("column.name"), tr("column.image"), tr("cleanup.reason")}}
+	for _, container := range candidates {
+		days := int(now.Sub(container.FinishedAt).Hours() / 24)
+		table = append(table, []string{container.Name, truncateImage(container.Image, cfg.List.ImageWidth), tr("cleanup.exitedDaysAgo", days)})
+	}
+	for _, row := range alignColumns(table) {
+		fmt.Println(row)
+	}
+	fmt.Println()
+
+	if !apply {
+		fmt.Println(tr("gc.dryRun"))
+		return
+	}
+
+	if removeGarbage(candidates) > 0 {
+		os.Exit(1)
+	}
+}
+
+// removeGarbage removes the containers, printing the outcome of each, and
+// returns how many couldn't be.
+func removeGarbage(containers []Container) int {
+	failed := 0
+	for _, container := range containers {
+		output, err := runWithSpinner(exec.Command("docker", "container", "rm", container.ID))
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v: %s\n", container.Name, err, strings.TrimSpace(string(output)))
+			continue
+		}
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("gc.removed"))
+	}
+	return failed
+}
+
+// collectGarbage is the gc policy run by `whale watch` when gc.enabled is
+// set, every gc.intervalMinutes.
+func collectGarbage(cfg *Config) {
+	interval := time.Duration(max(cfg.GC.IntervalMinutes, 1)) * time.Minute
+	for {
+		containers, err := getContainers(cfg)
+		if err != nil {
+			warn(tr("gc.failed", err))
+		} else {
+			removeGarbage(gcCandidates(cfg, containers, time.Now()))
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// deviceRequest mirrors one HostConfig.DeviceRequests entry, which is how
+// `docker run --gpus` records its allocation.
+type deviceRequest struct {
+	Driver       string     `json:"Driver"`
+	Count        int        `json:"Count"`
+	DeviceIDs    []string   `json:"DeviceIDs"`
+	Capabilities [][]string `json:"Capabilities"`
+}
+
+// isGPU reports requests for GPUs: either the nvidia driver or the "gpu"
+// capability, which is what --gpus sets when no driver is given.
+func (request deviceRequest) isGPU() bool {
+	if request.Driver == "nvidia" {
+		return true
+	}
+	for _, capabilities := range request.Capabilities {
+		if containsString(capabilities, "gpu") {
+			return true
+		}
+	}
+	return false
+}
+
+// describeGPUs summarizes the GPUs a container holds: "all", a count, or the
+// device IDs, or "" when it was started without --gpus.
+func describeGPUs(requests []deviceRequest) string {
+	var parts []string
+	for _, request := range requests {
+		if !request.isGPU() {
+			continue
+		}
+		switch {
+		case len(request.DeviceIDs) > 0:
+			parts = append(parts, strings.Join(request.DeviceIDs, ","))
+		case request.Count < 0:
+			parts = append(parts, "all")
+		case request.Count > 0:
+			parts = append(parts, strconv.Itoa(request.Count))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// gpuRuntimes returns the nvidia runtimes registered with the daemon.
+func gpuRuntimes() ([]string, error) {
+	output, err := dockerRead("info", "--format", "{{json .Runtimes}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var runtimes map[string]json.RawMessage
+	err = json.Unmarshal(output, &runtimes)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range runtimes {
+		if strings.Contains(name, "nvidia") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func checkGPURuntime() diagnostic {
+	result := diagnostic{Name: tr("doctor.gpu"), Status: diagnosticOK}
+
+	runtimes, err := gpuRuntimes()
+	switch {
+	case err != nil:
+		result.Status = diagnosticWarn
+		result.Detail = errorSummary(err)
+	case len(runtimes) == 0:
+		result.Detail = tr("doctor.gpuNone")
+		result.Hint = tr("doctor.gpuHint")
+	default:
+		sort.Strings(runtimes)
+		result.Detail = tr("doctor.gpuRuntimes", strings.Join(runtimes, ", "))
+	}
+
+	return result
+}
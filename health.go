@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// healthcheck mirrors Config.Healthcheck and State.Health of the inspect
+// document. Durations are in nanoseconds.
+type healthcheck struct {
+	Config struct {
+		Healthcheck *struct {
+			Test        []string      `json:"Test"`
+			Interval    time.Duration `json:"Interval"`
+			Timeout     time.Duration `json:"Timeout"`
+			StartPeriod time.Duration `json:"StartPeriod"`
+			Retries     int           `json:"Retries"`
+		} `json:"Healthcheck"`
+	} `json:"Config"`
+	State struct {
+		Health *struct {
+			Status        string `json:"Status"`
+			FailingStreak int    `json:"FailingStreak"`
+			Log           []struct {
+				Start    time.Time `json:"Start"`
+				ExitCode int       `json:"ExitCode"`
+				Output   string    `json:"Output"`
+			} `json:"Log"`
+		} `json:"Health"`
+	} `json:"State"`
+}
+
+func inspectHealthcheck(container Container) (healthcheck, error) {
+	var health healthcheck
+	output, err := cachedInspect(container.ID)
+	if err != nil {
+		return health, err
+	}
+	err = json.Unmarshal(output, &health)
+	return health, err
+}
+
+// healthcheckCommand turns the Test of a healthcheck into `docker exec`
+// arguments, or returns nil when the check is disabled.
+func healthcheckCommand(test []string, id string) []string {
+	if len(test) == 0 {
+		return nil
+	}
+	switch test[0] {
+	case "CMD":
+		return append([]string{"exec", id}, test[1:]...)
+	case "CMD-SHELL":
+		return []string{"exec", id, "sh", "-c", strings.Join(test[1:], " ")}
+	}
+	return nil
+}
+
+// showHealthcheck prints the healthcheck of a container with its last
+// results, then offers to run it right away to see what it prints.
+func showHealthcheck(cfg *Config, container Container) error {
+	health, err := inspectHealthcheck(container)
+	if err != nil {
+		return err
+	}
+
+	check := health.Config.Healthcheck
+	if check == nil || len(check.Test) == 0 || check.Test[0] == "NONE" {
+		fmt.Println(tr("health.none", container.Name))
+		return nil
+	}
+
+	fmt.Println(tr("health.title", container.Name))
+	fmt.Printf("  %-14s %s\n", tr("health.test"), strings.Join(check.Test, " "))
+	fmt.Printf("  %-14s %s\n", tr("health.interval"), orDefault(check.Interval, 30*time.Second))
+	fmt.Printf("  %-14s %s\n", tr("health.timeout"), orDefault(check.Timeout, 30*time.Second))
+	fmt.Printf("  %-14s %s\n", tr("health.startPeriod"), orDefault(check.StartPeriod, 0))
+	retries := check.Retries
+	if retries == 0 {
+		retries = 3
+	}
+	fmt.Printf("  %-14s %d\n", tr("health.retries"), retries)
+
+	if state := health.State.Health; state != nil {
+		fmt.Println()
+		fmt.Println(tr("health.status", state.Status, state.FailingStreak))
+		for _, result := range state.Log {
+			line := fmt.Sprintf("  %s  %d  %s", result.Start.Local().Format(time.TimeOnly), result.ExitCode, strings.TrimSpace(result.Output))
+			if result.ExitCode != 0 {
+				line = renderColor(line, "31")
+			}
+			fmt.Println(line)
+		}
+	}
+
+	args := healthcheckCommand(check.Test, container.ID)
+	if args == nil || container.State != "running" || !isActionAllowed(cfg, "runHealthcheck") || !isInteractive() {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Print(tr("health.runPrompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		return nil
+	}
+
+	started := time.Now()
+	output, err := runChild(exec.Command("docker", args...), true)
+	elapsed := time.Since(started).Round(time.Millisecond)
+
+	fmt.Print(string(output))
+	if err != nil {
+		fmt.Println(renderColor(tr("health.failed", err, elapsed), "31"))
+		return nil
+	}
+	fmt.Println(renderColor(tr("health.passed", elapsed), "32"))
+	return nil
+}
+
+// orDefault shows the daemon default for durations left unset.
+func orDefault(value time.Duration, fallback time.Duration) time.Duration {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// HighlightRule colors every match of Pattern in the log viewer.
+type HighlightRule struct {
+	Pattern string `json:"pattern"`
+	Color   string `json:"color"`
+}
+
+type highlighter struct {
+	pattern *regexp.Regexp
+	color   string
+}
+
+// compileHighlights validates the configured rules once, when cfg is loaded.
+func (cfg *Config) compileHighlights() error {
+	cfg.highlighters = nil
+	for _, rule := range cfg.Logs.Highlights {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid logs highlight pattern %q: %v", rule.Pattern, err)
+		}
+		cfg.highlighters = append(cfg.highlighters, highlighter{pattern: pattern, color: rule.Color})
+	}
+	return nil
+}
+
+// highlight colors the matches of the rules in line. When matches overlap,
+// the rule listed first wins.
+func highlight(cfg *Config, line string) string {
+	if len(cfg.highlighters) == 0 || plainMode {
+		return line
+	}
+
+	type span struct {
+		start, end int
+		color      string
+	}
+
+	var spans []span
+	taken := make([]bool, len(line))
+	for _, h := range cfg.highlighters {
+		for _, match := range h.pattern.FindAllStringIndex(line, -1) {
+			if match[0] == match[1] {
+				continue
+			}
+			free := true
+			for i := match[0]; i < match[1]; i++ {
+				if taken[i] {
+					free = false
+					break
+				}
+			}
+			if !free {
+				continue
+			}
+			for i := match[0]; i < match[1]; i++ {
+				taken[i] = true
+			}
+			spans = append(spans, span{match[0], match[1], h.color})
+		}
+	}
+	if len(spans) == 0 {
+		return line
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	s := ""
+	last := 0
+	for _, sp := range spans {
+		s += line[last:sp.start] + renderColor(line[sp.start:sp.end], sp.color)
+		last = sp.end
+	}
+	return s + line[last:]
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// hostsPath is the hosts file the hosts entries go to.
+const hostsPath = "/etc/hosts"
+
+// hostsMarker ends the lines whale adds, so that it only ever rewrites or
+// removes its own.
+const hostsMarker = "# whale"
+
+// hostsName is the name a container gets in the hosts file, its name under
+// hostsFile.domain: web.docker.
+func hostsName(cfg *Config, container Container) string {
+	return container.Name + "." + strings.TrimPrefix(cfg.HostsFile.Domain, ".")
+}
+
+// isWhaleHostsLine reports whether line is an entry whale added for name.
+func isWhaleHostsLine(line string, name string) bool {
+	if !strings.HasSuffix(strings.TrimSpace(line), hostsMarker) {
+		return false
+	}
+	fields := strings.Fields(line)
+	return len(fields) >= 2 && fields[1] == name
+}
+
+// hasHostsEntry reports whether the hosts file has an entry whale added for
+// the container.
+func hasHostsEntry(cfg *Config, container Container) bool {
+	data, err := os.ReadFile(hostsPath)
+	if err != nil {
+		return false
+	}
+	name := hostsName(cfg, container)
+	for _, line := range strings.Split(string(data), "\n") {
+		if isWhaleHostsLine(line, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// containerAddress is the address of the container on its first network by
+// name, what this machine reaches it at with a local daemon.
+func containerAddress(container Container) (string, error) {
+	networks, err := containerNetworks(container)
+	if err != nil {
+		return "", err
+	}
+
+	var names []string
+	for name, network := range networks {
+		if network.IPAddress != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("%s", tr("hosts.noAddress", container.Name))
+	}
+	sort.Strings(names)
+	return networks[names[0]].IPAddress, nil
+}
+
+// writeHosts replaces the hosts file, through sudo when whale may not write
+// it, sudo asking for the password on the terminal.
+func writeHosts(content string) error {
+	err := os.WriteFile(hostsPath, []byte(content), 0644)
+	if err == nil || !os.IsPermission(err) {
+		return err
+	}
+
+	fmt.Println(tr("hosts.sudo", hostsPath))
+	cmd := exec.Command("sudo", "tee", hostsPath)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// updateHostsEntry drops the entries whale added for the container, then
+// adds one pointing at address unless address is empty.
+func updateHostsEntry(cfg *Config, container Container, address string) error {
+	data, err := os.ReadFile(hostsPath)
+	if err != nil {
+		return err
+	}
+
+	name := hostsName(cfg, container)
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if !isWhaleHostsLine(line, name) {
+			lines = append(lines, line)
+		}
+	}
+	if address != "" {
+		lines = append(lines, fmt.Sprintf("%s\t%s %s", address, name, hostsMarker))
+	}
+	return writeHosts(strings.Join(lines, "\n") + "\n")
+}
+
+// addHostsEntry points <name>.<domain> at the address of the container, an
+// existing entry of whale being replaced since addresses change on restart.
+func addHostsEntry(cfg *Config, container Container) error {
+	address, err := containerAddress(container)
+	if err != nil {
+		return err
+	}
+
+	err = updateHostsEntry(cfg, container, address)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s\n", tr("hosts.added", hostsName(cfg, container), address))
+	return nil
+}
+
+func removeHostsEntry(cfg *Config, container Container) error {
+	err := updateHostsEntry(cfg, container, "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s\n", tr("hosts.removed", hostsName(cfg, container)))
+	return nil
+}
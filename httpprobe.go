@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// publishedPort is a TCP port of the container reachable from the daemon
+// host, from the Ports column: "0.0.0.0:8080->80/tcp".
+type publishedPort struct {
+	HostIP        string
+	HostPort      string
+	ContainerPort string
+}
+
+// publishedTCPPorts parses the published TCP ports, once each even when
+// bound on both IPv4 and IPv6.
+func publishedTCPPorts(ports string) []publishedPort {
+	seen := map[string]bool{}
+	var published []publishedPort
+	for _, item := range strings.Split(ports, ",") {
+		host, containerPort, ok := strings.Cut(strings.TrimSpace(item), "->")
+		if !ok || !strings.HasSuffix(containerPort, "/tcp") {
+			continue
+		}
+		index := strings.LastIndex(host, ":")
+		if index < 0 {
+			continue
+		}
+		hostIP, hostPort := strings.Trim(host[:index], "[]"), host[index+1:]
+		if seen[hostPort] {
+			continue
+		}
+		seen[hostPort] = true
+		published = append(published, publishedPort{HostIP: hostIP, HostPort: hostPort, ContainerPort: strings.TrimSuffix(containerPort, "/tcp")})
+	}
+	sort.SliceStable(published, func(i, j int) bool {
+		a, _ := strconv.Atoi(published[i].HostPort)
+		b, _ := strconv.Atoi(published[j].HostPort)
+		return a < b
+	})
+	return published
+}
+
+// probeHost is where published ports are reached from here: this machine
+// for local daemons, the host of ssh:// and tcp:// endpoints otherwise.
+func probeHost(port publishedPort) string {
+	if u, err := url.Parse(dockerEndpoint()); err == nil && (u.Scheme == "ssh" || u.Scheme == "tcp") && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	switch port.HostIP {
+	case "", "0.0.0.0", "::":
+		return "localhost"
+	}
+	return port.HostIP
+}
+
+// probeHTTP requests each published port of the container from here, over
+// HTTPS first for the usual TLS ports and over HTTP first otherwise, and
+// prints the status and latency, or why nothing answered.
+func probeHTTP(container Container) error {
+	ports := publishedTCPPorts(container.Ports)
+	if len(ports) == 0 {
+		fmt.Println(tr("probe.noPorts", container.Name))
+		return nil
+	}
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		// Dev servers mostly have self-signed certificates.
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		// Report redirects rather than follow them.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	for _, port := range ports {
+		schemes := []string{"http", "https"}
+		if strings.HasSuffix(port.ContainerPort, "443") {
+			schemes = []string{"https", "http"}
+		}
+
+		var err error
+		for _, scheme := range schemes {
+			target := fmt.Sprintf("%s://%s/", scheme, net.JoinHostPort(probeHost(port), port.HostPort))
+			var response *http.Response
+			start := time.Now()
+			response, err = client.Get(target)
+			if err == nil {
+				elapsed := time.Since(start)
+				response.Body.Close()
+				line := fmt.Sprintf("%s %s", response.Status, tr("probe.latency", elapsed.Round(time.Millisecond)))
+				if location := response.Header.Get("Location"); location != "" {
+					line += " → " + location
+				}
+				fmt.Printf("✓ %s: %s\n", target, line)
+				break
+			}
+			// Nothing listening, no point trying the other scheme.
+			if strings.Contains(err.Error(), "connection refused") {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Printf("✗ %s → %s: %s\n", port.HostPort, port.ContainerPort, probeError(err))
+		}
+	}
+	return nil
+}
+
+func probeError(err error) string {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "connection refused"):
+		return tr("probe.refused")
+	case strings.Contains(message, "Client.Timeout"), strings.Contains(message, "timeout"):
+		return tr("probe.timeout")
+	case strings.Contains(message, "malformed HTTP"), strings.Contains(message, "first record does not look like a TLS handshake"), strings.Contains(message, "EOF"):
+		return tr("probe.notHTTP")
+	}
+	return message
+}
@@ -0,0 +1,2706 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// catalogs holds the UI strings per language. English is the reference:
+// keys missing from another catalog fall back to it.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"error.getContainers":   "Error getting containers",
+		"error.chooseContainer": "Error choosing container",
+		"error.chooseAction":    "Error choosing action",
+		"error.doAction":        "Error doing action",
+		"action.selected":       "Action selected: %s",
+
+		"list.title":      "Choose a container:",
+		"list.empty":      "No containers.",
+		"list.sortFooter": "Sort: %s (o to change)",
+		"sort.none":       "none",
+		"sort.cpu":        "CPU",
+		"sort.memory":     "memory",
+
+		"column.id":       "ID",
+		"column.name":     "NAME",
+		"column.image":    "IMAGE",
+		"column.status":   "STATUS",
+		"column.ports":    "PORTS",
+		"column.project":  "PROJECT",
+		"column.cpu":      "CPU",
+		"column.mem":      "MEM",
+		"column.created":  "CREATED",
+		"column.restarts": "RESTARTS",
+		"list.crashLoop":  "crash loop",
+		"column.exit":     "EXIT",
+		"actions.exited":  "Exited: %s",
+
+		"picker.title": "Choose columns:",
+		"picker.help":  "space: toggle  shift+up/down: move  enter: apply  esc: cancel",
+
+		"actions.title":   "Container: %s",
+		"action.exit":     "Exit",
+		"action.copyId":   "Copy container ID",
+		"copy.idCopied":   "Container ID copied to clipboard",
+		"plain.prompt":    "Enter a number between 1 and %d, or q to quit: ",
+		"plain.invalid":   "%q is not a valid choice.",
+		"help.usage":      "Usage: whale [options]",
+		"help.run":        "Run the program",
+		"help.help":       "Show this help message",
+		"help.plain":      "Use numbered prompts instead of full-screen menus",
+		"help.keys":       "Keys in the container list:",
+		"help.keyStats":   "Toggle CPU/memory columns",
+		"help.keySort":    "Cycle sorting by CPU, memory or size",
+		"help.keyColumns": "Choose and order columns",
+		"help.keyFull":    "Reveal full IDs and image names",
+		"help.images":     "Browse images",
+		"help.keyDetails": "Toggle the details panel",
+		"help.logs":       "Follow the logs of a container (--tail N, --no-follow, --timestamps, --no-events, --save FILE, --compress, --export json|csv)",
+
+		"logs.usage":       "Usage: whale logs <name> [--tail N] [--no-follow] [--timestamps] [--no-events] [--save FILE [--compress]] [--export json|csv]",
+		"cli.missingValue": "Missing value for %s",
+		"cli.invalidValue": "Invalid value for %s: %s",
+		"match.none":       "No container matches %q",
+		"match.ambiguous":  "%q matches several containers: %s",
+
+		"help.stats":     "Print resource usage (--stream, --json, --interval N)",
+		"error.getStats": "Error getting stats",
+		"stats.memUsage": "MEM USAGE / LIMIT",
+		"stats.memPerc":  "MEM %",
+		"stats.netIO":    "NET I/O",
+		"stats.blockIO":  "BLOCK I/O",
+		"stats.pids":     "PIDS",
+
+		"help.inspect":    "Print the inspect JSON, or one value with --field .Path",
+		"inspect.usage":   "Usage: whale inspect <name> [--field .NetworkSettings.IPAddress]",
+		"inspect.noField": "No field %s",
+
+		"details.ports":     "Ports:",
+		"details.loading":   "Loading logs…",
+		"details.noLogs":    "No logs.",
+		"details.logsError": "Cannot read logs: %v",
+
+		"form.help":      "tab/up/down: move  enter: next/submit  ctrl+s: submit  ctrl+u: clear  esc: cancel",
+		"form.plainHelp": "Press enter to keep the value in brackets, or type - to clear it.",
+
+		"images.title":        "Choose an image:",
+		"images.actionsTitle": "Image: %s",
+		"error.getImages":     "Error getting images",
+		"error.chooseImage":   "Error choosing image",
+		"column.repository":   "REPOSITORY",
+		"column.tag":          "TAG",
+		"column.size":         "SIZE",
+
+		"action.createContainer": "Create container",
+		"create.title":           "Create a container from %s",
+		"create.name":            "Name",
+		"create.ports":           "Ports",
+		"create.portsHint":       "host:container[/protocol], comma separated",
+		"create.env":             "Environment",
+		"create.envHint":         "KEY=value, comma separated",
+		"create.volumes":         "Volumes",
+		"create.volumesHint":     "[host path or volume:]container path, comma separated",
+		"create.start":           "Start now",
+		"create.startHint":       "yes to docker run -d, no to docker create",
+		"create.done":            "Container %s created",
+
+		"help.init":           "Generate a Dockerfile and compose file for this project",
+		"error.init":          "Error initializing project",
+		"init.title":          "Scaffold a %s project",
+		"init.service":        "Service name",
+		"init.port":           "Port",
+		"init.overwrite":      "%s already exists, overwrite it?",
+		"init.written":        "%s written",
+		"init.next":           "What next?",
+		"action.composeUp":    "Build and run (docker compose up --build -d)",
+		"action.composeBuild": "Build only (docker compose build)",
+		"answer.yes":          "Yes",
+		"answer.no":           "No",
+
+		"help.compose":         "Browse compose projects",
+		"compose.title":        "Choose a compose project:",
+		"compose.empty":        "No compose projects.",
+		"compose.actionsTitle": "Project: %s",
+		"compose.watchTitle":   "docker compose watch: %s",
+		"error.chooseProject":  "Error choosing project",
+		"column.services":      "SERVICES",
+		"column.directory":     "DIRECTORY",
+		"action.composeWatch":  "Watch (docker compose watch)",
+
+		"stream.following": "following",
+		"stream.scrolled":  "%d lines above the end",
+		"stream.ended":     "ended",
+		"stream.failed":    "failed: %v",
+		"stream.help":      "up/down/pgup/pgdown: scroll  G: follow  w: wrap  left/right: scroll sideways  e/E: export JSON/CSV  q: quit",
+
+		"help.lifecycle":         "Run start, stop, restart, pause, unpause, kill or rm on each container (--wait: one at a time, healthy first)",
+		"lifecycle.usage":        "Usage: whale %s <name>...",
+		"lifecycle.done.start":   "started",
+		"lifecycle.done.stop":    "stopped",
+		"lifecycle.done.restart": "restarted",
+		"lifecycle.done.pause":   "paused",
+		"lifecycle.done.unpause": "unpaused",
+		"lifecycle.done.kill":    "killed",
+		"lifecycle.done.rm":      "removed",
+
+		"match.cancelled":    "No container chosen for %q",
+		"help.noInteractive": "Fail instead of asking when a name matches several containers",
+
+		"help.filter": "Only list containers matching a docker ps filter (repeatable)",
+
+		"header.host": "Docker host: %s",
+
+		"header.readOnly":    "[read-only]",
+		"help.readOnly":      "Disable every action that changes containers, images or projects",
+		"permissions.denied": "%s is not allowed here",
+
+		"confirm.prompt":     "Type %q to confirm: ",
+		"confirm.aborted":    "Aborted.",
+		"confirm.needsYes":   "Refusing to run a destructive operation without a terminal, pass --yes to confirm",
+		"confirm.bulkRemove": "This will remove: %s",
+		"help.prune":         "Remove all stopped containers and unused images, networks and volumes",
+
+		"spinner.running": "Running %s…",
+
+		"warning":       "warning: %s",
+		"retry.warning": "docker %s failed (%s), retrying (%d/%d)",
+
+		"help.doctor":                 "Diagnose the docker installation and daemon connection",
+		"doctor.binary":               "Docker CLI",
+		"doctor.binaryMissing":        "docker was not found in PATH",
+		"doctor.binaryHint":           "Install Docker: https://docs.docker.com/get-docker/",
+		"doctor.context":              "Context",
+		"doctor.daemon":               "Daemon",
+		"doctor.daemonDown":           "not reachable",
+		"doctor.daemonHint":           "Start Docker Desktop or the docker service (e.g. sudo systemctl start docker)",
+		"doctor.api":                  "API version",
+		"doctor.apiRange":             "client %s, daemon supports %s to %s",
+		"doctor.apiTooOld":            "The docker CLI is too old for this daemon, upgrade it",
+		"doctor.apiNegotiated":        "The CLI is newer than the daemon, requests are downgraded to API %s",
+		"doctor.socket":               "Socket",
+		"doctor.socketMissing":        "%s does not exist",
+		"doctor.socketPermissionHint": "Your user cannot access the docker socket, add it to the docker group or use rootless Docker",
+
+		"permission.staleSession":       "%s is in the docker group, but this session started before it was added. Log out and back in, or open a shell with the group:",
+		"permission.intro":              "%s is not allowed to use the Docker socket. Either:",
+		"permission.group":              "  1. add your user to the docker group:",
+		"permission.groupRelog":         "     then log out and back in (or run newgrp docker)",
+		"permission.groupWarning":       "members of the docker group are effectively root on this machine",
+		"permission.rootless":           "  2. or run Docker rootless, with a socket owned by your user:",
+		"doctor.socketStaleSessionHint": "You are in the docker group but this session predates it, log out and back in or run newgrp docker",
+
+		"list.emulated":    "⚠ %s",
+		"column.platform":  "PLATFORM",
+		"details.emulated": "⚠ Emulated: the image is %s but the engine runs on %s, expect slower and different behavior",
+
+		"column.gpu":         "GPU",
+		"details.gpus":       "GPUs: %s",
+		"details.gpuRuntime": "Runtime: %s (no GPUs requested)",
+		"doctor.gpu":         "GPU runtime",
+		"doctor.gpuNone":     "no nvidia runtime registered",
+		"doctor.gpuHint":     "Install the NVIDIA Container Toolkit to use docker run --gpus",
+		"doctor.gpuRuntimes": "available (%s)",
+
+		"help.resources":           "Sum memory and CPU limits of running containers against the host",
+		"error.getResources":       "Error reading resource limits:",
+		"resources.memLimit":       "MEM LIMIT",
+		"resources.memReservation": "MEM RESERVATION",
+		"resources.cpus":           "CPUS",
+		"resources.total":          "TOTAL",
+		"resources.commitment":     "%s: %s of %s (%.0f%%)",
+		"resources.overcommitted":  "over-committed",
+		"resources.unbounded":      "%d running containers have no memory or CPU limit and can use the whole host",
+
+		"stats.throttled":  "THROTTLED",
+		"stats.workingSet": "Memory is the cgroup v2 working set (usage minus reclaimable cache), what the OOM killer acts on.",
+
+		"logs.eventOOM":     "OOM-killed",
+		"logs.eventDie":     "exited with code %s",
+		"logs.eventKill":    "killed with signal %s",
+		"logs.eventRestart": "restarted",
+		"logs.eventStart":   "started",
+		"logs.eventHealth":  "health: %s",
+
+		"logs.compressLocal":        "--compress only applies to ssh:// hosts, downloading uncompressed",
+		"logs.downloaded":           "Saved %s (%s)",
+		"logs.downloadedCompressed": "Saved %s (%s, %s transferred)",
+		"error.saveLogs":            "Error saving logs:",
+
+		"stream.exported":     "exported to %s",
+		"stream.exportFailed": "export failed: %v",
+
+		"stream.column": "column %d",
+
+		"create.detach":       "Detach",
+		"create.detachHint":   "no to attach this terminal to the container (docker run -it)",
+		"create.attachedExit": "Container exited with code %d",
+
+		"action.runTask":   "Run a one-off task (docker run --rm)",
+		"task.title":       "Run a task with %s",
+		"task.command":     "Command",
+		"task.commandHint": "arguments given to the image, space separated",
+		"task.mount":       "Mount current directory",
+		"task.mountHint":   "yes to mount it on %s and run from there",
+		"task.running":     "Task: %s",
+
+		"action.devShell":         "Open a shell as the dev container user",
+		"action.devPorts":         "Show forwarded ports",
+		"action.restart":          "Restart",
+		"details.devcontainer":    "Dev container for %s",
+		"devcontainer.portsTitle": "Ports of %s (%s):",
+		"devcontainer.noPorts":    "No forwarded or published ports.",
+		"devcontainer.forwarded":  "  forwarded  %s",
+		"devcontainer.published":  "  published  %s",
+
+		"help.all": "Also show containers hidden by list.ignore",
+
+		"help.cleanup":           "Review cleanup suggestions and apply the ones you pick",
+		"check.help":             "space: toggle  a: all  enter: apply  esc: cancel",
+		"plain.promptMany":       "Enter numbers between 1 and %d separated by commas, all, or q to quit: ",
+		"error.cleanup":          "Error preparing cleanup:",
+		"cleanup.title":          "Cleanup suggestions:",
+		"cleanup.nothing":        "Nothing to clean up.",
+		"cleanup.kind":           "KIND",
+		"cleanup.reason":         "REASON",
+		"cleanup.kindProject":    "project",
+		"cleanup.kindContainer":  "container",
+		"cleanup.kindImage":      "image",
+		"cleanup.kindVolume":     "volume",
+		"cleanup.projectStopped": "%d containers, none running",
+		"cleanup.exitedDaysAgo":  "exited %d days ago",
+		"cleanup.dangling":       "dangling, %s, created %s",
+		"cleanup.unusedVolume":   "not used by any container",
+
+		"sort.size":    "size",
+		"details.size": "Writable layer: %s (%s with the image)",
+
+		"prune.keptProtected": "Kept protected image %s",
+
+		"help.tags":      "Remove old tags of repositories with many tags, keeping the newest N",
+		"tags.title":     "Tags to remove (keeping the newest %d of each repository):",
+		"tags.state":     "STATE",
+		"tags.inUse":     "used by a container",
+		"tags.protected": "protected",
+		"tags.kept":      "newest",
+		"tags.old":       "old",
+		"tags.nothing":   "No repository has several tags.",
+		"tags.refused":   "used by a container or protected, kept",
+
+		"help.registries":      "Show the registries you are logged into and log out of one",
+		"error.registries":     "Error reading registry credentials:",
+		"registries.none":      "Not logged into any registry.",
+		"registries.registry":  "REGISTRY",
+		"registries.user":      "USER",
+		"registries.source":    "STORED IN",
+		"registries.title":     "Choose a registry to log out of:",
+		"registries.loggedOut": "Logged out of %s",
+
+		"doctor.proxy":               "Daemon proxy",
+		"doctor.proxyNone":           "none",
+		"doctor.proxyExcept":         "except %s",
+		"doctor.proxyLocalhostHint":  "NO_PROXY doesn't include localhost, pulls from a local registry will go through the proxy and fail",
+		"doctor.proxyClientOnlyHint": "%s is set in your shell but the daemon doesn't use it; configure the proxy in daemon.json or the docker service",
+		"doctor.insecure":            "Insecure registries",
+		"doctor.insecureNone":        "none besides localhost",
+		"doctor.insecureHint":        "These registries are used without TLS verification, images pulled from them can be tampered with",
+
+		"action.healthcheck": "Show and run the healthcheck",
+		"health.none":        "%s has no healthcheck.",
+		"health.title":       "Healthcheck of %s:",
+		"health.test":        "Test",
+		"health.interval":    "Interval",
+		"health.timeout":     "Timeout",
+		"health.startPeriod": "Start period",
+		"health.retries":     "Retries",
+		"health.status":      "Status: %s, %d failures in a row. Last results:",
+		"health.runPrompt":   "Run the check now? [y/N] ",
+		"health.failed":      "✗ failed: %v (%s)",
+		"health.passed":      "✓ passed (%s)",
+
+		"action.wait":  "Wait until healthy",
+		"help.wait":    "Wait until containers are healthy (--timeout 90s), for scripts",
+		"wait.usage":   "Usage: whale wait <name>... [--timeout D]",
+		"wait.waiting": "Waiting for %s",
+		"wait.exited":  "exited with code %d",
+		"wait.timeout": "not healthy after %s",
+		"wait.ready":   "ready",
+
+		"check.helpReorder":        "space: toggle  shift+up/down: move  a: all  enter: apply  esc: cancel",
+		"lifecycle.stopping":       "not starting the next containers",
+		"lifecycle.nothingStopped": "No stopped containers.",
+		"lifecycle.orderTitle":     "Containers to start, in order:",
+
+		"action.editLabels":   "Edit labels (recreates the container)",
+		"labels.title":        "Labels of %s",
+		"labels.emptyRemoves": "empty to remove",
+		"labels.add":          "Add",
+		"labels.addHint":      "key=value, comma separated",
+		"labels.unchanged":    "Labels unchanged.",
+		"labels.done":         "labels updated",
+		"recreate.warning":    "⚠ Labels can't be changed in place: %s will be stopped, recreated with the same settings and started again. Files written inside the container outside of volumes are lost.",
+		"recreate.prompt":     "Recreate it? [y/N] ",
+		"recreate.rolledBack": "the original container was restored",
+
+		"action.editNote": "Edit note",
+		"notes.title":     "Note for %s",
+		"notes.note":      "Note",
+		"notes.hint":      "empty to delete",
+		"notes.saved":     "Note of %s saved",
+		"notes.invalid":   "ignoring %s, it is not valid JSON",
+		"notes.usage":     "Usage: whale note <name> [text...]",
+		"error.saveNote":  "Error saving note:",
+		"help.note":       "Print or set the note attached to a container",
+		"details.note":    "✎ %s",
+
+		"hint.alreadyRunning": "already running",
+		"hint.notRunning":     "not running",
+		"hint.paused":         "paused, unpause it first",
+		"hint.alreadyPaused":  "already paused",
+		"hint.notPaused":      "not paused",
+
+		"action.start":   "Start",
+		"action.stop":    "Stop",
+		"action.logs":    "Follow logs",
+		"action.rm":      "Remove",
+		"hint.stopFirst": "running, stop it first",
+		"remove.prompt":  "Remove %s? [y/N] ",
+		"list.shortcuts": "s start/stop · r restart · l logs · d remove",
+
+		"list.repeat":            ". repeat %s",
+		"list.repeatUnavailable": "%s is not available for %s",
+		"help.keyRepeat":         "Repeat the last action on the container under the cursor",
+
+		"action.composeSuspend":  "Suspend (stop the running containers, remember them)",
+		"action.composeResume":   "Resume (start the suspended containers again)",
+		"suspend.nothingRunning": "Nothing is running in %s.",
+		"suspend.done":           "suspended %s",
+		"suspend.resumed":        "resumed %s",
+		"suspend.missing":        "%d suspended containers no longer exist, skipping them.",
+
+		"help.keyShortcuts": "Start/stop, restart, follow logs or remove the container under the cursor",
+
+		"help.timings":     "Show how long startup took, step by step",
+		"timings.step":     "STEP",
+		"timings.duration": "DURATION",
+		"timings.total":    "total",
+		"timings.saved":    "Appended to %s",
+		"timings.notSaved": "could not save the timings: %v",
+
+		"api.pinned": "The daemon only supports API %s, older than the CLI's: some features may be missing",
+
+		"offline.banner": "Daemon unreachable: showing the containers as of %s, read-only, reconnecting…",
+
+		"help.list": "Print matching container names or IDs, one per line, for scripts",
+
+		"help.pick": "Pick a container and print its ID or another field, for pipelines",
+
+		"pick.noTerminal": "--stdin needs a terminal to read keys from:",
+
+		"action.shell": "Open a shell",
+
+		"actions.group.lifecycle": "Lifecycle",
+		"actions.group.inspect":   "Inspect",
+		"actions.group.files":     "Files",
+		"actions.group.danger":    "Danger zone",
+
+		"logs.title":     "docker logs -f: %s",
+		"action.pause":   "Pause",
+		"action.unpause": "Unpause",
+
+		"actions.filter":  "Filter: %s",
+		"actions.noMatch": "No matching action, Esc to clear the filter.",
+
+		"action.compareImage":   "Compare with another tag",
+		"imageDiff.chooseTitle": "Compare %s with:",
+		"imageDiff.noOtherTag":  "No other tag of %s to compare with.",
+		"imageDiff.title":       "%s → %s",
+		"imageDiff.identical":   "Both tags point to the same image.",
+		"imageDiff.size":        "Size: %s → %s (%s)",
+		"imageDiff.layers":      "Layers: %d shared, %d added, %d removed",
+		"imageDiff.config":      "Configuration changes:",
+		"imageDiff.sameConfig":  "No configuration change.",
+
+		"tabs.containers":          "Containers",
+		"tabs.images":              "Images",
+		"tabs.volumes":             "Volumes",
+		"tabs.networks":            "Networks",
+		"tabs.help":                "1-4/tab: switch tabs  ctrl+f: search",
+		"tabs.done.pullImage":      "pulled",
+		"tabs.done.tagImage":       "tagged",
+		"tabs.done.removeImage":    "removed",
+		"tabs.done.inspectVolume":  "inspected",
+		"tabs.done.removeVolume":   "removed",
+		"tabs.done.pruneVolumes":   "unused volumes removed",
+		"tabs.done.inspectNetwork": "inspected",
+		"tabs.done.removeNetwork":  "removed",
+		"tabs.done.pruneNetworks":  "unused networks removed",
+		"column.driver":            "DRIVER",
+		"column.scope":             "SCOPE",
+		"action.pullImage":         "Pull again",
+		"action.tagImage":          "Tag",
+		"action.removeImage":       "Remove",
+		"action.inspectVolume":     "Inspect",
+		"action.removeVolume":      "Remove",
+		"action.pruneVolumes":      "Prune unused volumes",
+		"action.inspectNetwork":    "Inspect",
+		"action.removeNetwork":     "Remove",
+		"action.pruneNetworks":     "Prune unused networks",
+		"images.tagTitle":          "Tag %s",
+		"images.newTag":            "New tag",
+		"images.newTagHint":        "repository:tag, e.g. registry.example.com/app:1.2",
+		"images.protectedRemove":   "protected by images.protected",
+		"volumes.title":            "Choose a volume:",
+		"volumes.actionsTitle":     "Volume: %s",
+		"volumes.inspectTitle":     "Volume %s",
+		"networks.title":           "Choose a network:",
+		"networks.actionsTitle":    "Network: %s",
+		"networks.inspectTitle":    "Network %s",
+		"error.chooseVolume":       "Error choosing volume",
+		"error.chooseNetwork":      "Error choosing network",
+		"help.runTab":              "Open the dashboard on a tab: containers, images, volumes or networks",
+		"help.keyTabs":             "Switch between the containers, images, volumes and networks tabs",
+
+		"help.report":          "Write a Markdown or JSON report of the containers, images, volumes and networks (--format, --output FILE, --with-env)",
+		"error.report":         "Error writing report",
+		"report.written":       "Report written to %s",
+		"report.title":         "Docker host report: %s",
+		"report.generated":     "Generated",
+		"report.daemon":        "Daemon",
+		"report.image":         "Image",
+		"report.command":       "Command",
+		"report.restartPolicy": "Restart policy",
+		"report.networks":      "Networks",
+		"report.mount":         "Mount",
+		"report.env":           "Environment",
+
+		"help.configExport":     "Bundle your config (theme, columns, actions, hosts) into one file (--only ui,hosts)",
+		"help.configImport":     "Merge a config bundle into your config (--replace, --only ui,hosts)",
+		"configBundle.usage":    "Usage: whale config export [FILE] [--only a,b] | whale config import FILE [--replace] [--only a,b]",
+		"error.configBundle":    "Error with the config bundle:",
+		"configBundle.exported": "Config exported to %s: %s",
+		"configBundle.imported": "Imported %s into %s",
+
+		"actions.group.packs": "Team actions",
+		"help.packs":          "List the actions of the packs in packs.sources, fetching them again with --update",
+		"packs.none":          "No action pack configured, add sources to packs.sources.",
+		"packs.summary":       "%s, %d actions",
+		"packs.unavailable":   "action pack %s unavailable: %v",
+		"packs.stale":         "could not refresh action pack %s, using the cached copy: %v",
+
+		"help.serve":         "Serve Prometheus metrics about the containers (--interval N)",
+		"serve.listening":    "Serving metrics on %s/metrics",
+		"error.serve":        "Error serving metrics:",
+		"serve.sampleFailed": "sampling containers failed: %v",
+
+		"help.watch":          "Report containers that crash, turn unhealthy or run out of memory, and post them to the webhooks",
+		"error.watch":         "Error following events:",
+		"watch.all":           "Watching every container, %d webhooks",
+		"watch.some":          "Watching %d containers, %d webhooks",
+		"watch.died":          "%s died with exit code %d",
+		"watch.oom":           "%s was killed for running out of memory",
+		"watch.unhealthy":     "%s is unhealthy",
+		"watch.webhookFailed": "could not notify %s: %v",
+
+		"action.backupVolume":     "Backup to a tar file",
+		"action.restoreVolume":    "Restore from a tar file",
+		"tabs.done.restoreVolume": "restored",
+		"backup.title":            "Backup volume %s",
+		"backup.file":             "File",
+		"backup.fileHint":         "local path of the .tar.gz to write",
+		"backup.done":             "✓ %s: backed up to %s",
+		"restore.title":           "Restore volume %s",
+		"restore.fileHint":        "local .tar.gz written by Backup",
+		"restore.wipe":            "Empty the volume first",
+		"restore.wipeHint":        "yes to delete what the backup doesn't contain",
+		"restore.wipeSummary":     "This will delete every file in volume %s before restoring.",
+
+		"action.browseFiles":  "Browse files",
+		"action.browseVolume": "Browse files",
+		"files.title":         "Files of %s",
+		"files.volumeTitle":   "Files of volume %s",
+		"files.loading":       "Loading...",
+		"files.empty":         "Empty directory",
+		"files.help":          "enter: open · ←: parent · c: copy here · q: back",
+		"files.copied":        "copied to %s",
+
+		"column.usedBy":   "USED BY",
+		"volumes.stopped": "%s (stopped)",
+		"volumes.unused":  "-",
+
+		"action.removeOrphanVolumes":    "Remove orphaned anonymous volumes",
+		"tabs.done.removeOrphanVolumes": "orphaned volumes removed",
+		"volumes.orphaned":              "- (orphaned)",
+		"volumes.noOrphans":             "no orphaned anonymous volume",
+		"volumes.age":                   "%d days ago",
+		"volumes.orphansTitle":          "%d anonymous volumes no container mounts, uncheck the ones to keep:",
+		"volumes.orphansFailed":         "%d of %d volumes could not be removed: %s",
+
+		"details.missingBind":      "Bind mount source missing: %s",
+		"create.missingBind":       "⚠ %s doesn't exist: docker will mount an empty directory created in its place.",
+		"create.missingBindPrompt": "Create the container anyway? [y/N] ",
+
+		"action.createNetwork":               "Create network",
+		"tabs.done.createNetwork":            "network created",
+		"networkCreate.title":                "Create network",
+		"networkCreate.name":                 "Name",
+		"networkCreate.driver":               "Driver",
+		"networkCreate.driverHint":           "bridge, overlay, macvlan, ipvlan...",
+		"networkCreate.subnet":               "Subnet",
+		"networkCreate.subnetHint":           "CIDR, e.g. 172.28.0.0/16; empty to let docker pick one",
+		"networkCreate.gateway":              "Gateway",
+		"networkCreate.gatewayHint":          "address inside the subnet; empty for the first one",
+		"networkCreate.internal":             "Internal",
+		"networkCreate.internalHint":         "yes to cut the network off from the outside",
+		"networkCreate.attachable":           "Attachable",
+		"networkCreate.attachableHint":       "yes to let standalone containers join an overlay network",
+		"networkCreate.invalidName":          "%q is not a valid network name",
+		"networkCreate.invalidSubnet":        "%s is not a CIDR subnet, e.g. 172.28.0.0/16",
+		"networkCreate.subnetHostBits":       "%s has host bits set, did you mean %s?",
+		"networkCreate.invalidGateway":       "%s is not an IP address",
+		"networkCreate.gatewayWithoutSubnet": "a gateway needs a subnet",
+		"networkCreate.gatewayOutside":       "gateway %s is outside %s",
+		"networkCreate.attachableOverlay":    "only overlay networks can be attachable",
+
+		"column.subnet":      "SUBNET",
+		"ipam.network":       "network %s (%s)",
+		"ipam.route":         "host route %s on %s",
+		"ipam.overlap":       "⚠ %s: %s overlaps %s",
+		"ipam.createOverlap": "⚠ %s overlaps %s: containers won't reach those addresses.",
+		"ipam.createPrompt":  "Create the network anyway? [y/N] ",
+
+		"action.debugDns":       "Debug DNS",
+		"actions.group.network": "Network",
+		"dns.title":             "DNS from %s",
+		"dns.names":             "Names",
+		"dns.namesHint":         "comma separated, looked up from inside the container",
+		"dns.defaultBridge":     "(default bridge only: no embedded DNS, container names won't resolve)",
+
+		"action.testConnectivity": "Test connectivity",
+		"connect.title":           "Reach from %s",
+		"connect.target":          "Target",
+		"connect.targetHint":      "container, service or host name, or an IP address",
+		"connect.port":            "Port",
+		"connect.portHint":        "TCP port; empty to ping",
+		"connect.reached":         "reachable",
+		"connect.reachedIn":       "reachable in %s ms",
+		"connect.dns":             "name not resolved (%s)",
+		"connect.refused":         "connection refused: nothing listens on that port",
+		"connect.timeout":         "timed out: a firewall or another network in between",
+		"connect.unreachable":     "unreachable",
+
+		"action.probeHttp": "Probe HTTP ports",
+		"probe.noPorts":    "%s publishes no TCP port.",
+		"probe.latency":    "in %s",
+		"probe.refused":    "connection refused: nothing listens on the host port",
+		"probe.timeout":    "no answer in 5s",
+		"probe.notHTTP":    "answers, but not over HTTP(S)",
+
+		"action.showTraffic": "Traffic per interface",
+		"traffic.title":      "Network traffic of %s",
+		"traffic.none":       "No network interface besides the loopback.",
+		"traffic.interface":  "INTERFACE",
+		"traffic.received":   "RECEIVED",
+		"traffic.sent":       "SENT",
+		"traffic.rxRate":     "RX/S",
+		"traffic.txRate":     "TX/S",
+		"traffic.help":       "q: back",
+		"stats.netRate":      "NET RATE",
+
+		"action.checkClock": "Check clock",
+		"clock.inSync":      "clock in sync with this machine (%s, ±%s)",
+		"clock.ahead":       "clock %s ahead of this machine, beyond %s",
+		"clock.behind":      "clock %s behind this machine, beyond %s",
+		"clock.hint":        "TLS handshakes and token checks may fail. Containers share the clock of the daemon's host: resync it, e.g. restart Docker Desktop or the VM after it slept.",
+
+		"create.pull":          "Pull",
+		"create.pullHint":      "always, missing or never",
+		"pull.invalid":         "unknown pull policy %q, expected always, missing or never",
+		"pull.always":          "Pull policy always: %s is pulled from its registry first.",
+		"pull.never":           "Pull policy never: only the local copy of %s is used, the registry is not contacted.",
+		"pull.present":         "Pull policy missing: %s is present locally, the registry is not contacted.",
+		"pull.absent":          "Pull policy missing: %s isn't present locally and will be pulled from its registry.",
+		"pull.compose.always":  "Pull policy always: every service image is pulled from its registry first.",
+		"pull.compose.missing": "Pull policy missing: only the service images not present locally are pulled.",
+		"pull.compose.never":   "Pull policy never: only local images are used, the registry is not contacted.",
+
+		"help.gc":    "List the exited containers the gc policy removes, --apply to remove them",
+		"gc.noRules": "No gc policy: set gc.match to the images or labels of the containers that may be removed.",
+		"gc.nothing": "No matching container exited more than %d days ago.",
+		"gc.dryRun":  "Dry run, nothing removed: run whale gc --apply to remove them, or set gc.enabled for whale watch to remove such containers as they age.",
+		"gc.removed": "removed",
+		"gc.failed":  "gc: %v",
+		"watch.gc":   "Removing matching containers exited more than %d days ago every %d minutes",
+
+		"stream.helpSplit":   "up/down/pgup/pgdown: scroll  G: follow  s: stdout/stderr  w: wrap  left/right: scroll sideways  e/E: export JSON/CSV  q: quit",
+		"stream.only.stdout": "stdout only",
+		"stream.only.stderr": "stderr only",
+
+		"help.follow":          "Open the logs of each new container matching a name or image prefix",
+		"error.follow":         "Error following containers:",
+		"follow.waiting":       "Waiting for %s to start... (ctrl+c to stop)",
+		"follow.any":           "a container",
+		"follow.byName":        "a container named %s*",
+		"follow.byImage":       "a container of image %s*",
+		"follow.byNameOrImage": "a container named %s* or of that image",
+		"follow.eventsEnded":   "docker events stopped",
+		"follow.title":         "Logs of %s, switching to the next matching container when it starts",
+
+		"composeUp.title":          "Starting %s",
+		"composeUp.state.pending":  "pending",
+		"composeUp.state.pulling":  "pulling",
+		"composeUp.state.pulled":   "pulled",
+		"composeUp.state.building": "building",
+		"composeUp.state.built":    "built",
+		"composeUp.state.creating": "creating",
+		"composeUp.state.created":  "created",
+		"composeUp.state.starting": "starting",
+		"composeUp.state.started":  "started",
+		"composeUp.state.waiting":  "waiting for its dependencies",
+		"composeUp.state.healthy":  "healthy",
+		"composeUp.state.failed":   "failed",
+		"composeUp.logs":           "Last logs:",
+		"composeUp.running":        "compose up running",
+		"composeUp.done":           "all up",
+		"composeUp.failed":         "compose up failed: %v",
+		"composeUp.help":           "up/down: move  enter: show output  q: quit",
+
+		"drift.image":             "image %s, the file says %s",
+		"drift.env":               "environment %s",
+		"drift.ports":             "published ports",
+		"drift.badge":             "⚠ %s needs recreate: %s",
+		"drift.unavailable":       "⚠ the compose file couldn't be compared: %v",
+		"action.composeReconcile": "Reconcile: recreate the services that drifted from the compose file",
+		"compose.reconcileTitle":  "Recreating %s",
+
+		"action.editCommand":     "Edit startup command (recreates the container)",
+		"command.title":          "Startup command of %s",
+		"command.entrypoint":     "Entrypoint",
+		"command.entrypointHint": "empty for none",
+		"command.command":        "Command",
+		"command.commandHint":    "e.g. sleep infinity to keep a crashing container up and look inside",
+		"command.unchanged":      "The startup command is unchanged.",
+		"command.summary":        "New startup command: %s",
+		"command.unterminated":   "unterminated quote or escape in %q",
+		"command.done":           "recreated with the new startup command",
+
+		"action.rescue":    "Rescue (recreate idle, without restarts, and open a shell)",
+		"action.endRescue": "End rescue (recreate with the original command)",
+		"rescue.plan":      "Rescue %s:\n  1. turn off its restart policy\n  2. recreate it with the same mounts, environment and networks, running an idle command instead of its own\n  3. open a shell inside\nEnd rescue, in its menu, recreates it with its command and restart policy back.",
+		"rescue.prompt":    "Rescue it? [y/N] ",
+		"rescue.done":      "recreated idle, opening a shell",
+		"rescue.endPlan":   "%s will be recreated running %s again, with its restart policy.",
+		"rescue.ended":     "recreated with its original command",
+
+		"action.showProvenance": "Show build provenance",
+		"provenance.title":      "Provenance of %s",
+		"provenance.none":       "No provenance: the image has no source or revision label and no build attestation.",
+		"provenance.labelsOnly": "From the image labels, no build attestation was found.",
+		"provenance.source":     "Source",
+		"provenance.revision":   "Revision",
+		"provenance.version":    "Version",
+		"provenance.created":    "Built",
+		"provenance.url":        "Home page",
+		"provenance.dockerfile": "Dockerfile",
+		"provenance.builder":    "Builder",
+		"provenance.commit":     "Commit",
+		"provenance.copyPrompt": "Copy the commit link? [y/N] ",
+		"provenance.copied":     "Commit link copied to the clipboard.",
+
+		"help.digests":          "Warn about running containers whose tag moved to another digest since they started",
+		"digests.startedFrom":   "STARTED FROM",
+		"digests.pinned":        "pinned by digest",
+		"digests.pulledLocally": "tag now points to %s locally, not recreated",
+		"digests.noDigest":      "built locally, no registry digest",
+		"digests.unreachable":   "registry unreachable",
+		"digests.moved":         "tag moved to %s in the registry",
+		"digests.unchanged":     "unchanged",
+		"digests.none":          "No running container.",
+		"digests.saveFailed":    "could not record the start digests: %v",
+		"digests.movedHint":     "Those containers no longer run what their tag points to: recreating them would run another image.",
+
+		"list.exported":     "%d containers exported to %s",
+		"list.exportFailed": "export failed: %v",
+		"help.keyExport":    "Export the list as shown to CSV, or to a Markdown table",
+
+		"keys.pending":     "%s … (esc to cancel)",
+		"keys.unavailable": "%s isn't available for %s",
+		"help.keyChords":   "Go to a tab, see keys.chords for more sequences",
+
+		"macro.started":             "recording a macro, m again to save it",
+		"macro.recording":           "● recording a macro: %d steps, m to save",
+		"macro.empty":               "nothing recorded, no macro saved",
+		"macro.saveTitle":           "Save the macro %s",
+		"macro.name":                "Name",
+		"macro.nameHint":            "to play it with M or whale macro <name>",
+		"macro.discarded":           "macro discarded",
+		"macro.saved":               "✓ macro %s saved, %d steps",
+		"macro.none":                "No macro yet: press m in the list to record one.",
+		"macro.chooseTitle":         "Play a macro on %s:",
+		"macro.step":                "%s %d/%d: %s on %s",
+		"macro.stepLabel.pullImage": "Pull the image again",
+		"macro.stepLabel.recreate":  "Recreate from its settings",
+		"macro.unknown":             "No macro named %s.",
+		"macro.usage":               "Usage: whale macro <macro> <name>...",
+		"macro.played":              "macro %s played",
+		"help.macro":                "Play a recorded macro on each container, or list the macros",
+		"help.keyMacro":             "Record a macro of the actions run from the list, play one",
+
+		"action.playMacro": "Play a macro",
+
+		"header.age":      "data %s old",
+		"refresh.running": "refreshing…",
+		"refresh.failed":  "refresh failed: %v",
+		"help.keyRefresh": "Fetch the list or tab again, r too on the resource tabs",
+
+		"runFile.usage":   "Usage: whale run-file <file.yaml|file.json> [--dry-run]",
+		"runFile.pulled":  "pulled",
+		"runFile.pruned":  "pruned",
+		"runFile.stopped": "Stopped, %d steps not run (set continueOnError to go on).",
+		"runFile.dryRun":  "Dry run, nothing was changed.",
+		"runFile.summary": "%d steps: %d ok, %d failed",
+		"help.runFile":    "Run the steps of a YAML or JSON ops file, --dry-run to list them",
+
+		"prune.kind.container":  "container",
+		"prune.kind.image":      "image",
+		"prune.kind.network":    "network",
+		"prune.kind.volume":     "volume",
+		"prune.kind.buildCache": "build cache",
+		"prune.buildCache":      "reclaimable layers",
+		"prune.previewTitle":    "%d items can go, %s in total. Uncheck the ones to keep:",
+		"prune.nothing":         "Nothing to prune.",
+		"prune.chosen":          "This will remove the %d items checked, %s in total.",
+		"prune.volumeData":      "The data of the volumes checked will be lost.",
+		"prune.freed":           "Removed %d items, %s freed.",
+		"prune.failed":          "%d of %d items couldn't be removed",
+
+		"action.forwardPort":    "Forward port",
+		"forward.title":         "Forward a port of %s to localhost",
+		"forward.port":          "Container port",
+		"forward.portHint":      "the port the service listens on inside the container",
+		"forward.exposedHint":   "exposed: %s",
+		"forward.localPort":     "Local port",
+		"forward.localPortHint": "empty for the same port",
+		"forward.invalidPort":   "invalid port %q",
+		"forward.hostNetwork":   "%s uses the host network, its ports are already reachable",
+		"forward.noAddress":     "%s has no address of its own on a network",
+		"forward.active":        "localhost:%d → %s:%d",
+		"forward.remote":        "The port is bound on the loopback of %[1]s, run ssh -L %[2]d:localhost:%[2]d %[1]s to reach it from here.",
+		"forward.stop":          "Press enter to stop forwarding. ",
+
+		"action.addHostsEntry":    "Add to /etc/hosts",
+		"action.removeHostsEntry": "Remove from /etc/hosts",
+		"hosts.noAddress":         "%s has no address of its own on a network",
+		"hosts.sudo":              "%s isn't writable, asking sudo.",
+		"hosts.added":             "%s → %s in the hosts file",
+		"hosts.removed":           "%s removed from the hosts file",
+
+		"create.entrypoint":     "Entrypoint",
+		"create.entrypointHint": "empty for the image's %s; overriding it drops the image's command",
+		"create.command":        "Command",
+		"create.commandHint":    "empty for the image's %s, arguments after the image replace it",
+		"create.user":           "User",
+		"create.userHint":       "empty for the image's, e.g. 1000:1000",
+		"create.workdir":        "Working directory",
+		"create.workdirHint":    "empty for the image's",
+		"create.confirm":        "Create the container? [y/N] ",
+		"argv.title":            "The container will run:",
+		"argv.entrypoint":       "Entrypoint",
+		"argv.cmd":              "Command",
+		"argv.user":             "User",
+		"argv.workdir":          "Workdir",
+		"argv.fromImage":        "from the image",
+		"argv.overridden":       "overridden",
+		"argv.default":          "docker's default",
+		"argv.cmdDropped":       "the image's %s is dropped since the entrypoint is overridden",
+		"argv.runs":             "→ %s",
+		"argv.empty":            "Neither an entrypoint nor a command: the container will fail to start.",
+		"argv.shellForm":        "The entrypoint is in shell form, it runs through sh -c and ignores the command.",
+
+		"action.checkEnvironment": "Check environment",
+		"sanity.title":            "Environment of %s:",
+		"sanity.configured":       "CONFIGURED",
+		"sanity.probed":           "IN THE CONTAINER",
+		"sanity.timezone":         "Timezone",
+		"sanity.locale":           "Locale",
+		"sanity.user":             "User",
+		"sanity.workdir":          "Workdir",
+		"sanity.openFiles":        "Open files",
+		"sanity.processes":        "Processes",
+		"sanity.notRunning":       "%s isn't running, only its configuration is shown.",
+		"sanity.probeFailed":      "Couldn't probe the container: %v",
+		"sanity.utc":              "No TZ nor /etc/localtime: the container runs on UTC.",
+		"sanity.noLocale":         "No LANG nor LC_ALL: the POSIX locale can garble non-ASCII text.",
+		"sanity.root":             "The processes run as root.",
+
+		"errhint.nameConflict":  "A container named %s already exists: remove it from the list with d, or pick another name.",
+		"errhint.portContainer": "Port %s of the host is published by %s: stop it from the list with s, or publish another host port.",
+		"errhint.portHost":      "Port %s of the host is taken by a process outside docker: free it, or publish another host port.",
+		"errhint.noSpace":       "The disk of the daemon is full: whale prune lists what can go, with the sizes.",
+		"errhint.manifest":      "The tag %s doesn't exist in the registry: check its spelling, or pull another one with Pull on the images tab.",
+		"errhint.accessDenied":  "The image doesn't exist or needs a login: whale registries shows who you are logged in as.",
+		"errhint.daemon":        "The daemon can't be reached: whale doctor checks the socket, the context and the permissions.",
+
+		"errhint.manifestUnknown": "The tag doesn't exist in the registry: check its spelling, or pull another one with Pull on the images tab.",
+
+		"timeout.expired": "%s killed after %s, the timeout set for %q in the config",
+
+		"column.host": "HOST",
+		"help.ps":     "List the containers, of every configured host with --all-hosts",
+
+		"session.writeFailed": "couldn't write the session to %s: %v",
+		"session.usage":       "Usage: whale replay FILE [--dry-run]",
+		"session.header":      "Session recorded on %s at %s, replayed on %s",
+		"session.skipFailed":  "- %s %s: skipped, it failed then (%s)",
+		"session.skipAction":  "- %s %s: skipped, it can't be replayed unattended",
+		"session.nothing":     "Nothing to replay.",
+		"help.replay":         "Run the actions of a recorded session again, --dry-run to review them",
+		"help.record":         "Record the actions run and their results to a file",
+
+		"create.nameHint":    "ctrl+g: another name, empty to let docker pick one",
+		"create.nameInvalid": "%s is not a valid container name: letters, digits, _ . and - only, starting with a letter or digit",
+		"create.nameTaken":   "A container named %s already exists",
+
+		"search.title": "Search containers, images, volumes and networks by name, ID or label:",
+		"search.none":  "No match",
+		"search.kind":  "KIND",
+		"search.match": "MATCH",
+		"search.more":  "… %d more, refine the search",
+		"search.help":  "type to search  up/down: move  enter: open in its tab  esc: back",
+		"error.search": "Error searching:",
+
+		"help.jump": "Open the action menu of the container matching <name>, or the list of those matching it",
+
+		"list.recent": "Recent",
+		"list.others": "Others",
+
+		"help.uptime":      "Restarts, downtime and availability of each container over the last 24h and 7d",
+		"error.getEvents":  "Error reading daemon events:",
+		"uptime.uptime":    "UP %s",
+		"uptime.restarts":  "RESTARTS %s",
+		"uptime.downtime":  "DOWN %s",
+		"uptime.truncated": "The daemon only keeps its last %d events: on this host they don't reach back the whole period, older downtime isn't counted.",
+
+		"answer.yesWords": "y,yes",
+
+		"offline.cacheFailed": "couldn't save the list for offline use: %v",
+
+		"runFile.removed":     "removed",
+		"session.parseFailed": "error parsing %s: %v",
+		"session.stepInvalid": "%s %s: %v",
+
+		"tabs.done.browseVolume": "browsed",
+
+		"tabs.done.backupVolume": "backed up",
+	},
+	"fr": {
+		"error.getContainers":   "Erreur lors de la récupération des conteneurs",
+		"error.chooseContainer": "Erreur lors du choix du conteneur",
+		"error.chooseAction":    "Erreur lors du choix de l'action",
+		"error.doAction":        "Erreur lors de l'exécution de l'action",
+		"action.selected":       "Action choisie : %s",
+
+		"list.title":      "Choisissez un conteneur :",
+		"list.empty":      "Aucun conteneur.",
+		"list.sortFooter": "Tri : %s (o pour changer)",
+		"sort.none":       "aucun",
+		"sort.cpu":        "CPU",
+		"sort.memory":     "mémoire",
+
+		"column.id":       "ID",
+		"column.name":     "NOM",
+		"column.image":    "IMAGE",
+		"column.status":   "STATUT",
+		"column.ports":    "PORTS",
+		"column.project":  "PROJET",
+		"column.cpu":      "CPU",
+		"column.mem":      "MÉM",
+		"column.created":  "CRÉÉ",
+		"column.restarts": "REDÉMARRAGES",
+		"list.crashLoop":  "boucle de crash",
+		"column.exit":     "SORTIE",
+		"actions.exited":  "Arrêté : %s",
+
+		"picker.title": "Choisissez les colonnes :",
+		"picker.help":  "espace : activer  maj+haut/bas : déplacer  entrée : appliquer  échap : annuler",
+
+		"actions.title":   "Conteneur : %s",
+		"action.exit":     "Quitter",
+		"action.copyId":   "Copier l'ID du conteneur",
+		"copy.idCopied":   "ID du conteneur copié dans le presse-papiers",
+		"plain.prompt":    "Entrez un nombre entre 1 et %d, ou q pour quitter : ",
+		"plain.invalid":   "%q n'est pas un choix valide.",
+		"help.usage":      "Utilisation : whale [options]",
+		"help.run":        "Lancer le programme",
+		"help.help":       "Afficher ce message d'aide",
+		"help.plain":      "Utiliser des invites numérotées au lieu des menus plein écran",
+		"help.keys":       "Touches dans la liste des conteneurs :",
+		"help.keyStats":   "Afficher ou masquer les colonnes CPU/mémoire",
+		"help.keySort":    "Trier par CPU, mémoire ou taille",
+		"help.keyColumns": "Choisir et ordonner les colonnes",
+		"help.keyFull":    "Afficher les IDs et noms d'images complets",
+		"help.images":     "Parcourir les images",
+		"help.keyDetails": "Afficher ou masquer le panneau de détails",
+		"help.logs":       "Suivre les logs d'un conteneur (--tail N, --no-follow, --timestamps, --no-events, --save FILE, --compress, --export json|csv)",
+
+		"logs.usage":       "Utilisation : whale logs <nom> [--tail N] [--no-follow] [--timestamps] [--no-events] [--save FILE [--compress]] [--export json|csv]",
+		"cli.missingValue": "Valeur manquante pour %s",
+		"cli.invalidValue": "Valeur invalide pour %s : %s",
+		"match.none":       "Aucun conteneur ne correspond à %q",
+		"match.ambiguous":  "%q correspond à plusieurs conteneurs : %s",
+
+		"help.stats":     "Afficher la consommation des ressources (--stream, --json, --interval N)",
+		"error.getStats": "Erreur lors de la récupération des stats",
+		"stats.memUsage": "MÉM UTILISÉE / LIMITE",
+		"stats.memPerc":  "MÉM %",
+		"stats.netIO":    "E/S RÉSEAU",
+		"stats.blockIO":  "E/S DISQUE",
+		"stats.pids":     "PIDS",
+
+		"help.inspect":    "Afficher le JSON d'inspection, ou une valeur avec --field .Chemin",
+		"inspect.usage":   "Utilisation : whale inspect <nom> [--field .NetworkSettings.IPAddress]",
+		"inspect.noField": "Aucun champ %s",
+
+		"details.ports":     "Ports :",
+		"details.loading":   "Chargement des logs…",
+		"details.noLogs":    "Aucun log.",
+		"details.logsError": "Impossible de lire les logs : %v",
+
+		"form.help":      "tab/haut/bas : déplacer  entrée : suivant/valider  ctrl+s : valider  ctrl+u : effacer  échap : annuler",
+		"form.plainHelp": "Appuyez sur entrée pour garder la valeur entre crochets, ou tapez - pour l'effacer.",
+
+		"images.title":        "Choisissez une image :",
+		"images.actionsTitle": "Image : %s",
+		"error.getImages":     "Erreur lors de la récupération des images",
+		"error.chooseImage":   "Erreur lors du choix de l'image",
+		"column.repository":   "DÉPÔT",
+		"column.tag":          "TAG",
+		"column.size":         "TAILLE",
+
+		"action.createContainer": "Créer un conteneur",
+		"create.title":           "Créer un conteneur depuis %s",
+		"create.name":            "Nom",
+		"create.ports":           "Ports",
+		"create.portsHint":       "hôte:conteneur[/protocole], séparés par des virgules",
+		"create.env":             "Environnement",
+		"create.envHint":         "CLÉ=valeur, séparés par des virgules",
+		"create.volumes":         "Volumes",
+		"create.volumesHint":     "[chemin hôte ou volume:]chemin conteneur, séparés par des virgules",
+		"create.start":           "Démarrer maintenant",
+		"create.startHint":       "oui pour docker run -d, non pour docker create",
+		"create.done":            "Conteneur %s créé",
+
+		"help.init":           "Générer un Dockerfile et un fichier compose pour ce projet",
+		"error.init":          "Erreur lors de l'initialisation du projet",
+		"init.title":          "Générer un projet %s",
+		"init.service":        "Nom du service",
+		"init.port":           "Port",
+		"init.overwrite":      "%s existe déjà, l'écraser ?",
+		"init.written":        "%s écrit",
+		"init.next":           "Et ensuite ?",
+		"action.composeUp":    "Construire et lancer (docker compose up --build -d)",
+		"action.composeBuild": "Construire seulement (docker compose build)",
+		"answer.yes":          "Oui",
+		"answer.no":           "Non",
+
+		"help.compose":         "Parcourir les projets compose",
+		"compose.title":        "Choisissez un projet compose :",
+		"compose.empty":        "Aucun projet compose.",
+		"compose.actionsTitle": "Projet : %s",
+		"compose.watchTitle":   "docker compose watch : %s",
+		"error.chooseProject":  "Erreur lors du choix du projet",
+		"column.services":      "SERVICES",
+		"column.directory":     "DOSSIER",
+		"action.composeWatch":  "Surveiller (docker compose watch)",
+
+		"stream.following": "suivi en cours",
+		"stream.scrolled":  "%d lignes au-dessus de la fin",
+		"stream.ended":     "terminé",
+		"stream.failed":    "échec : %v",
+		"stream.help":      "haut/bas/pgup/pgdown : défiler  G : suivre  w : retour à la ligne  gauche/droite : défiler latéralement  e/E : exporter JSON/CSV  q : quitter",
+
+		"help.lifecycle":         "Lancer start, stop, restart, pause, unpause, kill ou rm sur chaque conteneur (--wait : un par un, sain d'abord)",
+		"lifecycle.usage":        "Utilisation : whale %s <nom>...",
+		"lifecycle.done.start":   "démarré",
+		"lifecycle.done.stop":    "arrêté",
+		"lifecycle.done.restart": "redémarré",
+		"lifecycle.done.pause":   "mis en pause",
+		"lifecycle.done.unpause": "repris",
+		"lifecycle.done.kill":    "tué",
+		"lifecycle.done.rm":      "supprimé",
+
+		"match.cancelled":    "Aucun conteneur choisi pour %q",
+		"help.noInteractive": "Échouer au lieu de demander quand un nom correspond à plusieurs conteneurs",
+
+		"help.filter": "Ne lister que les conteneurs correspondant à un filtre docker ps (répétable)",
+
+		"header.host": "Hôte Docker : %s",
+
+		"header.readOnly":    "[lecture seule]",
+		"help.readOnly":      "Désactiver toutes les actions qui modifient conteneurs, images ou projets",
+		"permissions.denied": "%s n'est pas autorisé ici",
+
+		"confirm.prompt":     "Tapez %q pour confirmer : ",
+		"confirm.aborted":    "Annulé.",
+		"confirm.needsYes":   "Refus de lancer une opération destructive sans terminal, passez --yes pour confirmer",
+		"confirm.bulkRemove": "Ceci va supprimer : %s",
+		"help.prune":         "Supprimer les conteneurs arrêtés et les images, réseaux et volumes inutilisés",
+
+		"spinner.running": "Exécution de %s…",
+
+		"warning":       "attention : %s",
+		"retry.warning": "docker %s a échoué (%s), nouvelle tentative (%d/%d)",
+
+		"help.doctor":                 "Diagnostiquer l'installation de docker et la connexion au démon",
+		"doctor.binary":               "CLI Docker",
+		"doctor.binaryMissing":        "docker est introuvable dans le PATH",
+		"doctor.binaryHint":           "Installez Docker : https://docs.docker.com/get-docker/",
+		"doctor.context":              "Contexte",
+		"doctor.daemon":               "Démon",
+		"doctor.daemonDown":           "injoignable",
+		"doctor.daemonHint":           "Démarrez Docker Desktop ou le service docker (ex. sudo systemctl start docker)",
+		"doctor.api":                  "Version d'API",
+		"doctor.apiRange":             "client %s, le démon accepte de %s à %s",
+		"doctor.apiTooOld":            "La CLI docker est trop ancienne pour ce démon, mettez-la à jour",
+		"doctor.apiNegotiated":        "La CLI est plus récente que le démon, les requêtes utilisent l'API %s",
+		"doctor.socket":               "Socket",
+		"doctor.socketMissing":        "%s n'existe pas",
+		"doctor.socketPermissionHint": "Votre utilisateur n'a pas accès au socket docker, ajoutez-le au groupe docker ou utilisez Docker rootless",
+
+		"permission.staleSession":       "%s fait partie du groupe docker, mais cette session a démarré avant son ajout. Déconnectez-vous puis reconnectez-vous, ou ouvrez un shell avec le groupe :",
+		"permission.intro":              "%s n'a pas le droit d'utiliser le socket Docker. Au choix :",
+		"permission.group":              "  1. ajoutez votre utilisateur au groupe docker :",
+		"permission.groupRelog":         "     puis déconnectez-vous et reconnectez-vous (ou lancez newgrp docker)",
+		"permission.groupWarning":       "les membres du groupe docker sont de fait root sur cette machine",
+		"permission.rootless":           "  2. ou utilisez Docker rootless, avec un socket appartenant à votre utilisateur :",
+		"doctor.socketStaleSessionHint": "Vous êtes dans le groupe docker mais cette session est plus ancienne, reconnectez-vous ou lancez newgrp docker",
+
+		"list.emulated":    "⚠ %s",
+		"column.platform":  "PLATEFORME",
+		"details.emulated": "⚠ Émulé : l'image est %s mais le moteur tourne sur %s, attendez-vous à un comportement plus lent et différent",
+
+		"column.gpu":         "GPU",
+		"details.gpus":       "GPU : %s",
+		"details.gpuRuntime": "Runtime : %s (aucun GPU demandé)",
+		"doctor.gpu":         "Runtime GPU",
+		"doctor.gpuNone":     "aucun runtime nvidia enregistré",
+		"doctor.gpuHint":     "Installez le NVIDIA Container Toolkit pour utiliser docker run --gpus",
+		"doctor.gpuRuntimes": "disponible (%s)",
+
+		"help.resources":           "Additionner les limites mémoire et CPU des conteneurs lancés face à l'hôte",
+		"error.getResources":       "Erreur de lecture des limites de ressources :",
+		"resources.memLimit":       "LIMITE MÉM",
+		"resources.memReservation": "RÉSERVATION MÉM",
+		"resources.cpus":           "CPUS",
+		"resources.total":          "TOTAL",
+		"resources.commitment":     "%s : %s sur %s (%.0f%%)",
+		"resources.overcommitted":  "surengagé",
+		"resources.unbounded":      "%d conteneurs lancés n'ont pas de limite mémoire ou CPU et peuvent utiliser tout l'hôte",
+
+		"stats.throttled":  "BRIDÉ",
+		"stats.workingSet": "La mémoire est le working set cgroup v2 (utilisation moins le cache récupérable), ce sur quoi se base l'OOM killer.",
+
+		"logs.eventOOM":     "tué par manque de mémoire (OOM)",
+		"logs.eventDie":     "arrêté avec le code %s",
+		"logs.eventKill":    "tué par le signal %s",
+		"logs.eventRestart": "redémarré",
+		"logs.eventStart":   "démarré",
+		"logs.eventHealth":  "santé : %s",
+
+		"logs.compressLocal":        "--compress ne s'applique qu'aux hôtes ssh://, téléchargement sans compression",
+		"logs.downloaded":           "%s enregistré (%s)",
+		"logs.downloadedCompressed": "%s enregistré (%s, %s transférés)",
+		"error.saveLogs":            "Erreur lors de l'enregistrement des logs :",
+
+		"stream.exported":     "exporté dans %s",
+		"stream.exportFailed": "échec de l'export : %v",
+
+		"stream.column": "colonne %d",
+
+		"create.detach":       "Détacher",
+		"create.detachHint":   "non pour attacher ce terminal au conteneur (docker run -it)",
+		"create.attachedExit": "Le conteneur s'est arrêté avec le code %d",
+
+		"action.runTask":   "Lancer une tâche ponctuelle (docker run --rm)",
+		"task.title":       "Lancer une tâche avec %s",
+		"task.command":     "Commande",
+		"task.commandHint": "arguments passés à l'image, séparés par des espaces",
+		"task.mount":       "Monter le dossier courant",
+		"task.mountHint":   "oui pour le monter sur %s et s'y exécuter",
+		"task.running":     "Tâche : %s",
+
+		"action.devShell":         "Ouvrir un shell avec l'utilisateur du dev container",
+		"action.devPorts":         "Afficher les ports redirigés",
+		"action.restart":          "Redémarrer",
+		"details.devcontainer":    "Dev container de %s",
+		"devcontainer.portsTitle": "Ports de %s (%s) :",
+		"devcontainer.noPorts":    "Aucun port redirigé ou publié.",
+		"devcontainer.forwarded":  "  redirigé  %s",
+		"devcontainer.published":  "  publié    %s",
+
+		"help.all": "Afficher aussi les conteneurs masqués par list.ignore",
+
+		"help.cleanup":           "Passer en revue les suggestions de nettoyage et appliquer celles choisies",
+		"check.help":             "espace : cocher  a : tout  entrée : appliquer  échap : annuler",
+		"plain.promptMany":       "Entrez des nombres entre 1 et %d séparés par des virgules, all, ou q pour quitter : ",
+		"error.cleanup":          "Erreur lors de la préparation du nettoyage :",
+		"cleanup.title":          "Suggestions de nettoyage :",
+		"cleanup.nothing":        "Rien à nettoyer.",
+		"cleanup.kind":           "TYPE",
+		"cleanup.reason":         "RAISON",
+		"cleanup.kindProject":    "projet",
+		"cleanup.kindContainer":  "conteneur",
+		"cleanup.kindImage":      "image",
+		"cleanup.kindVolume":     "volume",
+		"cleanup.projectStopped": "%d conteneurs, aucun lancé",
+		"cleanup.exitedDaysAgo":  "arrêté il y a %d jours",
+		"cleanup.dangling":       "orpheline, %s, créée %s",
+		"cleanup.unusedVolume":   "utilisé par aucun conteneur",
+
+		"sort.size":    "taille",
+		"details.size": "Couche inscriptible : %s (%s avec l'image)",
+
+		"prune.keptProtected": "Image protégée %s conservée",
+
+		"help.tags":      "Supprimer les anciens tags des dépôts qui en ont beaucoup, en gardant les N plus récents",
+		"tags.title":     "Tags à supprimer (en gardant les %d plus récents de chaque dépôt) :",
+		"tags.state":     "ÉTAT",
+		"tags.inUse":     "utilisé par un conteneur",
+		"tags.protected": "protégé",
+		"tags.kept":      "récent",
+		"tags.old":       "ancien",
+		"tags.nothing":   "Aucun dépôt n'a plusieurs tags.",
+		"tags.refused":   "utilisé par un conteneur ou protégé, conservé",
+
+		"help.registries":      "Afficher les registres où vous êtes connecté et vous déconnecter de l'un d'eux",
+		"error.registries":     "Erreur de lecture des identifiants de registre :",
+		"registries.none":      "Connecté à aucun registre.",
+		"registries.registry":  "REGISTRE",
+		"registries.user":      "UTILISATEUR",
+		"registries.source":    "STOCKÉ DANS",
+		"registries.title":     "Choisissez un registre dont vous déconnecter :",
+		"registries.loggedOut": "Déconnecté de %s",
+
+		"doctor.proxy":               "Proxy du démon",
+		"doctor.proxyNone":           "aucun",
+		"doctor.proxyExcept":         "sauf %s",
+		"doctor.proxyLocalhostHint":  "NO_PROXY n'inclut pas localhost, les pulls depuis un registre local passeront par le proxy et échoueront",
+		"doctor.proxyClientOnlyHint": "%s est défini dans votre shell mais le démon ne l'utilise pas ; configurez le proxy dans daemon.json ou le service docker",
+		"doctor.insecure":            "Registres non sécurisés",
+		"doctor.insecureNone":        "aucun en dehors de localhost",
+		"doctor.insecureHint":        "Ces registres sont utilisés sans vérification TLS, les images qui en viennent peuvent être altérées",
+
+		"action.healthcheck": "Afficher et lancer le healthcheck",
+		"health.none":        "%s n'a pas de healthcheck.",
+		"health.title":       "Healthcheck de %s :",
+		"health.test":        "Test",
+		"health.interval":    "Intervalle",
+		"health.timeout":     "Délai",
+		"health.startPeriod": "Démarrage",
+		"health.retries":     "Essais",
+		"health.status":      "État : %s, %d échecs d'affilée. Derniers résultats :",
+		"health.runPrompt":   "Lancer le test maintenant ? [o/N] ",
+		"health.failed":      "✗ échec : %v (%s)",
+		"health.passed":      "✓ réussi (%s)",
+
+		"action.wait":  "Attendre qu'il soit sain",
+		"help.wait":    "Attendre que des conteneurs soient sains (--timeout 90s), pour les scripts",
+		"wait.usage":   "Utilisation : whale wait <nom>... [--timeout D]",
+		"wait.waiting": "Attente de %s",
+		"wait.exited":  "arrêté avec le code %d",
+		"wait.timeout": "pas sain après %s",
+		"wait.ready":   "prêt",
+
+		"check.helpReorder":        "espace : cocher  maj+haut/bas : déplacer  a : tout  entrée : appliquer  échap : annuler",
+		"lifecycle.stopping":       "les conteneurs suivants ne sont pas démarrés",
+		"lifecycle.nothingStopped": "Aucun conteneur arrêté.",
+		"lifecycle.orderTitle":     "Conteneurs à démarrer, dans l'ordre :",
+
+		"action.editLabels":   "Modifier les labels (recrée le conteneur)",
+		"labels.title":        "Labels de %s",
+		"labels.emptyRemoves": "vide pour supprimer",
+		"labels.add":          "Ajouter",
+		"labels.addHint":      "clé=valeur, séparés par des virgules",
+		"labels.unchanged":    "Labels inchangés.",
+		"labels.done":         "labels mis à jour",
+		"recreate.warning":    "⚠ Les labels ne peuvent pas être modifiés en place : %s va être arrêté, recréé avec les mêmes réglages puis redémarré. Les fichiers écrits dans le conteneur hors des volumes sont perdus.",
+		"recreate.prompt":     "Le recréer ? [o/N] ",
+		"recreate.rolledBack": "le conteneur d'origine a été restauré",
+
+		"action.editNote": "Modifier la note",
+		"notes.title":     "Note de %s",
+		"notes.note":      "Note",
+		"notes.hint":      "vide pour supprimer",
+		"notes.saved":     "Note de %s enregistrée",
+		"notes.invalid":   "%s ignoré, ce n'est pas du JSON valide",
+		"notes.usage":     "Utilisation : whale note <nom> [texte...]",
+		"error.saveNote":  "Erreur lors de l'enregistrement de la note :",
+		"help.note":       "Afficher ou définir la note d'un conteneur",
+		"details.note":    "✎ %s",
+
+		"hint.alreadyRunning": "déjà lancé",
+		"hint.notRunning":     "pas lancé",
+		"hint.paused":         "en pause, reprenez-le d'abord",
+		"hint.alreadyPaused":  "déjà en pause",
+		"hint.notPaused":      "pas en pause",
+
+		"action.start":   "Démarrer",
+		"action.stop":    "Arrêter",
+		"action.logs":    "Suivre les logs",
+		"action.rm":      "Supprimer",
+		"hint.stopFirst": "lancé, arrêtez-le d'abord",
+		"remove.prompt":  "Supprimer %s ? [o/N] ",
+		"list.shortcuts": "s démarrer/arrêter · r redémarrer · l logs · d supprimer",
+
+		"list.repeat":            ". répéter %s",
+		"list.repeatUnavailable": "%s n'est pas disponible pour %s",
+		"help.keyRepeat":         "Répéter la dernière action sur le conteneur sous le curseur",
+
+		"action.composeSuspend":  "Suspendre (arrêter les conteneurs lancés et s'en souvenir)",
+		"action.composeResume":   "Reprendre (relancer les conteneurs suspendus)",
+		"suspend.nothingRunning": "Rien n'est lancé dans %s.",
+		"suspend.done":           "suspendus : %s",
+		"suspend.resumed":        "repris : %s",
+		"suspend.missing":        "%d conteneurs suspendus n'existent plus, ils sont ignorés.",
+
+		"help.keyShortcuts": "Démarrer/arrêter, redémarrer, suivre les logs ou supprimer le conteneur sous le curseur",
+
+		"help.timings":     "Afficher la durée du démarrage, étape par étape",
+		"timings.step":     "ÉTAPE",
+		"timings.duration": "DURÉE",
+		"timings.total":    "total",
+		"timings.saved":    "Ajouté à %s",
+		"timings.notSaved": "impossible d'enregistrer les durées : %v",
+
+		"api.pinned": "Le démon ne prend en charge que l'API %s, plus ancienne que celle de la CLI : certaines fonctionnalités peuvent manquer",
+
+		"offline.banner": "Démon injoignable : conteneurs tels qu'à %s, en lecture seule, reconnexion…",
+
+		"help.list": "Afficher les noms ou IDs des conteneurs correspondants, un par ligne, pour les scripts",
+
+		"help.pick": "Choisir un conteneur et afficher son ID ou un autre champ, pour les pipelines",
+
+		"pick.noTerminal": "--stdin a besoin d'un terminal pour lire les touches :",
+
+		"action.shell": "Ouvrir un shell",
+
+		"actions.group.lifecycle": "Cycle de vie",
+		"actions.group.inspect":   "Inspecter",
+		"actions.group.files":     "Fichiers",
+		"actions.group.danger":    "Zone dangereuse",
+
+		"logs.title":     "docker logs -f : %s",
+		"action.pause":   "Mettre en pause",
+		"action.unpause": "Reprendre",
+
+		"actions.filter":  "Filtre : %s",
+		"actions.noMatch": "Aucune action ne correspond, Échap pour effacer le filtre.",
+
+		"action.compareImage":   "Comparer avec un autre tag",
+		"imageDiff.chooseTitle": "Comparer %s avec :",
+		"imageDiff.noOtherTag":  "Aucun autre tag de %s à comparer.",
+		"imageDiff.title":       "%s → %s",
+		"imageDiff.identical":   "Les deux tags désignent la même image.",
+		"imageDiff.size":        "Taille : %s → %s (%s)",
+		"imageDiff.layers":      "Couches : %d communes, %d ajoutées, %d supprimées",
+		"imageDiff.config":      "Changements de configuration :",
+		"imageDiff.sameConfig":  "Aucun changement de configuration.",
+
+		"tabs.containers":          "Conteneurs",
+		"tabs.images":              "Images",
+		"tabs.volumes":             "Volumes",
+		"tabs.networks":            "Réseaux",
+		"tabs.help":                "1-4/tab : changer d'onglet  ctrl+f : chercher",
+		"tabs.done.pullImage":      "téléchargée",
+		"tabs.done.tagImage":       "taguée",
+		"tabs.done.removeImage":    "supprimée",
+		"tabs.done.inspectVolume":  "inspecté",
+		"tabs.done.removeVolume":   "supprimé",
+		"tabs.done.pruneVolumes":   "volumes inutilisés supprimés",
+		"tabs.done.inspectNetwork": "inspecté",
+		"tabs.done.removeNetwork":  "supprimé",
+		"tabs.done.pruneNetworks":  "réseaux inutilisés supprimés",
+		"column.driver":            "PILOTE",
+		"column.scope":             "PORTÉE",
+		"action.pullImage":         "Télécharger à nouveau",
+		"action.tagImage":          "Taguer",
+		"action.removeImage":       "Supprimer",
+		"action.inspectVolume":     "Inspecter",
+		"action.removeVolume":      "Supprimer",
+		"action.pruneVolumes":      "Supprimer les volumes inutilisés",
+		"action.inspectNetwork":    "Inspecter",
+		"action.removeNetwork":     "Supprimer",
+		"action.pruneNetworks":     "Supprimer les réseaux inutilisés",
+		"images.tagTitle":          "Taguer %s",
+		"images.newTag":            "Nouveau tag",
+		"images.newTagHint":        "dépôt:tag, par ex. registry.example.com/app:1.2",
+		"images.protectedRemove":   "protégée par images.protected",
+		"volumes.title":            "Choisissez un volume :",
+		"volumes.actionsTitle":     "Volume : %s",
+		"volumes.inspectTitle":     "Volume %s",
+		"networks.title":           "Choisissez un réseau :",
+		"networks.actionsTitle":    "Réseau : %s",
+		"networks.inspectTitle":    "Réseau %s",
+		"error.chooseVolume":       "Erreur lors du choix du volume",
+		"error.chooseNetwork":      "Erreur lors du choix du réseau",
+		"help.runTab":              "Ouvrir le tableau de bord sur un onglet : containers, images, volumes ou networks",
+		"help.keyTabs":             "Passer d'un onglet à l'autre : conteneurs, images, volumes et réseaux",
+
+		"help.report":          "Écrire un rapport Markdown ou JSON des conteneurs, images, volumes et réseaux (--format, --output FICHIER, --with-env)",
+		"error.report":         "Erreur lors de l'écriture du rapport",
+		"report.written":       "Rapport écrit dans %s",
+		"report.title":         "Rapport de l'hôte Docker : %s",
+		"report.generated":     "Généré le",
+		"report.daemon":        "Démon",
+		"report.image":         "Image",
+		"report.command":       "Commande",
+		"report.restartPolicy": "Politique de redémarrage",
+		"report.networks":      "Réseaux",
+		"report.mount":         "Montage",
+		"report.env":           "Environnement",
+
+		"help.configExport":     "Regrouper votre configuration (thème, colonnes, actions, hôtes) dans un seul fichier (--only ui,hosts)",
+		"help.configImport":     "Fusionner un paquet de configuration dans la vôtre (--replace, --only ui,hosts)",
+		"configBundle.usage":    "Utilisation : whale config export [FICHIER] [--only a,b] | whale config import FICHIER [--replace] [--only a,b]",
+		"error.configBundle":    "Erreur avec le paquet de configuration :",
+		"configBundle.exported": "Configuration exportée dans %s : %s",
+		"configBundle.imported": "%s importé dans %s",
+
+		"actions.group.packs": "Actions d'équipe",
+		"help.packs":          "Lister les actions des packs de packs.sources, en les récupérant à nouveau avec --update",
+		"packs.none":          "Aucun pack d'actions configuré, ajoutez des sources à packs.sources.",
+		"packs.summary":       "%s, %d actions",
+		"packs.unavailable":   "pack d'actions %s indisponible : %v",
+		"packs.stale":         "impossible de rafraîchir le pack d'actions %s, copie en cache utilisée : %v",
+
+		"help.serve":         "Exposer des métriques Prometheus sur les conteneurs (--interval N)",
+		"serve.listening":    "Métriques exposées sur %s/metrics",
+		"error.serve":        "Erreur lors de l'exposition des métriques :",
+		"serve.sampleFailed": "échec de l'échantillonnage des conteneurs : %v",
+
+		"help.watch":          "Signaler les conteneurs qui plantent, deviennent non sains ou manquent de mémoire, et les envoyer aux webhooks",
+		"error.watch":         "Erreur lors du suivi des événements :",
+		"watch.all":           "Surveillance de tous les conteneurs, %d webhooks",
+		"watch.some":          "Surveillance de %d conteneurs, %d webhooks",
+		"watch.died":          "%s s'est arrêté avec le code %d",
+		"watch.oom":           "%s a été tué faute de mémoire",
+		"watch.unhealthy":     "%s n'est pas sain",
+		"watch.webhookFailed": "impossible de notifier %s : %v",
+
+		"action.backupVolume":     "Sauvegarder dans une archive tar",
+		"action.restoreVolume":    "Restaurer depuis une archive tar",
+		"tabs.done.restoreVolume": "restauré",
+		"backup.title":            "Sauvegarder le volume %s",
+		"backup.file":             "Fichier",
+		"backup.fileHint":         "chemin local du .tar.gz à écrire",
+		"backup.done":             "✓ %s : sauvegardé dans %s",
+		"restore.title":           "Restaurer le volume %s",
+		"restore.fileHint":        "fichier .tar.gz local écrit par Sauvegarder",
+		"restore.wipe":            "Vider le volume d'abord",
+		"restore.wipeHint":        "oui pour supprimer ce que la sauvegarde ne contient pas",
+		"restore.wipeSummary":     "Ceci va supprimer tous les fichiers du volume %s avant la restauration.",
+
+		"action.browseFiles":  "Parcourir les fichiers",
+		"action.browseVolume": "Parcourir les fichiers",
+		"files.title":         "Fichiers de %s",
+		"files.volumeTitle":   "Fichiers du volume %s",
+		"files.loading":       "Chargement...",
+		"files.empty":         "Répertoire vide",
+		"files.help":          "entrée : ouvrir · ← : parent · c : copier ici · q : retour",
+		"files.copied":        "copié dans %s",
+
+		"column.usedBy":   "UTILISÉ PAR",
+		"volumes.stopped": "%s (arrêté)",
+		"volumes.unused":  "-",
+
+		"action.removeOrphanVolumes":    "Supprimer les volumes anonymes orphelins",
+		"tabs.done.removeOrphanVolumes": "volumes orphelins supprimés",
+		"volumes.orphaned":              "- (orphelin)",
+		"volumes.noOrphans":             "aucun volume anonyme orphelin",
+		"volumes.age":                   "il y a %d jours",
+		"volumes.orphansTitle":          "%d volumes anonymes qu'aucun conteneur ne monte, décochez ceux à garder :",
+		"volumes.orphansFailed":         "%d volumes sur %d n'ont pas pu être supprimés : %s",
+
+		"details.missingBind":      "Source de bind mount introuvable : %s",
+		"create.missingBind":       "⚠ %s n'existe pas : docker montera un répertoire vide créé à sa place.",
+		"create.missingBindPrompt": "Créer le conteneur quand même ? [o/N] ",
+
+		"action.createNetwork":               "Créer un réseau",
+		"tabs.done.createNetwork":            "réseau créé",
+		"networkCreate.title":                "Créer un réseau",
+		"networkCreate.name":                 "Nom",
+		"networkCreate.driver":               "Driver",
+		"networkCreate.driverHint":           "bridge, overlay, macvlan, ipvlan...",
+		"networkCreate.subnet":               "Sous-réseau",
+		"networkCreate.subnetHint":           "CIDR, par ex. 172.28.0.0/16 ; vide pour laisser docker en choisir un",
+		"networkCreate.gateway":              "Passerelle",
+		"networkCreate.gatewayHint":          "adresse dans le sous-réseau ; vide pour la première",
+		"networkCreate.internal":             "Interne",
+		"networkCreate.internalHint":         "oui pour couper le réseau de l'extérieur",
+		"networkCreate.attachable":           "Attachable",
+		"networkCreate.attachableHint":       "oui pour que des conteneurs autonomes rejoignent un réseau overlay",
+		"networkCreate.invalidName":          "%q n'est pas un nom de réseau valide",
+		"networkCreate.invalidSubnet":        "%s n'est pas un sous-réseau CIDR, par ex. 172.28.0.0/16",
+		"networkCreate.subnetHostBits":       "%s a des bits d'hôte, vouliez-vous dire %s ?",
+		"networkCreate.invalidGateway":       "%s n'est pas une adresse IP",
+		"networkCreate.gatewayWithoutSubnet": "une passerelle demande un sous-réseau",
+		"networkCreate.gatewayOutside":       "la passerelle %s est hors de %s",
+		"networkCreate.attachableOverlay":    "seuls les réseaux overlay peuvent être attachables",
+
+		"column.subnet":      "SOUS-RÉSEAU",
+		"ipam.network":       "le réseau %s (%s)",
+		"ipam.route":         "la route %s sur %s",
+		"ipam.overlap":       "⚠ %s : %s chevauche %s",
+		"ipam.createOverlap": "⚠ %s chevauche %s : les conteneurs n'atteindront pas ces adresses.",
+		"ipam.createPrompt":  "Créer le réseau quand même ? [o/N] ",
+
+		"action.debugDns":       "Déboguer le DNS",
+		"actions.group.network": "Réseau",
+		"dns.title":             "DNS depuis %s",
+		"dns.names":             "Noms",
+		"dns.namesHint":         "séparés par des virgules, résolus depuis le conteneur",
+		"dns.defaultBridge":     "(bridge par défaut seulement : pas de DNS intégré, les noms de conteneurs ne se résolvent pas)",
+
+		"action.testConnectivity": "Tester la connectivité",
+		"connect.title":           "Joindre depuis %s",
+		"connect.target":          "Cible",
+		"connect.targetHint":      "nom de conteneur, de service ou d'hôte, ou adresse IP",
+		"connect.port":            "Port",
+		"connect.portHint":        "port TCP ; vide pour un ping",
+		"connect.reached":         "joignable",
+		"connect.reachedIn":       "joignable en %s ms",
+		"connect.dns":             "nom non résolu (%s)",
+		"connect.refused":         "connexion refusée : rien n'écoute sur ce port",
+		"connect.timeout":         "délai dépassé : un pare-feu ou un autre réseau entre les deux",
+		"connect.unreachable":     "injoignable",
+
+		"action.probeHttp": "Tester les ports HTTP",
+		"probe.noPorts":    "%s ne publie aucun port TCP.",
+		"probe.latency":    "en %s",
+		"probe.refused":    "connexion refusée : rien n'écoute sur le port de l'hôte",
+		"probe.timeout":    "pas de réponse en 5 s",
+		"probe.notHTTP":    "répond, mais pas en HTTP(S)",
+
+		"action.showTraffic": "Trafic par interface",
+		"traffic.title":      "Trafic réseau de %s",
+		"traffic.none":       "Aucune interface réseau hormis la boucle locale.",
+		"traffic.interface":  "INTERFACE",
+		"traffic.received":   "REÇU",
+		"traffic.sent":       "ENVOYÉ",
+		"traffic.rxRate":     "RX/S",
+		"traffic.txRate":     "TX/S",
+		"traffic.help":       "q : retour",
+		"stats.netRate":      "DÉBIT RÉSEAU",
+
+		"action.checkClock": "Vérifier l'horloge",
+		"clock.inSync":      "horloge synchronisée avec cette machine (%s, ±%s)",
+		"clock.ahead":       "horloge en avance de %s sur cette machine, au-delà de %s",
+		"clock.behind":      "horloge en retard de %s sur cette machine, au-delà de %s",
+		"clock.hint":        "Les poignées de main TLS et la vérification des jetons peuvent échouer. Les conteneurs partagent l'horloge de l'hôte du démon : resynchronisez-la, par ex. en redémarrant Docker Desktop ou la VM après une mise en veille.",
+
+		"create.pull":          "Téléchargement",
+		"create.pullHint":      "always, missing ou never",
+		"pull.invalid":         "politique de téléchargement %q inconnue, always, missing ou never attendu",
+		"pull.always":          "Politique always : %s est d'abord téléchargée depuis son registre.",
+		"pull.never":           "Politique never : seule la copie locale de %s est utilisée, le registre n'est pas contacté.",
+		"pull.present":         "Politique missing : %s est présente localement, le registre n'est pas contacté.",
+		"pull.absent":          "Politique missing : %s n'est pas présente localement et sera téléchargée depuis son registre.",
+		"pull.compose.always":  "Politique always : l'image de chaque service est d'abord téléchargée depuis son registre.",
+		"pull.compose.missing": "Politique missing : seules les images de services absentes localement sont téléchargées.",
+		"pull.compose.never":   "Politique never : seules les images locales sont utilisées, le registre n'est pas contacté.",
+
+		"help.gc":    "Lister les conteneurs arrêtés que la politique gc supprime, --apply pour les supprimer",
+		"gc.noRules": "Aucune politique gc : indiquez dans gc.match les images ou labels des conteneurs pouvant être supprimés.",
+		"gc.nothing": "Aucun conteneur correspondant arrêté depuis plus de %d jours.",
+		"gc.dryRun":  "Simulation, rien n'a été supprimé : lancez whale gc --apply pour les supprimer, ou activez gc.enabled pour que whale watch supprime ces conteneurs à mesure qu'ils vieillissent.",
+		"gc.removed": "supprimé",
+		"gc.failed":  "gc : %v",
+		"watch.gc":   "Suppression des conteneurs correspondants arrêtés depuis plus de %d jours toutes les %d minutes",
+
+		"stream.helpSplit":   "haut/bas/pgup/pgdown : défiler  G : suivre  s : stdout/stderr  w : retour à la ligne  gauche/droite : défiler latéralement  e/E : exporter JSON/CSV  q : quitter",
+		"stream.only.stdout": "stdout seul",
+		"stream.only.stderr": "stderr seul",
+
+		"help.follow":          "Ouvrir les logs de chaque nouveau conteneur dont le nom ou l'image commence par un préfixe",
+		"error.follow":         "Erreur lors du suivi des conteneurs :",
+		"follow.waiting":       "En attente du démarrage de %s... (ctrl+c pour arrêter)",
+		"follow.any":           "un conteneur",
+		"follow.byName":        "un conteneur nommé %s*",
+		"follow.byImage":       "un conteneur de l'image %s*",
+		"follow.byNameOrImage": "un conteneur nommé %s* ou de cette image",
+		"follow.eventsEnded":   "docker events s'est arrêté",
+		"follow.title":         "Logs de %s, bascule sur le prochain conteneur correspondant à son démarrage",
+
+		"composeUp.title":          "Démarrage de %s",
+		"composeUp.state.pending":  "en attente",
+		"composeUp.state.pulling":  "téléchargement",
+		"composeUp.state.pulled":   "téléchargé",
+		"composeUp.state.building": "construction",
+		"composeUp.state.built":    "construit",
+		"composeUp.state.creating": "création",
+		"composeUp.state.created":  "créé",
+		"composeUp.state.starting": "démarrage",
+		"composeUp.state.started":  "démarré",
+		"composeUp.state.waiting":  "attend ses dépendances",
+		"composeUp.state.healthy":  "sain",
+		"composeUp.state.failed":   "échec",
+		"composeUp.logs":           "Derniers logs :",
+		"composeUp.running":        "compose up en cours",
+		"composeUp.done":           "tout est démarré",
+		"composeUp.failed":         "échec de compose up : %v",
+		"composeUp.help":           "haut/bas : naviguer  entrée : afficher la sortie  q : quitter",
+
+		"drift.image":             "image %s, le fichier indique %s",
+		"drift.env":               "environnement %s",
+		"drift.ports":             "ports publiés",
+		"drift.badge":             "⚠ %s doit être recréé : %s",
+		"drift.unavailable":       "⚠ le fichier compose n'a pas pu être comparé : %v",
+		"action.composeReconcile": "Réconcilier : recréer les services qui divergent du fichier compose",
+		"compose.reconcileTitle":  "Recréation de %s",
+
+		"action.editCommand":     "Modifier la commande de démarrage (recrée le conteneur)",
+		"command.title":          "Commande de démarrage de %s",
+		"command.entrypoint":     "Point d'entrée",
+		"command.entrypointHint": "vide pour aucun",
+		"command.command":        "Commande",
+		"command.commandHint":    "p. ex. sleep infinity pour garder un conteneur qui plante démarré et l'examiner",
+		"command.unchanged":      "La commande de démarrage est inchangée.",
+		"command.summary":        "Nouvelle commande de démarrage : %s",
+		"command.unterminated":   "guillemet ou échappement non terminé dans %q",
+		"command.done":           "recréé avec la nouvelle commande de démarrage",
+
+		"action.rescue":    "Secourir (recréer au repos, sans redémarrages, et ouvrir un shell)",
+		"action.endRescue": "Terminer le secours (recréer avec la commande d'origine)",
+		"rescue.plan":      "Secourir %s :\n  1. désactiver sa politique de redémarrage\n  2. le recréer avec les mêmes montages, environnement et réseaux, en exécutant une commande au repos à la place de la sienne\n  3. ouvrir un shell à l'intérieur\nTerminer le secours, dans son menu, le recrée avec sa commande et sa politique de redémarrage.",
+		"rescue.prompt":    "Le secourir ? [o/N] ",
+		"rescue.done":      "recréé au repos, ouverture d'un shell",
+		"rescue.endPlan":   "%s va être recréé en exécutant de nouveau %s, avec sa politique de redémarrage.",
+		"rescue.ended":     "recréé avec sa commande d'origine",
+
+		"action.showProvenance": "Afficher la provenance du build",
+		"provenance.title":      "Provenance de %s",
+		"provenance.none":       "Aucune provenance : l'image n'a ni label de source ou de révision, ni attestation de build.",
+		"provenance.labelsOnly": "D'après les labels de l'image, aucune attestation de build trouvée.",
+		"provenance.source":     "Source",
+		"provenance.revision":   "Révision",
+		"provenance.version":    "Version",
+		"provenance.created":    "Construite",
+		"provenance.url":        "Page d'accueil",
+		"provenance.dockerfile": "Dockerfile",
+		"provenance.builder":    "Builder",
+		"provenance.commit":     "Commit",
+		"provenance.copyPrompt": "Copier le lien du commit ? [o/N] ",
+		"provenance.copied":     "Lien du commit copié dans le presse-papiers.",
+
+		"help.digests":          "Signaler les conteneurs dont le tag pointe vers un autre digest depuis leur démarrage",
+		"digests.startedFrom":   "DÉMARRÉ DEPUIS",
+		"digests.pinned":        "épinglé par digest",
+		"digests.pulledLocally": "le tag pointe maintenant vers %s localement, non recréé",
+		"digests.noDigest":      "construit localement, sans digest de registre",
+		"digests.unreachable":   "registre injoignable",
+		"digests.moved":         "le tag a bougé vers %s dans le registre",
+		"digests.unchanged":     "inchangé",
+		"digests.none":          "Aucun conteneur démarré.",
+		"digests.saveFailed":    "impossible d'enregistrer les digests de démarrage : %v",
+		"digests.movedHint":     "Ces conteneurs n'exécutent plus ce que pointe leur tag : les recréer exécuterait une autre image.",
+
+		"list.exported":     "%d conteneurs exportés dans %s",
+		"list.exportFailed": "échec de l'export : %v",
+		"help.keyExport":    "Exporter la liste affichée en CSV, ou en tableau Markdown",
+
+		"keys.pending":     "%s … (échap pour annuler)",
+		"keys.unavailable": "%s n'est pas disponible pour %s",
+		"help.keyChords":   "Aller à un onglet, voir keys.chords pour d'autres séquences",
+
+		"macro.started":             "enregistrement d'une macro, m de nouveau pour l'enregistrer",
+		"macro.recording":           "● enregistrement d'une macro : %d étapes, m pour l'enregistrer",
+		"macro.empty":               "rien d'enregistré, aucune macro sauvegardée",
+		"macro.saveTitle":           "Enregistrer la macro %s",
+		"macro.name":                "Nom",
+		"macro.nameHint":            "pour la lancer avec M ou whale macro <nom>",
+		"macro.discarded":           "macro abandonnée",
+		"macro.saved":               "✓ macro %s enregistrée, %d étapes",
+		"macro.none":                "Aucune macro : appuyez sur m dans la liste pour en enregistrer une.",
+		"macro.chooseTitle":         "Lancer une macro sur %s :",
+		"macro.step":                "%s %d/%d : %s sur %s",
+		"macro.stepLabel.pullImage": "Télécharger à nouveau l'image",
+		"macro.stepLabel.recreate":  "Recréer à partir de ses réglages",
+		"macro.unknown":             "Aucune macro nommée %s.",
+		"macro.usage":               "Utilisation : whale macro <macro> <nom>...",
+		"macro.played":              "macro %s lancée",
+		"help.macro":                "Lancer une macro enregistrée sur chaque conteneur, ou lister les macros",
+		"help.keyMacro":             "Enregistrer une macro des actions lancées depuis la liste, en lancer une",
+
+		"action.playMacro": "Lancer une macro",
+
+		"header.age":      "données de %s",
+		"refresh.running": "actualisation…",
+		"refresh.failed":  "échec de l'actualisation : %v",
+		"help.keyRefresh": "Recharger la liste ou l'onglet, r aussi sur les onglets de ressources",
+
+		"runFile.usage":   "Utilisation : whale run-file <fichier.yaml|fichier.json> [--dry-run]",
+		"runFile.pulled":  "téléchargée",
+		"runFile.pruned":  "nettoyé",
+		"runFile.stopped": "Arrêté, %d étapes non exécutées (continueOnError pour continuer).",
+		"runFile.dryRun":  "Simulation, rien n'a été modifié.",
+		"runFile.summary": "%d étapes : %d réussies, %d en échec",
+		"help.runFile":    "Exécuter les étapes d'un fichier YAML ou JSON, --dry-run pour les lister",
+
+		"prune.kind.container":  "conteneur",
+		"prune.kind.image":      "image",
+		"prune.kind.network":    "réseau",
+		"prune.kind.volume":     "volume",
+		"prune.kind.buildCache": "cache de build",
+		"prune.buildCache":      "couches récupérables",
+		"prune.previewTitle":    "%d éléments peuvent partir, %s au total. Décochez ceux à garder :",
+		"prune.nothing":         "Rien à nettoyer.",
+		"prune.chosen":          "Ceci va supprimer les %d éléments cochés, %s au total.",
+		"prune.volumeData":      "Les données des volumes cochés seront perdues.",
+		"prune.freed":           "%d éléments supprimés, %s libérés.",
+		"prune.failed":          "%d éléments sur %d n'ont pas pu être supprimés",
+
+		"action.forwardPort":    "Rediriger un port",
+		"forward.title":         "Rediriger un port de %s vers localhost",
+		"forward.port":          "Port du conteneur",
+		"forward.portHint":      "le port sur lequel le service écoute dans le conteneur",
+		"forward.exposedHint":   "exposés : %s",
+		"forward.localPort":     "Port local",
+		"forward.localPortHint": "vide pour le même port",
+		"forward.invalidPort":   "port invalide %q",
+		"forward.hostNetwork":   "%s utilise le réseau de l'hôte, ses ports sont déjà accessibles",
+		"forward.noAddress":     "%s n'a pas d'adresse propre sur un réseau",
+		"forward.active":        "localhost:%d → %s:%d",
+		"forward.remote":        "Le port est ouvert sur la boucle locale de %[1]s, lancez ssh -L %[2]d:localhost:%[2]d %[1]s pour y accéder d'ici.",
+		"forward.stop":          "Appuyez sur entrée pour arrêter la redirection. ",
+
+		"action.addHostsEntry":    "Ajouter à /etc/hosts",
+		"action.removeHostsEntry": "Retirer de /etc/hosts",
+		"hosts.noAddress":         "%s n'a pas d'adresse propre sur un réseau",
+		"hosts.sudo":              "%s n'est pas modifiable, passage par sudo.",
+		"hosts.added":             "%s → %s dans le fichier hosts",
+		"hosts.removed":           "%s retiré du fichier hosts",
+
+		"create.entrypoint":     "Point d'entrée",
+		"create.entrypointHint": "vide pour celui de l'image, %s ; le remplacer supprime la commande de l'image",
+		"create.command":        "Commande",
+		"create.commandHint":    "vide pour celle de l'image, %s, les arguments après l'image la remplacent",
+		"create.user":           "Utilisateur",
+		"create.userHint":       "vide pour celui de l'image, ex. 1000:1000",
+		"create.workdir":        "Répertoire de travail",
+		"create.workdirHint":    "vide pour celui de l'image",
+		"create.confirm":        "Créer le conteneur ? [o/N] ",
+		"argv.title":            "Le conteneur exécutera :",
+		"argv.entrypoint":       "Point d'entrée",
+		"argv.cmd":              "Commande",
+		"argv.user":             "Utilisateur",
+		"argv.workdir":          "Répertoire",
+		"argv.fromImage":        "de l'image",
+		"argv.overridden":       "remplacé",
+		"argv.default":          "défaut de docker",
+		"argv.cmdDropped":       "la commande %s de l'image est supprimée car le point d'entrée est remplacé",
+		"argv.runs":             "→ %s",
+		"argv.empty":            "Ni point d'entrée ni commande : le conteneur ne pourra pas démarrer.",
+		"argv.shellForm":        "Le point d'entrée est sous forme shell, il passe par sh -c et ignore la commande.",
+
+		"action.checkEnvironment": "Vérifier l'environnement",
+		"sanity.title":            "Environnement de %s :",
+		"sanity.configured":       "CONFIGURÉ",
+		"sanity.probed":           "DANS LE CONTENEUR",
+		"sanity.timezone":         "Fuseau horaire",
+		"sanity.locale":           "Locale",
+		"sanity.user":             "Utilisateur",
+		"sanity.workdir":          "Répertoire",
+		"sanity.openFiles":        "Fichiers ouverts",
+		"sanity.processes":        "Processus",
+		"sanity.notRunning":       "%s n'est pas démarré, seule sa configuration est affichée.",
+		"sanity.probeFailed":      "Impossible de sonder le conteneur : %v",
+		"sanity.utc":              "Ni TZ ni /etc/localtime : le conteneur est à l'heure UTC.",
+		"sanity.noLocale":         "Ni LANG ni LC_ALL : la locale POSIX peut abîmer le texte non ASCII.",
+		"sanity.root":             "Les processus tournent en root.",
+
+		"errhint.nameConflict":  "Un conteneur nommé %s existe déjà : supprimez-le depuis la liste avec d, ou choisissez un autre nom.",
+		"errhint.portContainer": "Le port %s de l'hôte est publié par %s : arrêtez-le depuis la liste avec s, ou publiez un autre port.",
+		"errhint.portHost":      "Le port %s de l'hôte est pris par un processus hors de docker : libérez-le, ou publiez un autre port.",
+		"errhint.noSpace":       "Le disque du démon est plein : whale prune liste ce qui peut partir, avec les tailles.",
+		"errhint.manifest":      "Le tag %s n'existe pas sur le registre : vérifiez son orthographe, ou tirez-en un autre avec Pull dans l'onglet images.",
+		"errhint.accessDenied":  "L'image n'existe pas ou demande une connexion : whale registries montre sous quel compte vous êtes connecté.",
+		"errhint.daemon":        "Le démon est injoignable : whale doctor vérifie le socket, le contexte et les permissions.",
+
+		"errhint.manifestUnknown": "Le tag n'existe pas sur le registre : vérifiez son orthographe, ou tirez-en un autre avec Pull dans l'onglet images.",
+
+		"timeout.expired": "%s tué après %s, le délai configuré pour %q",
+
+		"column.host": "HÔTE",
+		"help.ps":     "Lister les conteneurs, de tous les hôtes configurés avec --all-hosts",
+
+		"session.writeFailed": "impossible d'écrire la session dans %s : %v",
+		"session.usage":       "Usage : whale replay FICHIER [--dry-run]",
+		"session.header":      "Session enregistrée sur %s le %s, rejouée sur %s",
+		"session.skipFailed":  "- %s %s : ignoré, avait échoué (%s)",
+		"session.skipAction":  "- %s %s : ignoré, ne peut pas être rejoué sans intervention",
+		"session.nothing":     "Rien à rejouer.",
+		"help.replay":         "Rejouer les actions d'une session enregistrée, --dry-run pour les revoir",
+		"help.record":         "Enregistrer les actions lancées et leurs résultats dans un fichier",
+
+		"create.nameHint":    "ctrl+g : un autre nom, vide pour laisser docker en choisir un",
+		"create.nameInvalid": "%s n'est pas un nom de conteneur valide : lettres, chiffres, _ . et - uniquement, en commençant par une lettre ou un chiffre",
+		"create.nameTaken":   "Un conteneur nommé %s existe déjà",
+
+		"search.title": "Chercher des conteneurs, images, volumes et réseaux par nom, ID ou label :",
+		"search.none":  "Aucun résultat",
+		"search.kind":  "TYPE",
+		"search.match": "CORRESPONDANCE",
+		"search.more":  "… %d de plus, affinez la recherche",
+		"search.help":  "tapez pour chercher  haut/bas : déplacer  entrée : ouvrir dans son onglet  échap : retour",
+		"error.search": "Erreur de recherche :",
+
+		"help.jump": "Ouvrir le menu d'actions du conteneur correspondant à <name>, ou la liste de ceux qui y correspondent",
+
+		"list.recent": "Récents",
+		"list.others": "Autres",
+
+		"help.uptime":      "Redémarrages, indisponibilité et disponibilité de chaque conteneur sur les dernières 24 h et 7 j",
+		"error.getEvents":  "Erreur de lecture des événements du démon :",
+		"uptime.uptime":    "DISPO %s",
+		"uptime.restarts":  "REDÉMARRAGES %s",
+		"uptime.downtime":  "ARRÊT %s",
+		"uptime.truncated": "Le démon ne garde que ses %d derniers événements : sur cet hôte ils ne couvrent pas toute la période, l'indisponibilité plus ancienne n'est pas comptée.",
+
+		"answer.yesWords": "o,oui",
+
+		"offline.cacheFailed": "impossible d'enregistrer la liste pour le mode hors ligne : %v",
+
+		"runFile.removed":     "supprimé",
+		"session.parseFailed": "erreur de lecture de %s : %v",
+		"session.stepInvalid": "%s %s : %v",
+
+		"tabs.done.browseVolume": "parcouru",
+
+		"tabs.done.backupVolume": "sauvegardé",
+	},
+	"es": {
+		"error.getContainers":   "Error al obtener los contenedores",
+		"error.chooseContainer": "Error al elegir el contenedor",
+		"error.chooseAction":    "Error al elegir la acción",
+		"error.doAction":        "Error al ejecutar la acción",
+		"action.selected":       "Acción elegida: %s",
+
+		"list.title":      "Elige un contenedor:",
+		"list.empty":      "No hay contenedores.",
+		"list.sortFooter": "Orden: %s (o para cambiar)",
+		"sort.none":       "ninguno",
+		"sort.cpu":        "CPU",
+		"sort.memory":     "memoria",
+
+		"column.id":       "ID",
+		"column.name":     "NOMBRE",
+		"column.image":    "IMAGEN",
+		"column.status":   "ESTADO",
+		"column.ports":    "PUERTOS",
+		"column.project":  "PROYECTO",
+		"column.cpu":      "CPU",
+		"column.mem":      "MEM",
+		"column.created":  "CREADO",
+		"column.restarts": "REINICIOS",
+		"list.crashLoop":  "bucle de fallos",
+		"column.exit":     "SALIDA",
+		"actions.exited":  "Detenido: %s",
+
+		"picker.title": "Elige las columnas:",
+		"picker.help":  "espacio: activar  mayús+arriba/abajo: mover  intro: aplicar  esc: cancelar",
+
+		"actions.title":   "Contenedor: %s",
+		"action.exit":     "Salir",
+		"action.copyId":   "Copiar el ID del contenedor",
+		"copy.idCopied":   "ID del contenedor copiado al portapapeles",
+		"plain.prompt":    "Introduce un número entre 1 y %d, o q para salir: ",
+		"plain.invalid":   "%q no es una opción válida.",
+		"help.usage":      "Uso: whale [opciones]",
+		"help.run":        "Ejecutar el programa",
+		"help.help":       "Mostrar este mensaje de ayuda",
+		"help.plain":      "Usar menús numerados en lugar de pantallas completas",
+		"help.keys":       "Teclas en la lista de contenedores:",
+		"help.keyStats":   "Mostrar u ocultar las columnas de CPU/memoria",
+		"help.keySort":    "Ordenar por CPU, memoria o tamaño",
+		"help.keyColumns": "Elegir y ordenar las columnas",
+		"help.keyFull":    "Mostrar los IDs y nombres de imagen completos",
+		"help.images":     "Explorar las imágenes",
+		"help.keyDetails": "Mostrar u ocultar el panel de detalles",
+		"help.logs":       "Seguir los logs de un contenedor (--tail N, --no-follow, --timestamps, --no-events, --save FILE, --compress, --export json|csv)",
+
+		"logs.usage":       "Uso: whale logs <nombre> [--tail N] [--no-follow] [--timestamps] [--no-events] [--save FILE [--compress]] [--export json|csv]",
+		"cli.missingValue": "Falta el valor de %s",
+		"cli.invalidValue": "Valor no válido para %s: %s",
+		"match.none":       "Ningún contenedor coincide con %q",
+		"match.ambiguous":  "%q coincide con varios contenedores: %s",
+
+		"help.stats":     "Mostrar el uso de recursos (--stream, --json, --interval N)",
+		"error.getStats": "Error al obtener las estadísticas",
+		"stats.memUsage": "USO MEM / LÍMITE",
+		"stats.memPerc":  "MEM %",
+		"stats.netIO":    "E/S RED",
+		"stats.blockIO":  "E/S DISCO",
+		"stats.pids":     "PIDS",
+
+		"help.inspect":    "Mostrar el JSON de inspección, o un valor con --field .Ruta",
+		"inspect.usage":   "Uso: whale inspect <nombre> [--field .NetworkSettings.IPAddress]",
+		"inspect.noField": "No existe el campo %s",
+
+		"details.ports":     "Puertos:",
+		"details.loading":   "Cargando los logs…",
+		"details.noLogs":    "Sin logs.",
+		"details.logsError": "No se pueden leer los logs: %v",
+
+		"form.help":      "tab/arriba/abajo: mover  intro: siguiente/enviar  ctrl+s: enviar  ctrl+u: borrar  esc: cancelar",
+		"form.plainHelp": "Pulsa intro para mantener el valor entre corchetes, o escribe - para borrarlo.",
+
+		"images.title":        "Elige una imagen:",
+		"images.actionsTitle": "Imagen: %s",
+		"error.getImages":     "Error al obtener las imágenes",
+		"error.chooseImage":   "Error al elegir la imagen",
+		"column.repository":   "REPOSITORIO",
+		"column.tag":          "ETIQUETA",
+		"column.size":         "TAMAÑO",
+
+		"action.createContainer": "Crear contenedor",
+		"create.title":           "Crear un contenedor desde %s",
+		"create.name":            "Nombre",
+		"create.ports":           "Puertos",
+		"create.portsHint":       "host:contenedor[/protocolo], separados por comas",
+		"create.env":             "Entorno",
+		"create.envHint":         "CLAVE=valor, separados por comas",
+		"create.volumes":         "Volúmenes",
+		"create.volumesHint":     "[ruta host o volumen:]ruta contenedor, separados por comas",
+		"create.start":           "Iniciar ahora",
+		"create.startHint":       "sí para docker run -d, no para docker create",
+		"create.done":            "Contenedor %s creado",
+
+		"help.init":           "Generar un Dockerfile y un archivo compose para este proyecto",
+		"error.init":          "Error al inicializar el proyecto",
+		"init.title":          "Generar un proyecto %s",
+		"init.service":        "Nombre del servicio",
+		"init.port":           "Puerto",
+		"init.overwrite":      "%s ya existe, ¿sobrescribirlo?",
+		"init.written":        "%s escrito",
+		"init.next":           "¿Y ahora qué?",
+		"action.composeUp":    "Construir y ejecutar (docker compose up --build -d)",
+		"action.composeBuild": "Solo construir (docker compose build)",
+		"answer.yes":          "Sí",
+		"answer.no":           "No",
+
+		"help.compose":         "Explorar los proyectos compose",
+		"compose.title":        "Elige un proyecto compose:",
+		"compose.empty":        "No hay proyectos compose.",
+		"compose.actionsTitle": "Proyecto: %s",
+		"compose.watchTitle":   "docker compose watch: %s",
+		"error.chooseProject":  "Error al elegir el proyecto",
+		"column.services":      "SERVICIOS",
+		"column.directory":     "DIRECTORIO",
+		"action.composeWatch":  "Vigilar (docker compose watch)",
+
+		"stream.following": "siguiendo",
+		"stream.scrolled":  "%d líneas por encima del final",
+		"stream.ended":     "terminado",
+		"stream.failed":    "error: %v",
+		"stream.help":      "arriba/abajo/repág/avpág: desplazar  G: seguir  w: ajuste de línea  izq/der: desplazar lateralmente  e/E: exportar JSON/CSV  q: salir",
+
+		"help.lifecycle":         "Ejecutar start, stop, restart, pause, unpause, kill o rm en cada contenedor (--wait: uno a uno, sano primero)",
+		"lifecycle.usage":        "Uso: whale %s <nombre>...",
+		"lifecycle.done.start":   "iniciado",
+		"lifecycle.done.stop":    "detenido",
+		"lifecycle.done.restart": "reiniciado",
+		"lifecycle.done.pause":   "pausado",
+		"lifecycle.done.unpause": "reanudado",
+		"lifecycle.done.kill":    "terminado",
+		"lifecycle.done.rm":      "eliminado",
+
+		"match.cancelled":    "Ningún contenedor elegido para %q",
+		"help.noInteractive": "Fallar en lugar de preguntar cuando un nombre coincide con varios contenedores",
+
+		"help.filter": "Listar solo los contenedores que cumplen un filtro de docker ps (repetible)",
+
+		"header.host": "Host de Docker: %s",
+
+		"header.readOnly":    "[solo lectura]",
+		"help.readOnly":      "Desactivar las acciones que modifican contenedores, imágenes o proyectos",
+		"permissions.denied": "%s no está permitido aquí",
+
+		"confirm.prompt":     "Escribe %q para confirmar: ",
+		"confirm.aborted":    "Cancelado.",
+		"confirm.needsYes":   "No se ejecuta una operación destructiva sin terminal, usa --yes para confirmar",
+		"confirm.bulkRemove": "Esto eliminará: %s",
+		"help.prune":         "Eliminar los contenedores detenidos y las imágenes, redes y volúmenes sin usar",
+
+		"spinner.running": "Ejecutando %s…",
+
+		"warning":       "aviso: %s",
+		"retry.warning": "docker %s falló (%s), reintentando (%d/%d)",
+
+		"help.doctor":                 "Diagnosticar la instalación de docker y la conexión con el demonio",
+		"doctor.binary":               "CLI de Docker",
+		"doctor.binaryMissing":        "docker no está en el PATH",
+		"doctor.binaryHint":           "Instala Docker: https://docs.docker.com/get-docker/",
+		"doctor.context":              "Contexto",
+		"doctor.daemon":               "Demonio",
+		"doctor.daemonDown":           "no accesible",
+		"doctor.daemonHint":           "Inicia Docker Desktop o el servicio docker (p. ej. sudo systemctl start docker)",
+		"doctor.api":                  "Versión de API",
+		"doctor.apiRange":             "cliente %s, el demonio admite de %s a %s",
+		"doctor.apiTooOld":            "La CLI de docker es demasiado antigua para este demonio, actualízala",
+		"doctor.apiNegotiated":        "La CLI es más reciente que el demonio, las peticiones usan la API %s",
+		"doctor.socket":               "Socket",
+		"doctor.socketMissing":        "%s no existe",
+		"doctor.socketPermissionHint": "Tu usuario no puede acceder al socket de docker, añádelo al grupo docker o usa Docker rootless",
+
+		"permission.staleSession":       "%s pertenece al grupo docker, pero esta sesión empezó antes de añadirlo. Cierra la sesión y vuelve a entrar, o abre un shell con el grupo:",
+		"permission.intro":              "%s no tiene permiso para usar el socket de Docker. Puedes:",
+		"permission.group":              "  1. añadir tu usuario al grupo docker:",
+		"permission.groupRelog":         "     y cerrar la sesión y volver a entrar (o ejecutar newgrp docker)",
+		"permission.groupWarning":       "los miembros del grupo docker son en la práctica root en esta máquina",
+		"permission.rootless":           "  2. o usar Docker rootless, con un socket propiedad de tu usuario:",
+		"doctor.socketStaleSessionHint": "Estás en el grupo docker pero esta sesión es anterior, vuelve a iniciar sesión o ejecuta newgrp docker",
+
+		"list.emulated":    "⚠ %s",
+		"column.platform":  "PLATAFORMA",
+		"details.emulated": "⚠ Emulado: la imagen es %s pero el motor corre en %s, espera un comportamiento más lento y distinto",
+
+		"column.gpu":         "GPU",
+		"details.gpus":       "GPU: %s",
+		"details.gpuRuntime": "Runtime: %s (sin GPU solicitadas)",
+		"doctor.gpu":         "Runtime GPU",
+		"doctor.gpuNone":     "ningún runtime nvidia registrado",
+		"doctor.gpuHint":     "Instale NVIDIA Container Toolkit para usar docker run --gpus",
+		"doctor.gpuRuntimes": "disponible (%s)",
+
+		"help.resources":           "Sumar los límites de memoria y CPU de los contenedores en ejecución frente al host",
+		"error.getResources":       "Error al leer los límites de recursos:",
+		"resources.memLimit":       "LÍMITE MEM",
+		"resources.memReservation": "RESERVA MEM",
+		"resources.cpus":           "CPUS",
+		"resources.total":          "TOTAL",
+		"resources.commitment":     "%s: %s de %s (%.0f%%)",
+		"resources.overcommitted":  "sobrecomprometido",
+		"resources.unbounded":      "%d contenedores en ejecución no tienen límite de memoria o CPU y pueden usar todo el host",
+
+		"stats.throttled":  "LIMITADO",
+		"stats.workingSet": "La memoria es el working set de cgroup v2 (uso menos la caché recuperable), en lo que se basa el OOM killer.",
+
+		"logs.eventOOM":     "terminado por falta de memoria (OOM)",
+		"logs.eventDie":     "terminó con el código %s",
+		"logs.eventKill":    "terminado con la señal %s",
+		"logs.eventRestart": "reiniciado",
+		"logs.eventStart":   "iniciado",
+		"logs.eventHealth":  "salud: %s",
+
+		"logs.compressLocal":        "--compress solo se aplica a hosts ssh://, descargando sin comprimir",
+		"logs.downloaded":           "%s guardado (%s)",
+		"logs.downloadedCompressed": "%s guardado (%s, %s transferidos)",
+		"error.saveLogs":            "Error al guardar los logs:",
+
+		"stream.exported":     "exportado a %s",
+		"stream.exportFailed": "error al exportar: %v",
+
+		"stream.column": "columna %d",
+
+		"create.detach":       "Separar",
+		"create.detachHint":   "no para conectar esta terminal al contenedor (docker run -it)",
+		"create.attachedExit": "El contenedor terminó con el código %d",
+
+		"action.runTask":   "Ejecutar una tarea puntual (docker run --rm)",
+		"task.title":       "Ejecutar una tarea con %s",
+		"task.command":     "Comando",
+		"task.commandHint": "argumentos pasados a la imagen, separados por espacios",
+		"task.mount":       "Montar el directorio actual",
+		"task.mountHint":   "sí para montarlo en %s y ejecutar desde ahí",
+		"task.running":     "Tarea: %s",
+
+		"action.devShell":         "Abrir una shell con el usuario del dev container",
+		"action.devPorts":         "Mostrar los puertos redirigidos",
+		"action.restart":          "Reiniciar",
+		"details.devcontainer":    "Dev container de %s",
+		"devcontainer.portsTitle": "Puertos de %s (%s):",
+		"devcontainer.noPorts":    "Ningún puerto redirigido o publicado.",
+		"devcontainer.forwarded":  "  redirigido  %s",
+		"devcontainer.published":  "  publicado   %s",
+
+		"help.all": "Mostrar también los contenedores ocultos por list.ignore",
+
+		"help.cleanup":           "Revisar las sugerencias de limpieza y aplicar las elegidas",
+		"check.help":             "espacio: marcar  a: todo  intro: aplicar  esc: cancelar",
+		"plain.promptMany":       "Introduzca números entre 1 y %d separados por comas, all, o q para salir: ",
+		"error.cleanup":          "Error al preparar la limpieza:",
+		"cleanup.title":          "Sugerencias de limpieza:",
+		"cleanup.nothing":        "Nada que limpiar.",
+		"cleanup.kind":           "TIPO",
+		"cleanup.reason":         "MOTIVO",
+		"cleanup.kindProject":    "proyecto",
+		"cleanup.kindContainer":  "contenedor",
+		"cleanup.kindImage":      "imagen",
+		"cleanup.kindVolume":     "volumen",
+		"cleanup.projectStopped": "%d contenedores, ninguno en ejecución",
+		"cleanup.exitedDaysAgo":  "terminó hace %d días",
+		"cleanup.dangling":       "huérfana, %s, creada %s",
+		"cleanup.unusedVolume":   "no usado por ningún contenedor",
+
+		"sort.size":    "tamaño",
+		"details.size": "Capa escribible: %s (%s con la imagen)",
+
+		"prune.keptProtected": "Imagen protegida %s conservada",
+
+		"help.tags":      "Eliminar los tags antiguos de repositorios con muchos tags, conservando los N más recientes",
+		"tags.title":     "Tags a eliminar (conservando los %d más recientes de cada repositorio):",
+		"tags.state":     "ESTADO",
+		"tags.inUse":     "usado por un contenedor",
+		"tags.protected": "protegido",
+		"tags.kept":      "reciente",
+		"tags.old":       "antiguo",
+		"tags.nothing":   "Ningún repositorio tiene varios tags.",
+		"tags.refused":   "usado por un contenedor o protegido, conservado",
+
+		"help.registries":      "Mostrar los registros en los que ha iniciado sesión y cerrar sesión en uno",
+		"error.registries":     "Error al leer las credenciales de registro:",
+		"registries.none":      "No ha iniciado sesión en ningún registro.",
+		"registries.registry":  "REGISTRO",
+		"registries.user":      "USUARIO",
+		"registries.source":    "GUARDADO EN",
+		"registries.title":     "Elija un registro del que cerrar sesión:",
+		"registries.loggedOut": "Sesión cerrada en %s",
+
+		"doctor.proxy":               "Proxy del demonio",
+		"doctor.proxyNone":           "ninguno",
+		"doctor.proxyExcept":         "excepto %s",
+		"doctor.proxyLocalhostHint":  "NO_PROXY no incluye localhost, los pulls desde un registro local pasarán por el proxy y fallarán",
+		"doctor.proxyClientOnlyHint": "%s está definido en su shell pero el demonio no lo usa; configure el proxy en daemon.json o en el servicio docker",
+		"doctor.insecure":            "Registros inseguros",
+		"doctor.insecureNone":        "ninguno además de localhost",
+		"doctor.insecureHint":        "Estos registros se usan sin verificación TLS, las imágenes descargadas de ellos pueden ser alteradas",
+
+		"action.healthcheck": "Mostrar y ejecutar el healthcheck",
+		"health.none":        "%s no tiene healthcheck.",
+		"health.title":       "Healthcheck de %s:",
+		"health.test":        "Prueba",
+		"health.interval":    "Intervalo",
+		"health.timeout":     "Tiempo límite",
+		"health.startPeriod": "Arranque",
+		"health.retries":     "Reintentos",
+		"health.status":      "Estado: %s, %d fallos seguidos. Últimos resultados:",
+		"health.runPrompt":   "¿Ejecutar la prueba ahora? [s/N] ",
+		"health.failed":      "✗ falló: %v (%s)",
+		"health.passed":      "✓ correcto (%s)",
+
+		"action.wait":  "Esperar a que esté sano",
+		"help.wait":    "Esperar a que los contenedores estén sanos (--timeout 90s), para scripts",
+		"wait.usage":   "Uso: whale wait <nombre>... [--timeout D]",
+		"wait.waiting": "Esperando a %s",
+		"wait.exited":  "terminó con el código %d",
+		"wait.timeout": "no está sano después de %s",
+		"wait.ready":   "listo",
+
+		"check.helpReorder":        "espacio: marcar  mayús+arriba/abajo: mover  a: todo  intro: aplicar  esc: cancelar",
+		"lifecycle.stopping":       "no se inician los siguientes contenedores",
+		"lifecycle.nothingStopped": "Ningún contenedor detenido.",
+		"lifecycle.orderTitle":     "Contenedores a iniciar, en orden:",
+
+		"action.editLabels":   "Editar etiquetas (recrea el contenedor)",
+		"labels.title":        "Etiquetas de %s",
+		"labels.emptyRemoves": "vacío para eliminar",
+		"labels.add":          "Añadir",
+		"labels.addHint":      "clave=valor, separados por comas",
+		"labels.unchanged":    "Etiquetas sin cambios.",
+		"labels.done":         "etiquetas actualizadas",
+		"recreate.warning":    "⚠ Las etiquetas no se pueden cambiar en el sitio: %s se detendrá, se recreará con la misma configuración y se iniciará de nuevo. Los archivos escritos dentro del contenedor fuera de los volúmenes se pierden.",
+		"recreate.prompt":     "¿Recrearlo? [s/N] ",
+		"recreate.rolledBack": "se restauró el contenedor original",
+
+		"action.editNote": "Editar nota",
+		"notes.title":     "Nota de %s",
+		"notes.note":      "Nota",
+		"notes.hint":      "vacío para borrar",
+		"notes.saved":     "Nota de %s guardada",
+		"notes.invalid":   "se ignora %s, no es JSON válido",
+		"notes.usage":     "Uso: whale note <nombre> [texto...]",
+		"error.saveNote":  "Error al guardar la nota:",
+		"help.note":       "Mostrar o definir la nota de un contenedor",
+		"details.note":    "✎ %s",
+
+		"hint.alreadyRunning": "ya está en ejecución",
+		"hint.notRunning":     "no está en ejecución",
+		"hint.paused":         "en pausa, reanúdelo primero",
+		"hint.alreadyPaused":  "ya está en pausa",
+		"hint.notPaused":      "no está en pausa",
+
+		"action.start":   "Iniciar",
+		"action.stop":    "Detener",
+		"action.logs":    "Seguir los logs",
+		"action.rm":      "Eliminar",
+		"hint.stopFirst": "en ejecución, deténgalo primero",
+		"remove.prompt":  "¿Eliminar %s? [s/N] ",
+		"list.shortcuts": "s iniciar/detener · r reiniciar · l logs · d eliminar",
+
+		"list.repeat":            ". repetir %s",
+		"list.repeatUnavailable": "%s no está disponible para %s",
+		"help.keyRepeat":         "Repetir la última acción en el contenedor bajo el cursor",
+
+		"action.composeSuspend":  "Suspender (detener los contenedores en ejecución y recordarlos)",
+		"action.composeResume":   "Reanudar (iniciar de nuevo los contenedores suspendidos)",
+		"suspend.nothingRunning": "No hay nada en ejecución en %s.",
+		"suspend.done":           "suspendidos: %s",
+		"suspend.resumed":        "reanudados: %s",
+		"suspend.missing":        "%d contenedores suspendidos ya no existen, se omiten.",
+
+		"help.keyShortcuts": "Iniciar/detener, reiniciar, seguir los logs o eliminar el contenedor bajo el cursor",
+
+		"help.timings":     "Mostrar cuánto tardó el arranque, paso a paso",
+		"timings.step":     "PASO",
+		"timings.duration": "DURACIÓN",
+		"timings.total":    "total",
+		"timings.saved":    "Añadido a %s",
+		"timings.notSaved": "no se pudieron guardar los tiempos: %v",
+
+		"api.pinned": "El demonio solo admite la API %s, más antigua que la de la CLI: pueden faltar algunas funciones",
+
+		"offline.banner": "Demonio inalcanzable: contenedores tal como estaban a las %s, solo lectura, reconectando…",
+
+		"help.list": "Mostrar los nombres o IDs de los contenedores coincidentes, uno por línea, para scripts",
+
+		"help.pick": "Elegir un contenedor e imprimir su ID u otro campo, para pipelines",
+
+		"pick.noTerminal": "--stdin necesita una terminal para leer las teclas:",
+
+		"action.shell": "Abrir una shell",
+
+		"actions.group.lifecycle": "Ciclo de vida",
+		"actions.group.inspect":   "Inspeccionar",
+		"actions.group.files":     "Archivos",
+		"actions.group.danger":    "Zona de peligro",
+
+		"logs.title":     "docker logs -f: %s",
+		"action.pause":   "Pausar",
+		"action.unpause": "Reanudar",
+
+		"actions.filter":  "Filtro: %s",
+		"actions.noMatch": "Ninguna acción coincide, Esc para borrar el filtro.",
+
+		"action.compareImage":   "Comparar con otra etiqueta",
+		"imageDiff.chooseTitle": "Comparar %s con:",
+		"imageDiff.noOtherTag":  "No hay otra etiqueta de %s para comparar.",
+		"imageDiff.title":       "%s → %s",
+		"imageDiff.identical":   "Ambas etiquetas apuntan a la misma imagen.",
+		"imageDiff.size":        "Tamaño: %s → %s (%s)",
+		"imageDiff.layers":      "Capas: %d compartidas, %d añadidas, %d eliminadas",
+		"imageDiff.config":      "Cambios de configuración:",
+		"imageDiff.sameConfig":  "Ningún cambio de configuración.",
+
+		"tabs.containers":          "Contenedores",
+		"tabs.images":              "Imágenes",
+		"tabs.volumes":             "Volúmenes",
+		"tabs.networks":            "Redes",
+		"tabs.help":                "1-4/tab: cambiar de pestaña  ctrl+f: buscar",
+		"tabs.done.pullImage":      "descargada",
+		"tabs.done.tagImage":       "etiquetada",
+		"tabs.done.removeImage":    "eliminada",
+		"tabs.done.inspectVolume":  "inspeccionado",
+		"tabs.done.removeVolume":   "eliminado",
+		"tabs.done.pruneVolumes":   "volúmenes sin usar eliminados",
+		"tabs.done.inspectNetwork": "inspeccionada",
+		"tabs.done.removeNetwork":  "eliminada",
+		"tabs.done.pruneNetworks":  "redes sin usar eliminadas",
+		"column.driver":            "DRIVER",
+		"column.scope":             "ÁMBITO",
+		"action.pullImage":         "Volver a descargar",
+		"action.tagImage":          "Etiquetar",
+		"action.removeImage":       "Eliminar",
+		"action.inspectVolume":     "Inspeccionar",
+		"action.removeVolume":      "Eliminar",
+		"action.pruneVolumes":      "Eliminar los volúmenes sin usar",
+		"action.inspectNetwork":    "Inspeccionar",
+		"action.removeNetwork":     "Eliminar",
+		"action.pruneNetworks":     "Eliminar las redes sin usar",
+		"images.tagTitle":          "Etiquetar %s",
+		"images.newTag":            "Nueva etiqueta",
+		"images.newTagHint":        "repositorio:etiqueta, p. ej. registry.example.com/app:1.2",
+		"images.protectedRemove":   "protegida por images.protected",
+		"volumes.title":            "Elige un volumen:",
+		"volumes.actionsTitle":     "Volumen: %s",
+		"volumes.inspectTitle":     "Volumen %s",
+		"networks.title":           "Elige una red:",
+		"networks.actionsTitle":    "Red: %s",
+		"networks.inspectTitle":    "Red %s",
+		"error.chooseVolume":       "Error al elegir el volumen",
+		"error.chooseNetwork":      "Error al elegir la red",
+		"help.runTab":              "Abrir el panel en una pestaña: containers, images, volumes o networks",
+		"help.keyTabs":             "Cambiar entre las pestañas de contenedores, imágenes, volúmenes y redes",
+
+		"help.report":          "Escribir un informe Markdown o JSON de los contenedores, imágenes, volúmenes y redes (--format, --output ARCHIVO, --with-env)",
+		"error.report":         "Error al escribir el informe",
+		"report.written":       "Informe escrito en %s",
+		"report.title":         "Informe del host Docker: %s",
+		"report.generated":     "Generado",
+		"report.daemon":        "Demonio",
+		"report.image":         "Imagen",
+		"report.command":       "Comando",
+		"report.restartPolicy": "Política de reinicio",
+		"report.networks":      "Redes",
+		"report.mount":         "Montaje",
+		"report.env":           "Entorno",
+
+		"help.configExport":     "Agrupar tu configuración (tema, columnas, acciones, hosts) en un solo archivo (--only ui,hosts)",
+		"help.configImport":     "Fusionar un paquete de configuración con la tuya (--replace, --only ui,hosts)",
+		"configBundle.usage":    "Uso: whale config export [ARCHIVO] [--only a,b] | whale config import ARCHIVO [--replace] [--only a,b]",
+		"error.configBundle":    "Error con el paquete de configuración:",
+		"configBundle.exported": "Configuración exportada a %s: %s",
+		"configBundle.imported": "%s importado en %s",
+
+		"actions.group.packs": "Acciones del equipo",
+		"help.packs":          "Listar las acciones de los packs de packs.sources, descargándolas de nuevo con --update",
+		"packs.none":          "Ningún pack de acciones configurado, añade fuentes a packs.sources.",
+		"packs.summary":       "%s, %d acciones",
+		"packs.unavailable":   "pack de acciones %s no disponible: %v",
+		"packs.stale":         "no se pudo actualizar el pack de acciones %s, se usa la copia en caché: %v",
+
+		"help.serve":         "Exponer métricas Prometheus de los contenedores (--interval N)",
+		"serve.listening":    "Métricas expuestas en %s/metrics",
+		"error.serve":        "Error al exponer las métricas:",
+		"serve.sampleFailed": "falló el muestreo de los contenedores: %v",
+
+		"help.watch":          "Avisar de los contenedores que fallan, dejan de estar sanos o se quedan sin memoria, y enviarlos a los webhooks",
+		"error.watch":         "Error al seguir los eventos:",
+		"watch.all":           "Vigilando todos los contenedores, %d webhooks",
+		"watch.some":          "Vigilando %d contenedores, %d webhooks",
+		"watch.died":          "%s terminó con el código %d",
+		"watch.oom":           "%s fue detenido por falta de memoria",
+		"watch.unhealthy":     "%s no está sano",
+		"watch.webhookFailed": "no se pudo notificar a %s: %v",
+
+		"action.backupVolume":     "Respaldar en un archivo tar",
+		"action.restoreVolume":    "Restaurar desde un archivo tar",
+		"tabs.done.restoreVolume": "restaurado",
+		"backup.title":            "Respaldar el volumen %s",
+		"backup.file":             "Archivo",
+		"backup.fileHint":         "ruta local del .tar.gz a escribir",
+		"backup.done":             "✓ %s: respaldado en %s",
+		"restore.title":           "Restaurar el volumen %s",
+		"restore.fileHint":        "archivo .tar.gz local escrito por Respaldar",
+		"restore.wipe":            "Vaciar el volumen antes",
+		"restore.wipeHint":        "sí para borrar lo que el respaldo no contiene",
+		"restore.wipeSummary":     "Esto borrará todos los archivos del volumen %s antes de restaurar.",
+
+		"action.browseFiles":  "Explorar archivos",
+		"action.browseVolume": "Explorar archivos",
+		"files.title":         "Archivos de %s",
+		"files.volumeTitle":   "Archivos del volumen %s",
+		"files.loading":       "Cargando...",
+		"files.empty":         "Directorio vacío",
+		"files.help":          "enter: abrir · ←: padre · c: copiar aquí · q: volver",
+		"files.copied":        "copiado en %s",
+
+		"column.usedBy":   "USADO POR",
+		"volumes.stopped": "%s (detenido)",
+		"volumes.unused":  "-",
+
+		"action.removeOrphanVolumes":    "Eliminar los volúmenes anónimos huérfanos",
+		"tabs.done.removeOrphanVolumes": "volúmenes huérfanos eliminados",
+		"volumes.orphaned":              "- (huérfano)",
+		"volumes.noOrphans":             "ningún volumen anónimo huérfano",
+		"volumes.age":                   "hace %d días",
+		"volumes.orphansTitle":          "%d volúmenes anónimos que ningún contenedor monta, desmarca los que quieras conservar:",
+		"volumes.orphansFailed":         "%d de %d volúmenes no se pudieron eliminar: %s",
+
+		"details.missingBind":      "Origen del bind mount inexistente: %s",
+		"create.missingBind":       "⚠ %s no existe: docker montará un directorio vacío creado en su lugar.",
+		"create.missingBindPrompt": "¿Crear el contenedor de todos modos? [s/N] ",
+
+		"action.createNetwork":               "Crear una red",
+		"tabs.done.createNetwork":            "red creada",
+		"networkCreate.title":                "Crear una red",
+		"networkCreate.name":                 "Nombre",
+		"networkCreate.driver":               "Driver",
+		"networkCreate.driverHint":           "bridge, overlay, macvlan, ipvlan...",
+		"networkCreate.subnet":               "Subred",
+		"networkCreate.subnetHint":           "CIDR, p. ej. 172.28.0.0/16; vacío para que docker elija una",
+		"networkCreate.gateway":              "Puerta de enlace",
+		"networkCreate.gatewayHint":          "dirección dentro de la subred; vacío para la primera",
+		"networkCreate.internal":             "Interna",
+		"networkCreate.internalHint":         "sí para aislar la red del exterior",
+		"networkCreate.attachable":           "Conectable",
+		"networkCreate.attachableHint":       "sí para que contenedores independientes se unan a una red overlay",
+		"networkCreate.invalidName":          "%q no es un nombre de red válido",
+		"networkCreate.invalidSubnet":        "%s no es una subred CIDR, p. ej. 172.28.0.0/16",
+		"networkCreate.subnetHostBits":       "%s tiene bits de host, ¿quisiste decir %s?",
+		"networkCreate.invalidGateway":       "%s no es una dirección IP",
+		"networkCreate.gatewayWithoutSubnet": "una puerta de enlace necesita una subred",
+		"networkCreate.gatewayOutside":       "la puerta de enlace %s está fuera de %s",
+		"networkCreate.attachableOverlay":    "solo las redes overlay pueden ser conectables",
+
+		"column.subnet":      "SUBRED",
+		"ipam.network":       "la red %s (%s)",
+		"ipam.route":         "la ruta %s en %s",
+		"ipam.overlap":       "⚠ %s: %s se solapa con %s",
+		"ipam.createOverlap": "⚠ %s se solapa con %s: los contenedores no alcanzarán esas direcciones.",
+		"ipam.createPrompt":  "¿Crear la red de todos modos? [s/N] ",
+
+		"action.debugDns":       "Depurar el DNS",
+		"actions.group.network": "Red",
+		"dns.title":             "DNS desde %s",
+		"dns.names":             "Nombres",
+		"dns.namesHint":         "separados por comas, resueltos desde el contenedor",
+		"dns.defaultBridge":     "(solo bridge por defecto: sin DNS integrado, los nombres de contenedores no se resuelven)",
+
+		"action.testConnectivity": "Probar la conectividad",
+		"connect.title":           "Alcanzar desde %s",
+		"connect.target":          "Destino",
+		"connect.targetHint":      "nombre de contenedor, servicio o host, o una dirección IP",
+		"connect.port":            "Puerto",
+		"connect.portHint":        "puerto TCP; vacío para hacer ping",
+		"connect.reached":         "alcanzable",
+		"connect.reachedIn":       "alcanzable en %s ms",
+		"connect.dns":             "nombre no resuelto (%s)",
+		"connect.refused":         "conexión rechazada: nada escucha en ese puerto",
+		"connect.timeout":         "tiempo agotado: un firewall u otra red de por medio",
+		"connect.unreachable":     "inalcanzable",
+
+		"action.probeHttp": "Probar los puertos HTTP",
+		"probe.noPorts":    "%s no publica ningún puerto TCP.",
+		"probe.latency":    "en %s",
+		"probe.refused":    "conexión rechazada: nada escucha en el puerto del host",
+		"probe.timeout":    "sin respuesta en 5 s",
+		"probe.notHTTP":    "responde, pero no por HTTP(S)",
+
+		"action.showTraffic": "Tráfico por interfaz",
+		"traffic.title":      "Tráfico de red de %s",
+		"traffic.none":       "Ninguna interfaz de red aparte del loopback.",
+		"traffic.interface":  "INTERFAZ",
+		"traffic.received":   "RECIBIDO",
+		"traffic.sent":       "ENVIADO",
+		"traffic.rxRate":     "RX/S",
+		"traffic.txRate":     "TX/S",
+		"traffic.help":       "q: volver",
+		"stats.netRate":      "TASA DE RED",
+
+		"action.checkClock": "Comprobar el reloj",
+		"clock.inSync":      "reloj sincronizado con esta máquina (%s, ±%s)",
+		"clock.ahead":       "reloj %s adelantado respecto a esta máquina, más de %s",
+		"clock.behind":      "reloj %s atrasado respecto a esta máquina, más de %s",
+		"clock.hint":        "Los handshakes TLS y la validación de tokens pueden fallar. Los contenedores comparten el reloj del host del demonio: resincronízalo, p. ej. reiniciando Docker Desktop o la VM tras una suspensión.",
+
+		"create.pull":          "Descarga",
+		"create.pullHint":      "always, missing o never",
+		"pull.invalid":         "política de descarga %q desconocida, se esperaba always, missing o never",
+		"pull.always":          "Política always: %s se descarga primero de su registro.",
+		"pull.never":           "Política never: solo se usa la copia local de %s, no se contacta el registro.",
+		"pull.present":         "Política missing: %s está presente localmente, no se contacta el registro.",
+		"pull.absent":          "Política missing: %s no está presente localmente y se descargará de su registro.",
+		"pull.compose.always":  "Política always: la imagen de cada servicio se descarga primero de su registro.",
+		"pull.compose.missing": "Política missing: solo se descargan las imágenes de servicios ausentes localmente.",
+		"pull.compose.never":   "Política never: solo se usan imágenes locales, no se contacta el registro.",
+
+		"help.gc":    "Listar los contenedores detenidos que la política gc elimina, --apply para eliminarlos",
+		"gc.noRules": "Ninguna política gc: indica en gc.match las imágenes o etiquetas de los contenedores que se pueden eliminar.",
+		"gc.nothing": "Ningún contenedor coincidente detenido hace más de %d días.",
+		"gc.dryRun":  "Simulación, no se eliminó nada: ejecuta whale gc --apply para eliminarlos, o activa gc.enabled para que whale watch elimine estos contenedores a medida que envejecen.",
+		"gc.removed": "eliminado",
+		"gc.failed":  "gc: %v",
+		"watch.gc":   "Eliminando los contenedores coincidentes detenidos hace más de %d días cada %d minutos",
+
+		"stream.helpSplit":   "arriba/abajo/repág/avpág: desplazar  G: seguir  s: stdout/stderr  w: ajuste de línea  izq/der: desplazar lateralmente  e/E: exportar JSON/CSV  q: salir",
+		"stream.only.stdout": "solo stdout",
+		"stream.only.stderr": "solo stderr",
+
+		"help.follow":          "Abrir los logs de cada nuevo contenedor cuyo nombre o imagen empiece por un prefijo",
+		"error.follow":         "Error al seguir los contenedores:",
+		"follow.waiting":       "Esperando a que arranque %s... (ctrl+c para parar)",
+		"follow.any":           "un contenedor",
+		"follow.byName":        "un contenedor llamado %s*",
+		"follow.byImage":       "un contenedor de la imagen %s*",
+		"follow.byNameOrImage": "un contenedor llamado %s* o de esa imagen",
+		"follow.eventsEnded":   "docker events se detuvo",
+		"follow.title":         "Logs de %s, cambiando al siguiente contenedor coincidente cuando arranque",
+
+		"composeUp.title":          "Arrancando %s",
+		"composeUp.state.pending":  "pendiente",
+		"composeUp.state.pulling":  "descargando",
+		"composeUp.state.pulled":   "descargado",
+		"composeUp.state.building": "construyendo",
+		"composeUp.state.built":    "construido",
+		"composeUp.state.creating": "creando",
+		"composeUp.state.created":  "creado",
+		"composeUp.state.starting": "arrancando",
+		"composeUp.state.started":  "arrancado",
+		"composeUp.state.waiting":  "esperando a sus dependencias",
+		"composeUp.state.healthy":  "sano",
+		"composeUp.state.failed":   "fallido",
+		"composeUp.logs":           "Últimos logs:",
+		"composeUp.running":        "compose up en curso",
+		"composeUp.done":           "todo arrancado",
+		"composeUp.failed":         "compose up falló: %v",
+		"composeUp.help":           "arriba/abajo: mover  intro: mostrar la salida  q: salir",
+
+		"drift.image":             "imagen %s, el archivo indica %s",
+		"drift.env":               "entorno %s",
+		"drift.ports":             "puertos publicados",
+		"drift.badge":             "⚠ %s necesita recrearse: %s",
+		"drift.unavailable":       "⚠ no se pudo comparar el archivo compose: %v",
+		"action.composeReconcile": "Reconciliar: recrear los servicios que divergen del archivo compose",
+		"compose.reconcileTitle":  "Recreando %s",
+
+		"action.editCommand":     "Editar el comando de arranque (recrea el contenedor)",
+		"command.title":          "Comando de arranque de %s",
+		"command.entrypoint":     "Punto de entrada",
+		"command.entrypointHint": "vacío para ninguno",
+		"command.command":        "Comando",
+		"command.commandHint":    "p. ej. sleep infinity para mantener arrancado un contenedor que falla e inspeccionarlo",
+		"command.unchanged":      "El comando de arranque no cambió.",
+		"command.summary":        "Nuevo comando de arranque: %s",
+		"command.unterminated":   "comilla o escape sin cerrar en %q",
+		"command.done":           "recreado con el nuevo comando de arranque",
+
+		"action.rescue":    "Rescatar (recrear en reposo, sin reinicios, y abrir un shell)",
+		"action.endRescue": "Terminar el rescate (recrear con el comando original)",
+		"rescue.plan":      "Rescatar %s:\n  1. desactivar su política de reinicio\n  2. recrearlo con los mismos montajes, entorno y redes, ejecutando un comando en reposo en lugar del suyo\n  3. abrir un shell dentro\nTerminar el rescate, en su menú, lo recrea con su comando y su política de reinicio.",
+		"rescue.prompt":    "¿Rescatarlo? [s/N] ",
+		"rescue.done":      "recreado en reposo, abriendo un shell",
+		"rescue.endPlan":   "%s se recreará ejecutando de nuevo %s, con su política de reinicio.",
+		"rescue.ended":     "recreado con su comando original",
+
+		"action.showProvenance": "Mostrar la procedencia del build",
+		"provenance.title":      "Procedencia de %s",
+		"provenance.none":       "Sin procedencia: la imagen no tiene etiqueta de origen ni de revisión, ni atestación de build.",
+		"provenance.labelsOnly": "Según las etiquetas de la imagen, no se encontró atestación de build.",
+		"provenance.source":     "Origen",
+		"provenance.revision":   "Revisión",
+		"provenance.version":    "Versión",
+		"provenance.created":    "Construida",
+		"provenance.url":        "Página",
+		"provenance.dockerfile": "Dockerfile",
+		"provenance.builder":    "Builder",
+		"provenance.commit":     "Commit",
+		"provenance.copyPrompt": "¿Copiar el enlace del commit? [s/N] ",
+		"provenance.copied":     "Enlace del commit copiado al portapapeles.",
+
+		"help.digests":          "Avisar de los contenedores cuya etiqueta apunta a otro digest desde que arrancaron",
+		"digests.startedFrom":   "ARRANCADO DESDE",
+		"digests.pinned":        "fijado por digest",
+		"digests.pulledLocally": "la etiqueta apunta ahora a %s localmente, sin recrear",
+		"digests.noDigest":      "construida localmente, sin digest de registro",
+		"digests.unreachable":   "registro inaccesible",
+		"digests.moved":         "la etiqueta se movió a %s en el registro",
+		"digests.unchanged":     "sin cambios",
+		"digests.none":          "Ningún contenedor en ejecución.",
+		"digests.saveFailed":    "no se pudieron guardar los digests de arranque: %v",
+		"digests.movedHint":     "Esos contenedores ya no ejecutan lo que apunta su etiqueta: recrearlos ejecutaría otra imagen.",
+
+		"list.exported":     "%d contenedores exportados a %s",
+		"list.exportFailed": "falló la exportación: %v",
+		"help.keyExport":    "Exportar la lista mostrada a CSV, o a una tabla Markdown",
+
+		"keys.pending":     "%s … (esc para cancelar)",
+		"keys.unavailable": "%s no está disponible para %s",
+		"help.keyChords":   "Ir a una pestaña, ver keys.chords para más secuencias",
+
+		"macro.started":             "grabando una macro, m de nuevo para guardarla",
+		"macro.recording":           "● grabando una macro: %d pasos, m para guardarla",
+		"macro.empty":               "nada grabado, ninguna macro guardada",
+		"macro.saveTitle":           "Guardar la macro %s",
+		"macro.name":                "Nombre",
+		"macro.nameHint":            "para ejecutarla con M o whale macro <nombre>",
+		"macro.discarded":           "macro descartada",
+		"macro.saved":               "✓ macro %s guardada, %d pasos",
+		"macro.none":                "Ninguna macro todavía: pulsa m en la lista para grabar una.",
+		"macro.chooseTitle":         "Ejecutar una macro en %s:",
+		"macro.step":                "%s %d/%d: %s en %s",
+		"macro.stepLabel.pullImage": "Volver a descargar la imagen",
+		"macro.stepLabel.recreate":  "Recrear a partir de su configuración",
+		"macro.unknown":             "Ninguna macro llamada %s.",
+		"macro.usage":               "Uso: whale macro <macro> <nombre>...",
+		"macro.played":              "macro %s ejecutada",
+		"help.macro":                "Ejecutar una macro grabada en cada contenedor, o listar las macros",
+		"help.keyMacro":             "Grabar una macro de las acciones lanzadas desde la lista, ejecutar una",
+
+		"action.playMacro": "Ejecutar una macro",
+
+		"header.age":      "datos de hace %s",
+		"refresh.running": "actualizando…",
+		"refresh.failed":  "falló la actualización: %v",
+		"help.keyRefresh": "Volver a cargar la lista o la pestaña, r también en las pestañas de recursos",
+
+		"runFile.usage":   "Uso: whale run-file <archivo.yaml|archivo.json> [--dry-run]",
+		"runFile.pulled":  "descargada",
+		"runFile.pruned":  "limpiado",
+		"runFile.stopped": "Detenido, %d pasos sin ejecutar (continueOnError para seguir).",
+		"runFile.dryRun":  "Simulación, no se cambió nada.",
+		"runFile.summary": "%d pasos: %d correctos, %d fallidos",
+		"help.runFile":    "Ejecutar los pasos de un archivo YAML o JSON, --dry-run para listarlos",
+
+		"prune.kind.container":  "contenedor",
+		"prune.kind.image":      "imagen",
+		"prune.kind.network":    "red",
+		"prune.kind.volume":     "volumen",
+		"prune.kind.buildCache": "caché de build",
+		"prune.buildCache":      "capas recuperables",
+		"prune.previewTitle":    "%d elementos pueden irse, %s en total. Desmarca los que quieras conservar:",
+		"prune.nothing":         "Nada que limpiar.",
+		"prune.chosen":          "Esto eliminará los %d elementos marcados, %s en total.",
+		"prune.volumeData":      "Se perderán los datos de los volúmenes marcados.",
+		"prune.freed":           "%d elementos eliminados, %s liberados.",
+		"prune.failed":          "No se pudieron eliminar %d de %d elementos",
+
+		"action.forwardPort":    "Redirigir un puerto",
+		"forward.title":         "Redirigir un puerto de %s a localhost",
+		"forward.port":          "Puerto del contenedor",
+		"forward.portHint":      "el puerto en el que escucha el servicio dentro del contenedor",
+		"forward.exposedHint":   "expuestos: %s",
+		"forward.localPort":     "Puerto local",
+		"forward.localPortHint": "vacío para el mismo puerto",
+		"forward.invalidPort":   "puerto no válido %q",
+		"forward.hostNetwork":   "%s usa la red del host, sus puertos ya son accesibles",
+		"forward.noAddress":     "%s no tiene dirección propia en una red",
+		"forward.active":        "localhost:%d → %s:%d",
+		"forward.remote":        "El puerto está abierto en el loopback de %[1]s, ejecuta ssh -L %[2]d:localhost:%[2]d %[1]s para acceder desde aquí.",
+		"forward.stop":          "Pulsa intro para detener la redirección. ",
+
+		"action.addHostsEntry":    "Añadir a /etc/hosts",
+		"action.removeHostsEntry": "Quitar de /etc/hosts",
+		"hosts.noAddress":         "%s no tiene dirección propia en una red",
+		"hosts.sudo":              "%s no se puede escribir, usando sudo.",
+		"hosts.added":             "%s → %s en el archivo hosts",
+		"hosts.removed":           "%s quitado del archivo hosts",
+
+		"create.entrypoint":     "Punto de entrada",
+		"create.entrypointHint": "vacío para el de la imagen, %s; reemplazarlo descarta el comando de la imagen",
+		"create.command":        "Comando",
+		"create.commandHint":    "vacío para el de la imagen, %s, los argumentos tras la imagen lo reemplazan",
+		"create.user":           "Usuario",
+		"create.userHint":       "vacío para el de la imagen, p. ej. 1000:1000",
+		"create.workdir":        "Directorio de trabajo",
+		"create.workdirHint":    "vacío para el de la imagen",
+		"create.confirm":        "¿Crear el contenedor? [s/N] ",
+		"argv.title":            "El contenedor ejecutará:",
+		"argv.entrypoint":       "Punto de entrada",
+		"argv.cmd":              "Comando",
+		"argv.user":             "Usuario",
+		"argv.workdir":          "Directorio",
+		"argv.fromImage":        "de la imagen",
+		"argv.overridden":       "reemplazado",
+		"argv.default":          "por defecto de docker",
+		"argv.cmdDropped":       "el comando %s de la imagen se descarta porque el punto de entrada está reemplazado",
+		"argv.runs":             "→ %s",
+		"argv.empty":            "Ni punto de entrada ni comando: el contenedor no podrá arrancar.",
+		"argv.shellForm":        "El punto de entrada está en forma shell, se ejecuta con sh -c e ignora el comando.",
+
+		"action.checkEnvironment": "Comprobar el entorno",
+		"sanity.title":            "Entorno de %s:",
+		"sanity.configured":       "CONFIGURADO",
+		"sanity.probed":           "EN EL CONTENEDOR",
+		"sanity.timezone":         "Zona horaria",
+		"sanity.locale":           "Locale",
+		"sanity.user":             "Usuario",
+		"sanity.workdir":          "Directorio",
+		"sanity.openFiles":        "Archivos abiertos",
+		"sanity.processes":        "Procesos",
+		"sanity.notRunning":       "%s no está en ejecución, solo se muestra su configuración.",
+		"sanity.probeFailed":      "No se pudo sondear el contenedor: %v",
+		"sanity.utc":              "Ni TZ ni /etc/localtime: el contenedor usa UTC.",
+		"sanity.noLocale":         "Ni LANG ni LC_ALL: la locale POSIX puede estropear el texto no ASCII.",
+		"sanity.root":             "Los procesos se ejecutan como root.",
+
+		"errhint.nameConflict":  "Ya existe un contenedor llamado %s: elimínalo desde la lista con d, o elige otro nombre.",
+		"errhint.portContainer": "El puerto %s del host lo publica %s: detenlo desde la lista con s, o publica otro puerto.",
+		"errhint.portHost":      "El puerto %s del host lo ocupa un proceso fuera de docker: libéralo, o publica otro puerto.",
+		"errhint.noSpace":       "El disco del daemon está lleno: whale prune lista lo que puede irse, con los tamaños.",
+		"errhint.manifest":      "La etiqueta %s no existe en el registro: comprueba cómo se escribe, o descarga otra con Pull en la pestaña de imágenes.",
+		"errhint.accessDenied":  "La imagen no existe o requiere iniciar sesión: whale registries muestra con qué cuenta has iniciado sesión.",
+		"errhint.daemon":        "No se puede contactar con el daemon: whale doctor comprueba el socket, el contexto y los permisos.",
+
+		"errhint.manifestUnknown": "La etiqueta no existe en el registro: comprueba cómo se escribe, o descarga otra con Pull en la pestaña de imágenes.",
+
+		"timeout.expired": "%s terminado tras %s, el límite configurado para %q",
+
+		"column.host": "HOST",
+		"help.ps":     "Listar los contenedores, de todos los hosts configurados con --all-hosts",
+
+		"session.writeFailed": "no se pudo escribir la sesión en %s: %v",
+		"session.usage":       "Uso: whale replay ARCHIVO [--dry-run]",
+		"session.header":      "Sesión grabada en %s el %s, reproducida en %s",
+		"session.skipFailed":  "- %s %s: omitido, falló entonces (%s)",
+		"session.skipAction":  "- %s %s: omitido, no se puede reproducir sin intervención",
+		"session.nothing":     "Nada que reproducir.",
+		"help.replay":         "Ejecutar de nuevo las acciones de una sesión grabada, --dry-run para revisarlas",
+		"help.record":         "Grabar las acciones ejecutadas y sus resultados en un archivo",
+
+		"create.nameHint":    "ctrl+g: otro nombre, vacío para que docker elija uno",
+		"create.nameInvalid": "%s no es un nombre de contenedor válido: solo letras, dígitos, _ . y -, empezando por una letra o un dígito",
+		"create.nameTaken":   "Ya existe un contenedor llamado %s",
+
+		"search.title": "Buscar contenedores, imágenes, volúmenes y redes por nombre, ID o etiqueta:",
+		"search.none":  "Sin resultados",
+		"search.kind":  "TIPO",
+		"search.match": "COINCIDENCIA",
+		"search.more":  "… %d más, afina la búsqueda",
+		"search.help":  "escribe para buscar  arriba/abajo: mover  intro: abrir en su pestaña  esc: volver",
+		"error.search": "Error al buscar:",
+
+		"help.jump": "Abrir el menú de acciones del contenedor que coincide con <name>, o la lista de los que coinciden",
+
+		"list.recent": "Recientes",
+		"list.others": "Otros",
+
+		"help.uptime":      "Reinicios, tiempo caído y disponibilidad de cada contenedor en las últimas 24 h y 7 d",
+		"error.getEvents":  "Error al leer los eventos del daemon:",
+		"uptime.uptime":    "ACTIVO %s",
+		"uptime.restarts":  "REINICIOS %s",
+		"uptime.downtime":  "CAÍDO %s",
+		"uptime.truncated": "El daemon solo guarda sus últimos %d eventos: en este host no cubren todo el periodo, el tiempo caído anterior no se cuenta.",
+
+		"answer.yesWords": "s,si,sí",
+
+		"offline.cacheFailed": "no se pudo guardar la lista para usarla sin conexión: %v",
+
+		"runFile.removed":     "eliminado",
+		"session.parseFailed": "error al leer %s: %v",
+		"session.stepInvalid": "%s %s: %v",
+
+		"tabs.done.browseVolume": "explorado",
+
+		"tabs.done.backupVolume": "respaldado",
+	},
+}
+
+var locale = "en"
+
+// loadLocale picks the UI language from the config, then from the usual
+// locale environment variables, defaulting to English.
+func loadLocale(cfg *Config) {
+	candidates := []string{cfg.Ui.Locale, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+
+		language := strings.ToLower(candidate)
+		if i := strings.IndexAny(language, "_.-@"); i >= 0 {
+			language = language[:i]
+		}
+
+		if _, ok := catalogs[language]; ok {
+			locale = language
+		}
+		return
+	}
+}
+
+// tr returns the UI string for key in the active locale, formatted with args.
+func tr(key string, args ...any) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs["en"][key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+type iconSet struct {
+	States  map[string]string
+	Unknown string
+	DB      string
+	Web     string
+	Image   string
+	Compose string
+}
+
+var nerdIcons = iconSet{
+	States: map[string]string{
+		"running":    "\uf04b",
+		"paused":     "\uf04c",
+		"exited":     "\uf04d",
+		"restarting": "\uf021",
+		"created":    "\uf067",
+		"dead":       "\uf00d",
+		"removing":   "\uf1f8",
+	},
+	Unknown: "\uf128",
+	DB:      "\uf1c0",
+	Web:     "\uf0ac",
+	Image:   "\uf308",
+	Compose: "\uf1b3",
+}
+
+var asciiIcons = iconSet{
+	States: map[string]string{
+		"running":    ">",
+		"paused":     "=",
+		"exited":     ".",
+		"restarting": "~",
+		"created":    "+",
+		"dead":       "x",
+		"removing":   "-",
+	},
+	Unknown: "?",
+	DB:      "D",
+	Web:     "W",
+	Image:   " ",
+	Compose: "C",
+}
+
+var databaseImages = []string{"postgres", "mysql", "mariadb", "mongo", "redis", "valkey", "memcached", "cassandra", "couchdb", "elasticsearch", "clickhouse", "influxdb", "neo4j"}
+
+var webServerImages = []string{"nginx", "httpd", "apache", "caddy", "traefik", "haproxy", "envoy", "lighttpd", "varnish"}
+
+// activeIcons returns the icon set to use, or nil when icons are disabled.
+// Nerd-font glyphs need a UTF-8 capable terminal, so anything else falls
+// back to plain ASCII markers.
+func activeIcons(cfg *Config) *iconSet {
+	if !cfg.Ui.Icons || plainMode {
+		return nil
+	}
+	if supportsGlyphs() {
+		return &nerdIcons
+	}
+	return &asciiIcons
+}
+
+func supportsGlyphs() bool {
+	if os.Getenv("TERM") == "linux" {
+		return false
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToLower(value)
+			return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+		}
+	}
+
+	return false
+}
+
+func (icons *iconSet) render(container Container) string {
+	state, ok := icons.States[container.State]
+	if !ok {
+		state = icons.Unknown
+	}
+
+	kind := icons.Image
+	switch imageKind(container.Image) {
+	case "db":
+		kind = icons.DB
+	case "web":
+		kind = icons.Web
+	}
+
+	compose := " "
+	if container.Labels[composeProjectLabel] != "" {
+		compose = icons.Compose
+	}
+
+	return state + " " + kind + " " + compose
+}
+
+// imageKind classifies well-known images by repository name.
+func imageKind(image string) string {
+	name := imageRepository(image)
+	for _, db := range databaseImages {
+		if strings.HasPrefix(name, db) {
+			return "db"
+		}
+	}
+	for _, web := range webServerImages {
+		if strings.HasPrefix(name, web) {
+			return "web"
+		}
+	}
+	return ""
+}
+
+// imageRepository strips the registry, namespace, tag and digest from an
+// image reference: registry.example.com/team/app:tag -> app.
+func imageRepository(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.IndexAny(name, ":@"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ToLower(name)
+}
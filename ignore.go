@@ -0,0 +1,56 @@
+package main
+
+import "strings"
+
+// showIgnored lists the containers the ignore rules would hide, from --all.
+var showIgnored bool
+
+// IgnoreRules hide ephemeral infrastructure, such as the testcontainers
+// reaper, from the container list. Labels are "key" or "key=value".
+type IgnoreRules struct {
+	Images []string `json:"images"`
+	Labels []string `json:"labels"`
+}
+
+func (rules IgnoreRules) matches(container Container) bool {
+	name := imageName(container.Image)
+	for _, image := range rules.Images {
+		image = strings.ToLower(image)
+		if name == image || strings.HasSuffix(name, "/"+image) {
+			return true
+		}
+	}
+
+	for _, label := range rules.Labels {
+		if container.hasLabel(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageName strips the tag and digest of an image reference, keeping the
+// registry and namespace.
+func imageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return strings.ToLower(image)
+}
+
+func filterIgnored(cfg *Config, containers []Container) []Container {
+	if showIgnored {
+		return containers
+	}
+
+	var kept []Container
+	for _, container := range containers {
+		if !cfg.List.Ignore.matches(container) {
+			kept = append(kept, container)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// imageInspect is the subset of `docker image inspect` compared between two
+// tags.
+type imageInspect struct {
+	ID          string   `json:"Id"`
+	Size        int64    `json:"Size"`
+	RepoDigests []string `json:"RepoDigests"`
+	RootFS      struct {
+		Layers []string `json:"Layers"`
+	} `json:"RootFS"`
+	Config struct {
+		imageConfig
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+func inspectImage(reference string) (imageInspect, error) {
+	output, err := dockerRead("image", "inspect", "--format", "{{json .}}", reference)
+	if err != nil {
+		return imageInspect{}, fmt.Errorf("error inspecting image %s: %v", reference, err)
+	}
+
+	var inspect imageInspect
+	err = json.Unmarshal(output, &inspect)
+	if err != nil {
+		return imageInspect{}, fmt.Errorf("error parsing image %s: %v", reference, err)
+	}
+
+	return inspect, nil
+}
+
+// otherTags returns the other tagged images of the same repository, newest
+// first.
+func otherTags(image Image, images []Image) []Image {
+	for _, group := range groupTags(images) {
+		if group.Repository != image.Repository {
+			continue
+		}
+
+		var others []Image
+		for _, other := range group.Images {
+			if other.Tag != image.Tag {
+				others = append(others, other)
+			}
+		}
+		return others
+	}
+	return nil
+}
+
+// compareImage asks for another tag of the same repository and prints what
+// changed from the older of the two to the newer: layers, size and config.
+func compareImage(cfg *Config, image Image, images []Image) error {
+	others := otherTags(image, images)
+	if len(others) == 0 {
+		println(tr("imageDiff.noOtherTag", image.Repository))
+		return nil
+	}
+
+	table := [][]string{{tr("column.tag"), tr("column.size"), tr("column.created")}}
+	for _, other := range others {
+		table = append(table, []string{other.Tag, other.Size, other.Created})
+	}
+	rows := alignColumns(table)
+
+	choice, err := chooseFromList(cfg, tr("imageDiff.chooseTitle", image.Reference()), rows[0], rows[1:])
+	if err != nil || choice < 0 {
+		return err
+	}
+
+	from, to := image, others[choice]
+	if from.CreatedAt.After(to.CreatedAt) {
+		from, to = to, from
+	}
+
+	before, err := inspectImage(from.Reference())
+	if err != nil {
+		return err
+	}
+	after, err := inspectImage(to.Reference())
+	if err != nil {
+		return err
+	}
+
+	printImageDiff(from, to, before, after)
+	return nil
+}
+
+func printImageDiff(from Image, to Image, before imageInspect, after imageInspect) {
+	fmt.Println(tr("imageDiff.title", from.Reference(), to.Reference()))
+	if before.ID == after.ID {
+		fmt.Println(tr("imageDiff.identical"))
+		return
+	}
+
+	delta := after.Size - before.Size
+	sign := "+"
+	if delta < 0 {
+		sign, delta = "-", -delta
+	}
+	fmt.Println(tr("imageDiff.size", formatBytes(before.Size), formatBytes(after.Size), sign+formatBytes(delta)))
+
+	added := missingFrom(after.RootFS.Layers, before.RootFS.Layers)
+	removed := missingFrom(before.RootFS.Layers, after.RootFS.Layers)
+	shared := len(after.RootFS.Layers) - len(added)
+	fmt.Println(tr("imageDiff.layers", shared, len(added), len(removed)))
+	for _, layer := range added {
+		fmt.Println("  " + renderColor("+ "+shortDigest(layer), "32"))
+	}
+	for _, layer := range removed {
+		fmt.Println("  " + renderColor("- "+shortDigest(layer), "31"))
+	}
+
+	fmt.Println()
+	changes := configChanges(before, after)
+	if len(changes) == 0 {
+		fmt.Println(tr("imageDiff.sameConfig"))
+		return
+	}
+	fmt.Println(tr("imageDiff.config"))
+	for _, change := range changes {
+		color := "33"
+		switch change[0] {
+		case '+':
+			color = "32"
+		case '-':
+			color = "31"
+		}
+		fmt.Println("  " + renderColor(change, color))
+	}
+}
+
+// configChanges lists the config differences as "+ added", "- removed" and
+// "~ changed" lines, in Dockerfile instruction terms.
+func configChanges(before imageInspect, after imageInspect) []string {
+	var changes []string
+
+	scalar := func(name string, old string, new string) {
+		if old != new {
+			changes = append(changes, fmt.Sprintf("~ %s %s → %s", name, orNone(old), orNone(new)))
+		}
+	}
+	scalar("ENTRYPOINT", formatExec(before.Config.Entrypoint), formatExec(after.Config.Entrypoint))
+	scalar("CMD", formatExec(before.Config.Cmd), formatExec(after.Config.Cmd))
+	scalar("USER", before.Config.User, after.Config.User)
+	scalar("WORKDIR", before.Config.WorkingDir, after.Config.WorkingDir)
+
+	changes = append(changes, mapChanges("ENV", envMap(before.Config.Env), envMap(after.Config.Env))...)
+	changes = append(changes, mapChanges("LABEL", before.Config.Labels, after.Config.Labels)...)
+	changes = append(changes, mapChanges("EXPOSE", keySet(before.Config.ExposedPorts), keySet(after.Config.ExposedPorts))...)
+	changes = append(changes, mapChanges("VOLUME", keySet(before.Config.Volumes), keySet(after.Config.Volumes))...)
+
+	return changes
+}
+
+func mapChanges(name string, before map[string]string, after map[string]string) []string {
+	var keys []string
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	format := func(key string, value string) string {
+		if value == "" {
+			return key
+		}
+		return key + "=" + value
+	}
+
+	var changes []string
+	for _, key := range keys {
+		old, hadOld := before[key]
+		new, hasNew := after[key]
+		switch {
+		case !hadOld:
+			changes = append(changes, fmt.Sprintf("+ %s %s", name, format(key, new)))
+		case !hasNew:
+			changes = append(changes, fmt.Sprintf("- %s %s", name, format(key, old)))
+		case old != new:
+			changes = append(changes, fmt.Sprintf("~ %s %s: %s → %s", name, key, old, new))
+		}
+	}
+	return changes
+}
+
+func envMap(env []string) map[string]string {
+	values := make(map[string]string)
+	for _, variable := range env {
+		key, value, _ := strings.Cut(variable, "=")
+		values[key] = value
+	}
+	return values
+}
+
+func keySet(set map[string]struct{}) map[string]string {
+	values := make(map[string]string)
+	for key := range set {
+		values[key] = ""
+	}
+	return values
+}
+
+// missingFrom returns the items of list that are not in other, keeping
+// their order.
+func missingFrom(list []string, other []string) []string {
+	var missing []string
+	for _, item := range list {
+		if !containsString(other, item) {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+func formatExec(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	quoted, _ := json.Marshal(args)
+	return string(quoted)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func shortDigest(digest string) string {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found {
+		return shortID(digest)
+	}
+	return algorithm + ":" + shortID(hex)
+}
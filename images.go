@@ -0,0 +1,414 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Image struct {
+	ID         string
+	Repository string
+	Tag        string
+	Size       string
+	Created    string
+	CreatedAt  time.Time
+}
+
+// imageLine mirrors one line of `docker image ls --format '{{json .}}'`.
+type imageLine struct {
+	ID           string `json:"ID"`
+	Repository   string `json:"Repository"`
+	Tag          string `json:"Tag"`
+	Size         string `json:"Size"`
+	CreatedSince string `json:"CreatedSince"`
+	CreatedAt    string `json:"CreatedAt"`
+}
+
+// imageConfig is the subset of `docker image inspect` used to pre-fill forms.
+type imageConfig struct {
+	ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+	Volumes      map[string]struct{} `json:"Volumes"`
+	Env          []string            `json:"Env"`
+	Entrypoint   []string            `json:"Entrypoint"`
+	Cmd          []string            `json:"Cmd"`
+	User         string              `json:"User"`
+	WorkingDir   string              `json:"WorkingDir"`
+}
+
+func (image Image) Reference() string {
+	if image.Repository == "<none>" || image.Repository == "" {
+		return image.ID
+	}
+	if image.Tag == "<none>" || image.Tag == "" {
+		return image.Repository
+	}
+	return image.Repository + ":" + image.Tag
+}
+
+func getImages(cfg *Config) ([]Image, error) {
+	output, err := dockerRead("image", "ls", "--no-trunc", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var images []Image
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var i imageLine
+		err := json.Unmarshal([]byte(line), &i)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing image: %v", err)
+		}
+
+		createdAt, _ := parseDockerTime(cfg, i.CreatedAt)
+		created := i.CreatedSince
+		if created == "" && !createdAt.IsZero() {
+			created = humanAge(time.Since(createdAt))
+		}
+		images = append(images, Image{
+			ID:         i.ID,
+			Repository: i.Repository,
+			Tag:        i.Tag,
+			Size:       i.Size,
+			Created:    created,
+			CreatedAt:  createdAt,
+		})
+	}
+
+	markFetched()
+	return images, nil
+}
+
+func inspectImageConfig(reference string) (imageConfig, error) {
+	output, err := dockerRead("image", "inspect", "--format", "{{json .Config}}", reference)
+	if err != nil {
+		return imageConfig{}, fmt.Errorf("error inspecting image %s: %v", reference, err)
+	}
+
+	var imgConfig imageConfig
+	err = json.Unmarshal(output, &imgConfig)
+	if err != nil {
+		return imageConfig{}, fmt.Errorf("error parsing image %s: %v", reference, err)
+	}
+
+	return imgConfig, nil
+}
+
+func imageRows(images []Image) []string {
+	table := [][]string{{tr("column.repository"), tr("column.tag"), tr("column.id"), tr("column.size"), tr("column.created")}}
+	for _, image := range images {
+		table = append(table, []string{image.Repository, image.Tag, shortID(strings.TrimPrefix(image.ID, "sha256:")), image.Size, image.Created})
+	}
+	return alignColumns(table)
+}
+
+func chooseImage(cfg *Config, images []Image) (Image, error) {
+	rows := imageRows(images)
+	choice, err := chooseFromList(cfg, tr("images.title"), rows[0], rows[1:])
+	if err != nil || choice < 0 {
+		return Image{}, err
+	}
+
+	return images[choice], nil
+}
+
+func chooseImageAction(cfg *Config, image Image, images []Image) (string, error) {
+	actions := []string{"exit", "createContainer", "runTask"}
+	if len(otherTags(image, images)) > 0 {
+		actions = append(actions, "compareImage")
+	}
+	if image.Repository != "<none>" {
+		actions = append(actions, "pullImage")
+	}
+	actions = append(actions, "showProvenance", "tagImage", "removeImage")
+
+	return chooseResourceAction(cfg, tr("images.actionsTitle", image.Reference()), actions)
+}
+
+func imagesMode() {
+	cfg := currentConfig()
+	images, err := getImages(cfg)
+	if err != nil {
+		println(tr("error.getImages"), err)
+		os.Exit(1)
+	}
+
+	image, err := chooseImage(cfg, images)
+	if err != nil {
+		println(tr("error.chooseImage"), err)
+		os.Exit(1)
+	}
+	if image.ID == "" {
+		return
+	}
+
+	action, err := chooseImageAction(cfg, image, images)
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+
+	err = doImageAction(cfg, action, image, images)
+	if err != nil && !errors.Is(err, errCancelled) {
+		println(tr("error.doAction"), err)
+		os.Exit(1)
+	}
+}
+
+// imagesTab is the images tab of the dashboard.
+func imagesTab(notice string) (string, string) {
+	cfg := currentConfig()
+	images, err := getImages(cfg)
+	if err != nil {
+		return "containers", fmt.Sprintf("✗ %s: %v", tr("tabs.images"), err)
+	}
+
+	rows := imageRows(images)
+	keys := make([]string, len(images))
+	for i, image := range images {
+		keys[i] = image.ID + " " + image.Reference()
+	}
+	choice, next, err := chooseInTab(cfg, "images", tr("images.title"), rows[0], rows[1:], keys, notice)
+	if err != nil {
+		println(tr("error.chooseImage"), err)
+		os.Exit(1)
+	}
+	if next != "" || choice < 0 {
+		return next, ""
+	}
+
+	image := images[choice]
+	action, err := chooseImageAction(cfg, image, images)
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+
+	return tabOutcome("images", image.Reference(), action, func() error {
+		return doImageAction(cfg, action, image, images)
+	})
+}
+
+func doImageAction(cfg *Config, action string, image Image, images []Image) error {
+	switch action {
+	case "createContainer":
+		return createContainerFromImage(cfg, image)
+	case "runTask":
+		return runTask(cfg, image)
+	case "compareImage":
+		return compareImage(cfg, image, images)
+	case "pullImage":
+		return runDocker("pull", image.Reference())
+	case "showProvenance":
+		return showProvenance(image.Reference())
+	case "tagImage":
+		return tagImage(cfg, image)
+	case "removeImage":
+		if isProtectedImage(cfg, image) {
+			return fmt.Errorf("%s", tr("images.protectedRemove"))
+		}
+		if !confirmRemoval(image.Reference()) {
+			return errCancelled
+		}
+		return runDocker("image", "rm", image.Reference())
+	}
+
+	return nil
+}
+
+func tagImage(cfg *Config, image Image) error {
+	fields, ok, err := fillForm(cfg, tr("images.tagTitle", image.Reference()), []formField{
+		{Label: tr("images.newTag"), Value: image.Repository + ":", Hint: tr("images.newTagHint")},
+	})
+	if err != nil {
+		return err
+	}
+	target := strings.TrimSpace(fields[0].Value)
+	if !ok || target == "" || strings.HasSuffix(target, ":") {
+		return errCancelled
+	}
+
+	return runDocker("tag", image.Reference(), target)
+}
+
+// createContainerFromImage pre-fills port and volume mappings from the image
+// config and lets the user adjust them before running `docker create`/`run`.
+func createContainerFromImage(cfg *Config, image Image) error {
+	imgConfig, err := inspectImageConfig(image.Reference())
+	if err != nil {
+		return err
+	}
+
+	var ports []string
+	for port := range imgConfig.ExposedPorts {
+		number, protocol, _ := strings.Cut(port, "/")
+		mapping := number + ":" + number
+		if protocol != "" && protocol != "tcp" {
+			mapping += "/" + protocol
+		}
+		ports = append(ports, mapping)
+	}
+	sort.Strings(ports)
+
+	var volumes []string
+	for volume := range imgConfig.Volumes {
+		volumes = append(volumes, volume)
+	}
+	sort.Strings(volumes)
+
+	// A name the daemon would refuse is only reported once everything else
+	// was filled in, so it is checked before the form is submitted.
+	taken, err := containerNames()
+	if err != nil {
+		return err
+	}
+	prefix := namePrefix(cfg, image.Reference())
+	generate := func() string { return generateName(prefix, taken) }
+	check := func(name string) string { return checkContainerName(name, taken) }
+
+	fields, ok, err := fillForm(cfg, tr("create.title", image.Reference()), []formField{
+		{Label: tr("create.name"), Value: generate(), Hint: tr("create.nameHint"), Generate: generate, Check: check},
+		{Label: tr("create.ports"), Value: strings.Join(ports, ", "), Hint: tr("create.portsHint")},
+		{Label: tr("create.env"), Hint: tr("create.envHint")},
+		{Label: tr("create.volumes"), Value: strings.Join(volumes, ", "), Hint: tr("create.volumesHint")},
+		{Label: tr("create.start"), Value: yesNo(true), Hint: tr("create.startHint")},
+		{Label: tr("create.detach"), Value: yesNo(cfg.Run.Detach), Hint: tr("create.detachHint")},
+		{Label: tr("create.pull"), Value: pullPolicy(cfg), Hint: tr("create.pullHint")},
+		{Label: tr("create.entrypoint"), Hint: tr("create.entrypointHint", formatArgv(imgConfig.Entrypoint))},
+		{Label: tr("create.command"), Hint: tr("create.commandHint", formatArgv(imgConfig.Cmd))},
+		{Label: tr("create.user"), Hint: tr("create.userHint")},
+		{Label: tr("create.workdir"), Hint: tr("create.workdirHint")},
+	})
+	if err != nil || !ok {
+		return err
+	}
+	policy, err := parsePullPolicy(fields[6].Value)
+	if err != nil {
+		return err
+	}
+
+	start := isYes(fields[4].Value)
+	attach := start && !isYes(fields[5].Value)
+
+	args := []string{"create"}
+	if attach {
+		args = []string{"run", "-it"}
+	} else if start {
+		args = []string{"run", "-d"}
+	}
+	args = append(args, "--pull", policy)
+	if name := strings.TrimSpace(fields[0].Value); name != "" {
+		args = append(args, "--name", name)
+	}
+	for _, port := range splitList(fields[1].Value) {
+		args = append(args, "-p", port)
+	}
+	for _, env := range splitList(fields[2].Value) {
+		args = append(args, "-e", env)
+	}
+	var sources []string
+	for _, volume := range splitList(fields[3].Value) {
+		args = append(args, "-v", volume)
+		if source, ok := bindSource(volume); ok {
+			sources = append(sources, source)
+		}
+	}
+	if missing := missingBindSources(sources); len(missing) > 0 {
+		for _, source := range missing {
+			fmt.Println(renderColor(tr("create.missingBind", source), "33"))
+		}
+		fmt.Print(tr("create.missingBindPrompt"))
+		answer, _ := plainInput.ReadString('\n')
+		if !isYes(answer) {
+			fmt.Println(tr("confirm.aborted"))
+			return nil
+		}
+	}
+	overrides := argvOverrides{
+		Entrypoint: strings.TrimSpace(fields[7].Value),
+		Cmd:        strings.Fields(fields[8].Value),
+		User:       strings.TrimSpace(fields[9].Value),
+		Workdir:    strings.TrimSpace(fields[10].Value),
+	}
+	if overrides.Entrypoint != "" {
+		args = append(args, "--entrypoint", overrides.Entrypoint)
+	}
+	if overrides.User != "" {
+		args = append(args, "-u", overrides.User)
+	}
+	if overrides.Workdir != "" {
+		args = append(args, "-w", overrides.Workdir)
+	}
+	args = append(args, image.Reference())
+	args = append(args, overrides.Cmd...)
+
+	// Shown before anything runs, how the process gets its argv is the
+	// first thing to check when a container won't start.
+	fmt.Println(strings.Join(explainArgv(imgConfig, overrides), "\n"))
+	fmt.Print(tr("create.confirm"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		fmt.Println(tr("confirm.aborted"))
+		return nil
+	}
+	fmt.Println(pullSummary(policy, image.Reference()))
+
+	if attach {
+		return attachContainer(args)
+	}
+
+	cmd := exec.Command("docker", args...)
+	output, err := runWithSpinner(cmd)
+	if err != nil {
+		return fmt.Errorf("error creating container: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	println(tr("create.done", shortID(lastLine(output))))
+	return nil
+}
+
+// attachContainer runs `docker run -it` with the terminal handed over, for
+// REPLs and other interactive images. The wizard's program has exited by
+// then, so the command owns stdin/stdout until the container exits.
+func attachContainer(args []string) error {
+	cmd := exec.Command("docker", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		println(tr("create.attachedExit", exitErr.ExitCode()))
+		return nil
+	}
+	return err
+}
+
+// yesNo is value as the answer word of the active locale, for forms.
+func yesNo(value bool) string {
+	if value {
+		return strings.ToLower(tr("answer.yes"))
+	}
+	return strings.ToLower(tr("answer.no"))
+}
+
+// isYes reports whether value is one of the yes words of the active locale.
+func isYes(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, word := range strings.Split(tr("answer.yesWords"), ",") {
+		if value == word {
+			return true
+		}
+	}
+	return false
+}
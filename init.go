@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type projectTemplate struct {
+	Kind       string
+	Marker     string
+	Dockerfile string
+	Port       string
+}
+
+// projectTemplates are used when the `docker init` plugin isn't available,
+// the first one whose marker file exists in the current directory wins.
+var projectTemplates = []projectTemplate{
+	{
+		Kind:   "go",
+		Marker: "go.mod",
+		Port:   "8080",
+		Dockerfile: `FROM golang:1.21 AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /bin/app .
+
+FROM gcr.io/distroless/static-debian12
+COPY --from=build /bin/app /bin/app
+EXPOSE 8080
+ENTRYPOINT ["/bin/app"]
+`,
+	},
+	{
+		Kind:   "node",
+		Marker: "package.json",
+		Port:   "3000",
+		Dockerfile: `FROM node:20-alpine
+WORKDIR /app
+COPY package*.json ./
+RUN npm ci --omit=dev
+COPY . .
+EXPOSE 3000
+CMD ["npm", "start"]
+`,
+	},
+	{
+		Kind:   "python",
+		Marker: "requirements.txt",
+		Port:   "8000",
+		Dockerfile: `FROM python:3.12-slim
+WORKDIR /app
+COPY requirements.txt .
+RUN pip install --no-cache-dir -r requirements.txt
+COPY . .
+EXPOSE 8000
+CMD ["python", "main.py"]
+`,
+	},
+	{
+		Kind:   "generic",
+		Marker: "",
+		Port:   "8080",
+		Dockerfile: `FROM alpine:3.20
+WORKDIR /app
+COPY . .
+EXPOSE 8080
+CMD ["sh"]
+`,
+	},
+}
+
+const composeTemplate = `services:
+  %s:
+    build: .
+    ports:
+      - "%s:%s"
+`
+
+func hasDockerInit() bool {
+	cmd := exec.Command("docker", "init", "--version")
+	return cmd.Run() == nil
+}
+
+// initMode scaffolds a Dockerfile and compose file for the current project,
+// through `docker init` when installed, then offers to build and run it.
+func initMode() {
+	cfg := currentConfig()
+	if hasDockerInit() {
+		cmd := exec.Command("docker", "init")
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			println(tr("error.init"), err)
+			os.Exit(1)
+		}
+	} else {
+		err := scaffoldProject(cfg)
+		if err != nil {
+			println(tr("error.init"), err)
+			os.Exit(1)
+		}
+	}
+
+	if !fileExists("compose.yaml") && !fileExists("docker-compose.yml") && !fileExists("compose.yml") {
+		return
+	}
+
+	actions := allowedActions(cfg, []string{"exit", "composeUp", "composeBuild"})
+	var labels []string
+	for _, action := range actions {
+		labels = append(labels, actionLabel(cfg, action))
+	}
+
+	choice, err := chooseFromList(cfg, tr("init.next"), "", labels)
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+	if choice < 0 {
+		return
+	}
+
+	var args []string
+	switch actions[choice] {
+	case "composeUp":
+		dir, _ := os.Getwd()
+		compose := func(args ...string) *exec.Cmd {
+			return exec.Command("docker", append([]string{"compose"}, args...)...)
+		}
+		err = composeUp(cfg, tr("composeUp.title", filepath.Base(dir)), compose, []string{"--build"}, nil)
+		if err != nil {
+			println(tr("error.doAction"), err)
+			os.Exit(1)
+		}
+		return
+	case "composeBuild":
+		args = []string{"compose", "build"}
+	default:
+		return
+	}
+
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		println(tr("error.doAction"), err)
+		os.Exit(1)
+	}
+}
+
+func scaffoldProject(cfg *Config) error {
+	template := detectProjectTemplate()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	service := serviceName(filepath.Base(dir))
+
+	fields, ok, err := fillForm(cfg, tr("init.title", template.Kind), []formField{
+		{Label: tr("init.service"), Value: service},
+		{Label: tr("init.port"), Value: template.Port},
+	})
+	if err != nil || !ok {
+		return err
+	}
+	service = serviceName(fields[0].Value)
+	port := strings.TrimSpace(fields[1].Value)
+
+	dockerfile := template.Dockerfile
+	if port != template.Port {
+		dockerfile = strings.ReplaceAll(dockerfile, "EXPOSE "+template.Port, "EXPOSE "+port)
+	}
+
+	err = writeScaffoldFile(cfg, "Dockerfile", dockerfile)
+	if err != nil {
+		return err
+	}
+
+	return writeScaffoldFile(cfg, "compose.yaml", fmt.Sprintf(composeTemplate, service, port, port))
+}
+
+func detectProjectTemplate() projectTemplate {
+	for _, template := range projectTemplates {
+		if template.Marker == "" || fileExists(template.Marker) {
+			return template
+		}
+	}
+	return projectTemplates[len(projectTemplates)-1]
+}
+
+// writeScaffoldFile writes a generated file, asking before replacing one.
+func writeScaffoldFile(cfg *Config, name string, content string) error {
+	if fileExists(name) {
+		choice, err := chooseFromList(cfg, tr("init.overwrite", name), "", []string{tr("answer.no"), tr("answer.yes")})
+		if err != nil {
+			return err
+		}
+		if choice != 1 {
+			return nil
+		}
+	}
+
+	err := os.WriteFile(name, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %v", name, err)
+	}
+
+	println(tr("init.written", name))
+	return nil
+}
+
+var invalidServiceChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+func serviceName(name string) string {
+	name = invalidServiceChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// inspectCommand implements `whale inspect <name> [--field .Path.To.Value]`.
+func inspectCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	var query, field string
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--field" || args[i] == "-f":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			field = args[i]
+		case strings.HasPrefix(args[i], "--field="):
+			field = strings.TrimPrefix(args[i], "--field=")
+		default:
+			query = args[i]
+		}
+	}
+
+	if query == "" {
+		println(tr("inspect.usage"))
+		os.Exit(1)
+	}
+
+	requireAction(cfg, "inspect")
+
+	container, err := resolveContainer(cfg, containers, query)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	document, err := inspectDocument(container.ID)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	value, err := extractField(document, field)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	output, err := formatField(value)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(output)
+}
+
+// inspectDocument returns the raw inspect object of a container.
+func inspectDocument(id string) (any, error) {
+	output, err := dockerRead("container", "inspect", id)
+	if err != nil {
+		return nil, fmt.Errorf("error inspecting container: %v", err)
+	}
+
+	var list []any
+	err = json.Unmarshal(output, &list)
+	if err != nil || len(list) == 0 {
+		return nil, fmt.Errorf("error parsing container inspect: %v", err)
+	}
+
+	return list[0], nil
+}
+
+// extractField walks a dotted path such as .NetworkSettings.IPAddress or
+// .Mounts[0].Source through a decoded JSON document. Keys are matched
+// case-insensitively when there is no exact match.
+func extractField(document any, path string) (any, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), ".")
+	if path == "" {
+		return document, nil
+	}
+
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	current := document
+	walked := ""
+
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "" {
+			continue
+		}
+		walked += "." + segment
+
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				for key, candidate := range node {
+					if strings.EqualFold(key, segment) {
+						value, ok = candidate, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s", tr("inspect.noField", walked))
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("%s", tr("inspect.noField", walked))
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("%s", tr("inspect.noField", walked))
+		}
+	}
+
+	return current, nil
+}
+
+// formatField prints scalars bare so they can be used in scripts, and
+// objects as indented JSON.
+func formatField(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, float64:
+		output, err := json.Marshal(v)
+		return string(output), err
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	return string(output), err
+}
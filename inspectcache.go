@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/abroudoux/whale/pkg/whale"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inspectCacheSize bounds how many inspect documents are kept, enough for
+// the rows around the cursor and the ones just visited.
+const inspectCacheSize = 32
+
+// inspectCache holds recent `docker container inspect` documents so the
+// screens opened from the list don't wait for the daemon. Entries are
+// dropped on container events while the list is open.
+var inspectCache = struct {
+	sync.Mutex
+	entries map[string][]byte
+	order   []string // least recently used first
+}{entries: map[string][]byte{}}
+
+func cacheInspect(id string, document []byte) {
+	inspectCache.Lock()
+	defer inspectCache.Unlock()
+
+	if _, ok := inspectCache.entries[id]; !ok && len(inspectCache.order) >= inspectCacheSize {
+		delete(inspectCache.entries, inspectCache.order[0])
+		inspectCache.order = inspectCache.order[1:]
+	}
+	inspectCache.entries[id] = document
+	inspectCache.order = append(removeString(inspectCache.order, id), id)
+}
+
+func forgetInspect(id string) {
+	inspectCache.Lock()
+	defer inspectCache.Unlock()
+
+	delete(inspectCache.entries, id)
+	inspectCache.order = removeString(inspectCache.order, id)
+}
+
+func lookupInspect(id string) ([]byte, bool) {
+	inspectCache.Lock()
+	defer inspectCache.Unlock()
+
+	document, ok := inspectCache.entries[id]
+	if ok {
+		inspectCache.order = append(removeString(inspectCache.order, id), id)
+	}
+	return document, ok
+}
+
+// cachedInspect returns the inspect document of a container as JSON, from
+// the cache when it was prefetched. It is for showing the container, what
+// acts on it uses freshInspect.
+func cachedInspect(id string) ([]byte, error) {
+	if document, ok := lookupInspect(id); ok {
+		return document, nil
+	}
+	return freshInspect(id)
+}
+
+// freshInspect inspects a container as it is now, leaving the cache aside,
+// and caches the result.
+func freshInspect(id string) ([]byte, error) {
+	var output []byte
+	var err error
+	if engine != nil {
+		output, err = engine.inspect(id)
+	} else {
+		output, err = dockerRead("container", "inspect", "--format", "{{json .}}", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cacheInspect(id, output)
+	return output, nil
+}
+
+// prefetchInspect inspects the containers not cached yet in the background,
+// with a single call.
+func prefetchInspect(ids []string) tea.Cmd {
+	var missing []string
+	for _, id := range ids {
+		if _, ok := lookupInspect(id); !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if engine != nil {
+			for _, id := range missing {
+				if document, err := engine.inspect(id); err == nil {
+					cacheInspect(id, document)
+				}
+			}
+			return nil
+		}
+
+		output, err := dockerRead(append([]string{"container", "inspect", "--format", "{{json .}}"}, missing...)...)
+		if err != nil {
+			return nil
+		}
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		for i, line := range lines {
+			if i < len(missing) {
+				cacheInspect(missing[i], []byte(line))
+			}
+		}
+		return nil
+	}
+}
+
+// watchInspectEvents evicts containers from the cache as the daemon reports
+// changes to them, until the returned function is called.
+func watchInspectEvents() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	events, _ := daemon.Watch(ctx, whale.WatchOptions{Filters: []string{"type=container"}})
+
+	go func() {
+		for event := range events {
+			if event.ID != "" {
+				forgetInspect(event.ID)
+			}
+		}
+	}()
+	return cancel
+}
+
+func removeString(values []string, value string) []string {
+	for i, v := range values {
+		if v == value {
+			return append(values[:i:i], values[i+1:]...)
+		}
+	}
+	return values
+}
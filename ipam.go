@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// hostRoute is a route of the machine whale runs on, VPN ones included,
+// which containers can't reach when their network overlaps it.
+type hostRoute struct {
+	Net   *net.IPNet
+	Iface string
+}
+
+// dockerInterfaces carry the routes docker adds for its own networks.
+var dockerInterfaces = []string{"docker0", "br-", "veth", "docker_gwbridge"}
+
+// hostRoutes returns the IPv4 routes of this machine, without the default
+// route, host routes and those of docker's bridges. The routes only matter
+// when the daemon runs here, Docker Desktop included, so none are returned
+// for remote daemons.
+func hostRoutes() []hostRoute {
+	endpoint := dockerEndpoint()
+	if endpoint != "" && !strings.HasPrefix(endpoint, "unix://") && !strings.HasPrefix(endpoint, "npipe://") {
+		return nil
+	}
+
+	var routes []hostRoute
+	switch runtime.GOOS {
+	case "linux":
+		routes = linuxRoutes()
+	case "darwin", "freebsd":
+		routes = netstatRoutes()
+	}
+
+	var kept []hostRoute
+	for _, route := range routes {
+		ones, _ := route.Net.Mask.Size()
+		if ones == 0 || ones == 32 || containsAnyPrefix(route.Iface, dockerInterfaces) {
+			continue
+		}
+		kept = append(kept, route)
+	}
+	return kept
+}
+
+func containsAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// linuxRoutes reads /proc/net/route, where addresses are little-endian hex.
+func linuxRoutes() []hostRoute {
+	file, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var routes []hostRoute
+	scanner := bufio.NewScanner(file)
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		destination, err1 := hex.DecodeString(fields[1])
+		mask, err2 := hex.DecodeString(fields[7])
+		if err1 != nil || err2 != nil || len(destination) != 4 || len(mask) != 4 {
+			continue
+		}
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(destination))
+		ipMask := make(net.IPMask, 4)
+		binary.BigEndian.PutUint32(ipMask, binary.LittleEndian.Uint32(mask))
+		routes = append(routes, hostRoute{Net: &net.IPNet{IP: ip, Mask: ipMask}, Iface: fields[0]})
+	}
+	return routes
+}
+
+// netstatRoutes parses `netstat -rn -f inet`, whose destinations drop
+// trailing zero octets: "10.8/16", or "192.168.1" for a /24.
+func netstatRoutes() []hostRoute {
+	output, err := exec.Command("netstat", "-rn", "-f", "inet").Output()
+	if err != nil {
+		return nil
+	}
+
+	var routes []hostRoute
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] == "default" || fields[0] == "Destination" {
+			continue
+		}
+
+		address, prefix, hasPrefix := strings.Cut(fields[0], "/")
+		octets := strings.Split(address, ".")
+		if len(octets) > 4 {
+			continue
+		}
+		bits := 8 * len(octets)
+		if hasPrefix {
+			n, err := strconv.Atoi(prefix)
+			if err != nil {
+				continue
+			}
+			bits = n
+		}
+		for len(octets) < 4 {
+			octets = append(octets, "0")
+		}
+		_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", strings.Join(octets, "."), bits))
+		if err != nil {
+			continue
+		}
+		routes = append(routes, hostRoute{Net: ipNet, Iface: fields[3]})
+	}
+	return routes
+}
+
+// networkSubnets returns the IPAM subnets of the networks by network ID.
+func networkSubnets(networks []Network) (map[string][]*net.IPNet, error) {
+	subnets := map[string][]*net.IPNet{}
+	if len(networks) == 0 {
+		return subnets, nil
+	}
+
+	args := []string{"network", "inspect", "--format", "{{.Id}}\t{{range .IPAM.Config}}{{.Subnet}} {{end}}"}
+	for _, network := range networks {
+		args = append(args, network.ID)
+	}
+	output, err := dockerRead(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		id, list, _ := strings.Cut(line, "\t")
+		for _, subnet := range strings.Fields(list) {
+			if _, ipNet, err := net.ParseCIDR(subnet); err == nil {
+				subnets[id] = append(subnets[id], ipNet)
+			}
+		}
+	}
+	return subnets, nil
+}
+
+func subnetsOverlap(a *net.IPNet, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func sameSubnet(a *net.IPNet, b *net.IPNet) bool {
+	return a.IP.Equal(b.IP) && a.Mask.String() == b.Mask.String()
+}
+
+// subnetConflicts describes what subnet overlaps: other docker networks
+// and host routes. self is the ID of the network the subnet belongs to, if
+// any, whose own route is docker's and is skipped.
+func subnetConflicts(subnet *net.IPNet, self string, networks []Network, subnets map[string][]*net.IPNet, routes []hostRoute) []string {
+	var conflicts []string
+	for _, network := range networks {
+		if network.ID == self {
+			continue
+		}
+		for _, other := range subnets[network.ID] {
+			if subnetsOverlap(subnet, other) {
+				conflicts = append(conflicts, tr("ipam.network", network.Name, other))
+			}
+		}
+	}
+
+	for _, route := range routes {
+		if self != "" && sameSubnet(subnet, route.Net) {
+			continue
+		}
+		if subnetsOverlap(subnet, route.Net) {
+			conflicts = append(conflicts, tr("ipam.route", route.Net, route.Iface))
+		}
+	}
+	return conflicts
+}
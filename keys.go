@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+)
+
+// chord follows a two-key sequence being typed in a list, like `g i`: the
+// first key of a configured chord is held in pending until the next one.
+type chord struct {
+	pending string
+}
+
+// press feeds key to the chord. It returns the target of a completed chord,
+// a tab or an action, and whether the key was taken by the chord, in which
+// case the screen must not handle it: the first key of a chord, and the
+// second one even when the pair is bound to nothing.
+func (c *chord) press(cfg *Config, key string) (string, bool) {
+	if c.pending != "" {
+		sequence := c.pending + " " + key
+		c.pending = ""
+		return cfg.Keys.Chords[sequence], true
+	}
+
+	if isChordLeader(cfg, key) {
+		c.pending = key
+		return "", true
+	}
+	return "", false
+}
+
+// isChordLeader reports the keys starting one of the configured chords.
+func isChordLeader(cfg *Config, key string) bool {
+	for sequence, target := range cfg.Keys.Chords {
+		if first, _, ok := strings.Cut(sequence, " "); ok && first == key && target != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// footer is what the list shows while the second key is awaited.
+func (c chord) footer() string {
+	if c.pending == "" {
+		return ""
+	}
+	return tr("keys.pending", c.pending)
+}
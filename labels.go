@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// editLabels changes the labels of a container. The engine can't update
+// labels in place, so the container is recreated with the same settings,
+// which loses its writable layer: it is confirmed explicitly.
+func editLabels(cfg *Config, container Container) error {
+	spec, err := inspectSpec(container.ID)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	for key := range spec.Config.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fields []formField
+	for _, key := range keys {
+		fields = append(fields, formField{Label: key, Value: spec.Config.Labels[key], Hint: tr("labels.emptyRemoves")})
+	}
+	fields = append(fields, formField{Label: tr("labels.add"), Hint: tr("labels.addHint")})
+
+	fields, ok, err := fillForm(cfg, tr("labels.title", container.Name), fields)
+	if err != nil || !ok {
+		return err
+	}
+
+	labels := map[string]string{}
+	for i, key := range keys {
+		if value := fields[i].Value; value != "" {
+			labels[key] = value
+		}
+	}
+	for _, pair := range splitList(fields[len(fields)-1].Value) {
+		key, value, _ := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); key != "" {
+			labels[key] = strings.TrimSpace(value)
+		}
+	}
+
+	if fmt.Sprint(labels) == fmt.Sprint(spec.Config.Labels) {
+		fmt.Println(tr("labels.unchanged"))
+		return nil
+	}
+
+	policy := pullPolicy(cfg)
+	fmt.Println(renderColor(tr("recreate.warning", container.Name), "33"))
+	fmt.Println(pullSummary(policy, spec.Config.Image))
+	fmt.Print(tr("recreate.prompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		fmt.Println(tr("confirm.aborted"))
+		return nil
+	}
+
+	spec.Config.Labels = labels
+	err = recreateContainer(spec, policy)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s: %s\n", container.Name, tr("labels.done"))
+	return nil
+}
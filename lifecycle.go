@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// lifecycleCommands maps the CLI verbs to their docker subcommand.
+var lifecycleCommands = map[string]string{
+	"start":   "start",
+	"stop":    "stop",
+	"restart": "restart",
+	"pause":   "pause",
+	"unpause": "unpause",
+	"kill":    "kill",
+	"rm":      "rm",
+	"remove":  "rm",
+}
+
+func isLifecycleVerb(verb string) bool {
+	_, ok := lifecycleCommands[verb]
+	return ok
+}
+
+// runLifecycle runs a lifecycle docker command against one container.
+func runLifecycle(verb string, container Container) error {
+	action := lifecycleCommands[verb]
+	return withSpinner("docker "+action+" "+shortID(container.ID), func() error {
+		return daemon.Action(context.Background(), whale.Action(action), container.ID)
+	})
+}
+
+// lifecycleCommand implements `whale <verb> <name>... [--wait]`, running
+// the verb against every matching container and reporting each result.
+// With --wait, containers are handled in the given order and each one must
+// be healthy before the next is touched, for stacks without compose.
+func lifecycleCommand(containers []Container, verb string, args []string) {
+	cfg := currentConfig()
+	assumeYes := false
+	wait := false
+	var queries []string
+	for _, arg := range args {
+		switch arg {
+		case "--yes", "-y":
+			assumeYes = true
+		case "--wait", "-w":
+			wait = true
+		default:
+			queries = append(queries, arg)
+		}
+	}
+
+	requireAction(cfg, verb)
+
+	if len(queries) == 0 && wait && lifecycleCommands[verb] == "start" && isInteractive() {
+		queries = chooseStartOrder(cfg, containers)
+		if len(queries) == 0 {
+			return
+		}
+	}
+
+	if len(queries) == 0 {
+		println(tr("lifecycle.usage", verb))
+		os.Exit(1)
+	}
+
+	failed := false
+	var targets []Container
+	for _, query := range queries {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			fmt.Printf("✗ %s\n", err)
+			failed = true
+			continue
+		}
+		targets = append(targets, container)
+	}
+
+	if lifecycleCommands[verb] == "rm" && len(targets) > 1 {
+		var names []string
+		for _, container := range targets {
+			names = append(names, container.Name)
+		}
+		if !confirmBulk(tr("confirm.bulkRemove", strings.Join(names, ", ")), confirmWord, assumeYes) {
+			os.Exit(1)
+		}
+	}
+
+	for _, container := range targets {
+		if reason := actionUnavailable(verb, container); reason != "" {
+			fmt.Printf("✗ %s: %s\n", container.Name, reason)
+			failed = true
+			continue
+		}
+
+		err := runLifecycle(verb, container)
+		recordAction(verb, "container", container.Name, err)
+		if err != nil {
+			fmt.Println(errorNotice(container.Name, err, containers))
+			failed = true
+			continue
+		}
+
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("lifecycle.done."+lifecycleCommands[verb]))
+
+		if wait && (lifecycleCommands[verb] == "start" || lifecycleCommands[verb] == "restart") {
+			err := waitReady(container, time.Duration(max(cfg.Wait.Timeout, 1))*time.Second)
+			if err != nil {
+				fmt.Printf("✗ %s: %v, %s\n", container.Name, err, tr("lifecycle.stopping"))
+				os.Exit(1)
+			}
+			fmt.Printf("✓ %s: %s\n", container.Name, tr("wait.ready"))
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// chooseStartOrder lets the user pick the stopped containers to start and
+// put them in dependency order, returning their IDs.
+func chooseStartOrder(cfg *Config, containers []Container) []string {
+	var stopped []Container
+	for _, container := range containers {
+		if container.State != "running" {
+			stopped = append(stopped, container)
+		}
+	}
+	if len(stopped) == 0 {
+		fmt.Println(tr("lifecycle.nothingStopped"))
+		return nil
+	}
+
+	table := [][]string{{tr("column.name"), tr("column.image"), tr("column.status")}}
+	for _, container := range stopped {
+		table = append(table, []string{container.Name, truncateImage(container.Image, cfg.List.ImageWidth), container.Status})
+	}
+	rows := alignColumns(table)
+
+	chosen, err := chooseOrdered(cfg, tr("lifecycle.orderTitle"), rows[0], rows[1:], make([]bool, len(stopped)))
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	var ids []string
+	for _, i := range chosen {
+		ids = append(ids, stopped[i].ID)
+	}
+	return ids
+}
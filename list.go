@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// listCommand implements `whale list [--names | --ids] [--running] [query]`:
+// bare container names or IDs, sorted, one per line, so other scripts can
+// use whale's fuzzy matching as a selector, e.g.
+// `docker logs $(whale list web)`. It only lists, skipping the inspect
+// calls the menus need.
+func listCommand(args []string) {
+	ids := false
+	running := false
+	var query string
+	for _, arg := range args {
+		switch arg {
+		case "--names":
+			ids = false
+		case "--ids":
+			ids = true
+		case "--running":
+			running = true
+		default:
+			query = arg
+		}
+	}
+
+	containers, err := listContainers(currentConfig())
+	if err != nil {
+		println(tr("error.getContainers"), err)
+		os.Exit(1)
+	}
+
+	if query != "" {
+		containers = findContainers(containers, query)
+	}
+
+	var values []string
+	for _, container := range containers {
+		if running && container.State != "running" {
+			continue
+		}
+		if ids {
+			values = append(values, container.ID)
+		} else {
+			values = append(values, container.Name)
+		}
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		fmt.Println(value)
+	}
+	if len(values) == 0 && query != "" {
+		os.Exit(1)
+	}
+}
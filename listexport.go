@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// writeListExport writes a table, header first, as CSV ("csv") or as a
+// Markdown table ("markdown").
+func writeListExport(w io.Writer, format string, table [][]string) error {
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.WriteAll(table)
+		return writer.Error()
+	case "markdown":
+		for i, row := range table {
+			cells := make([]string, len(row))
+			for j, cell := range row {
+				cells[j] = markdownCell(cell)
+			}
+			_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(row)))
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// markdownCell escapes what would end a cell or break the table.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// exportList writes the container list to a new timestamped file in the
+// working directory and returns its name.
+func exportList(format string, table [][]string) (string, error) {
+	extension := "csv"
+	if format == "markdown" {
+		extension = "md"
+	}
+	path := fmt.Sprintf("whale-containers-%s.%s", time.Now().Format("20060102-150405"), extension)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return path, writeListExport(file, format, table)
+}
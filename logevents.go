@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// logEvents are the daemon events worth seeing next to the logs.
+var logEvents = []string{"oom", "die", "kill", "restart", "start", "health_status"}
+
+// containerEvent mirrors one line of `docker events --format '{{json .}}'`.
+type containerEvent struct {
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+func (event containerEvent) time() time.Time {
+	return time.Unix(0, event.TimeNano)
+}
+
+// logEntry is either a log line or an event, ordered by time.
+type logEntry struct {
+	Time   time.Time
+	Text   string
+	Stderr bool
+}
+
+// splitTimestamp separates the RFC 3339 prefix added by `docker logs
+// --timestamps` from the line.
+func splitTimestamp(line string) (time.Time, string) {
+	stamp, text, found := strings.Cut(line, " ")
+	if !found {
+		stamp = line
+		text = ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, line
+	}
+	return t, text
+}
+
+// logEventFilters select the events of the container id shown in its logs.
+func logEventFilters(id string) []string {
+	filters := []string{"type=container", "container=" + id}
+	for _, event := range logEvents {
+		filters = append(filters, "event="+event)
+	}
+	return filters
+}
+
+// dockerTime formats a time the way --since and --until accept it, with
+// nanosecond precision.
+func dockerTime(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// describeEvent renders an event as an annotated divider line.
+func describeEvent(event containerEvent, timestamps bool) string {
+	color := "33"
+	var text string
+	switch {
+	case event.Action == "oom":
+		text = tr("logs.eventOOM")
+		color = "31"
+	case event.Action == "die":
+		code := event.Actor.Attributes["exitCode"]
+		text = tr("logs.eventDie", code)
+		if code != "0" {
+			color = "31"
+		}
+	case event.Action == "kill":
+		text = tr("logs.eventKill", event.Actor.Attributes["signal"])
+	case event.Action == "restart":
+		text = tr("logs.eventRestart")
+	case event.Action == "start":
+		text = tr("logs.eventStart")
+		color = "32"
+	case strings.HasPrefix(event.Action, "health_status"):
+		status := strings.TrimSpace(strings.TrimPrefix(event.Action, "health_status:"))
+		text = tr("logs.eventHealth", status)
+		if status == "unhealthy" {
+			color = "31"
+		}
+	default:
+		text = event.Action
+	}
+
+	stamp := event.time().Format(time.TimeOnly)
+	if timestamps {
+		stamp = event.time().UTC().Format(time.RFC3339Nano)
+	}
+	return renderColor(fmt.Sprintf("──── %s %s ────", stamp, text), color)
+}
+
+// printAnnotatedHistory prints the last lines of the logs with the events
+// that happened meanwhile, and returns the time it stopped at.
+func printAnnotatedHistory(container Container, tail string, timestamps bool) (time.Time, error) {
+	now := time.Now()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker", "logs", "--timestamps", "--tail", tail, "--until", dockerTime(now), container.ID)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		os.Stderr.Write(stderr.Bytes())
+		return now, err
+	}
+
+	var entries []logEntry
+	collect := func(output string, isStderr bool) {
+		for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			t, text := splitTimestamp(line)
+			if timestamps {
+				text = line
+			}
+			entries = append(entries, logEntry{Time: t, Text: text, Stderr: isStderr})
+		}
+	}
+	collect(stdout.String(), false)
+	collect(stderr.String(), true)
+
+	since := container.StartedAt
+	for _, entry := range entries {
+		if !entry.Time.IsZero() && (since.IsZero() || entry.Time.Before(since)) {
+			since = entry.Time
+		}
+	}
+	if !since.IsZero() {
+		events, err := pastEvents(logEventFilters(container.ID), since, now)
+		if err == nil {
+			for _, event := range events {
+				entries = append(entries, logEntry{Time: event.time(), Text: describeEvent(event, timestamps)})
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	for _, entry := range entries {
+		if entry.Stderr {
+			fmt.Fprintln(os.Stderr, entry.Text)
+		} else {
+			fmt.Println(entry.Text)
+		}
+	}
+
+	return now, nil
+}
+
+// followAnnotated streams new log lines and events as they happen, until
+// the logs stream ends.
+func followAnnotated(container Container, since time.Time, timestamps bool) error {
+	var mu sync.Mutex
+	printLines := func(reader io.Reader, writer io.Writer, annotate func(string) string) {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			mu.Lock()
+			fmt.Fprintln(writer, annotate(scanner.Text()))
+			mu.Unlock()
+		}
+	}
+	stripStamp := func(line string) string {
+		if timestamps {
+			return line
+		}
+		_, text := splitTimestamp(line)
+		return text
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, _ := daemon.Watch(ctx, whale.WatchOptions{Filters: logEventFilters(container.ID), Since: since})
+	go func() {
+		for event := range events {
+			mu.Lock()
+			fmt.Println(describeEvent(fromWhaleEvent(event), timestamps))
+			mu.Unlock()
+		}
+	}()
+
+	logs := exec.Command("docker", "logs", "--timestamps", "--follow", "--since", dockerTime(since), container.ID)
+	stdout, err := logs.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := logs.StderrPipe()
+	if err != nil {
+		return err
+	}
+	err = logs.Start()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		printLines(stdout, os.Stdout, stripStamp)
+	}()
+	go func() {
+		defer wg.Done()
+		printLines(stderr, os.Stderr, stripStamp)
+	}()
+	wg.Wait()
+
+	return logs.Wait()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// logRecord is one log line split into the fields analysis tools expect.
+// Fields that can't be recognized are left empty, Raw is always kept.
+type logRecord struct {
+	Time    string `json:"time,omitempty"`
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message"`
+	Raw     string `json:"raw"`
+}
+
+var (
+	levelPattern = regexp.MustCompile(`(?i)\b(trace|debug|info|notice|warn|warning|error|err|fatal|critical|panic)\b`)
+	timeLayouts  = []string{time.RFC3339Nano, "2006-01-02 15:04:05.000", "2006-01-02 15:04:05", "2006/01/02 15:04:05"}
+)
+
+// parseLogRecord recognizes JSON logs first, then a leading timestamp and
+// the first level keyword of plain text lines.
+func parseLogRecord(line string) logRecord {
+	record := logRecord{Message: line, Raw: line}
+
+	var fields map[string]any
+	if strings.HasPrefix(strings.TrimSpace(line), "{") && json.Unmarshal([]byte(line), &fields) == nil {
+		record.Time = firstField(fields, "time", "ts", "timestamp", "@timestamp")
+		record.Level = strings.ToLower(firstField(fields, "level", "lvl", "severity"))
+		if message := firstField(fields, "msg", "message"); message != "" {
+			record.Message = message
+		}
+		return record
+	}
+
+	rest := line
+	for _, layout := range timeLayouts {
+		width := len(layout)
+		if layout == time.RFC3339Nano {
+			stamp, _, _ := strings.Cut(rest, " ")
+			width = len(stamp)
+		}
+		if width > len(rest) {
+			continue
+		}
+		if t, err := time.Parse(layout, rest[:width]); err == nil {
+			record.Time = t.Format(time.RFC3339Nano)
+			rest = strings.TrimSpace(rest[width:])
+			break
+		}
+	}
+
+	if match := levelPattern.FindString(rest); match != "" {
+		record.Level = normalizeLevel(match)
+	}
+	record.Message = rest
+	return record
+}
+
+func firstField(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+func normalizeLevel(level string) string {
+	switch level = strings.ToLower(level); level {
+	case "warning":
+		return "warn"
+	case "err":
+		return "error"
+	case "critical":
+		return "fatal"
+	}
+	return level
+}
+
+// writeLogExport writes lines as JSON Lines ("json") or CSV ("csv").
+func writeLogExport(w io.Writer, format string, lines []string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		for _, line := range lines {
+			err := encoder.Encode(parseLogRecord(line))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"time", "level", "message"})
+		for _, line := range lines {
+			record := parseLogRecord(line)
+			writer.Write([]string{record.Time, record.Level, record.Message})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// exportLogs writes lines to a new timestamped file in the working directory
+// and returns its name.
+func exportLogs(format string, lines []string) (string, error) {
+	extension := "jsonl"
+	if format == "csv" {
+		extension = "csv"
+	}
+	path := fmt.Sprintf("whale-logs-%s.%s", time.Now().Format("20060102-150405"), extension)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return path, writeLogExport(file, format, lines)
+}
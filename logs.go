@@ -0,0 +1,161 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// logsCommand implements `whale logs <name> [--tail N] [--no-follow]
+// [--timestamps] [--no-events] [--save FILE [--compress]] [--export json|csv]`,
+// streaming the logs of the matching container straight to the terminal with
+// daemon events interleaved, or saving them to a file.
+func logsCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	follow := true
+	events := true
+	timestamps := false
+	compress := false
+	tail := "100"
+	var query, save, export string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--no-follow":
+			follow = false
+		case "--follow", "-f":
+			follow = true
+		case "--no-events":
+			events = false
+		case "--timestamps", "-t":
+			timestamps = true
+		case "--compress", "-z":
+			compress = true
+		case "--export":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			if args[i] != "json" && args[i] != "csv" {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			export = args[i]
+		case "--save", "-o":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			save = args[i]
+		case "--tail", "-n":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			if _, err := strconv.Atoi(args[i]); err != nil && args[i] != "all" {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			tail = args[i]
+		default:
+			query = args[i]
+		}
+	}
+
+	if query == "" {
+		println(tr("logs.usage"))
+		os.Exit(1)
+	}
+
+	requireAction(cfg, "logs")
+
+	container, err := resolveContainer(cfg, containers, query)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	if export != "" {
+		err = exportCommandLogs(container, tail, export)
+		if err != nil {
+			println(tr("error.saveLogs"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if save != "" {
+		err = downloadLogs(container, tail, save, compress)
+		if err != nil {
+			println(tr("error.saveLogs"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if events {
+		since, err := printAnnotatedHistory(container, tail, timestamps)
+		if err == nil && follow {
+			err = followAnnotated(container, since, timestamps)
+		}
+		if err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	dockerArgs := []string{"logs", "--tail", tail}
+	if timestamps {
+		dockerArgs = append(dockerArgs, "--timestamps")
+	}
+	if follow {
+		dockerArgs = append(dockerArgs, "--follow")
+	}
+	dockerArgs = append(dockerArgs, container.ID)
+
+	cmd := exec.Command("docker", dockerArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// showLogs follows the logs of a container from the menus in the scrollable
+// viewer, or in plain mode straight in the terminal with daemon events
+// interleaved, until interrupted.
+func showLogs(cfg *Config, container Container) error {
+	if !plainMode {
+		cmd := exec.Command("docker", "logs", "--follow", "--tail", strconv.Itoa(max(cfg.Logs.Tail, 1)), container.ID)
+		return runSplitStream(cfg, tr("logs.title", container.Name), cmd)
+	}
+
+	since, err := printAnnotatedHistory(container, "100", false)
+	if err != nil {
+		return err
+	}
+	return followAnnotated(container, since, false)
+}
+
+// exportCommandLogs prints the last lines of the logs as JSON Lines or CSV.
+func exportCommandLogs(container Container, tail string, format string) error {
+	// "all" and the other non-numbers are 0, every line.
+	lines, _ := strconv.Atoi(tail)
+	output, err := readLogs(container.ID, whale.LogOptions{Tail: lines, Timestamps: true})
+	if err != nil {
+		return err
+	}
+
+	text := strings.TrimRight(string(output), "\n")
+	if text == "" {
+		return nil
+	}
+	return writeLogExport(os.Stdout, format, strings.Split(text, "\n"))
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// macroSteps are the steps a macro can hold besides the container actions:
+// pulling the image of the container again and recreating it from its
+// current settings, which together update it to the latest image.
+var macroSteps = []string{"pullImage", "recreate"}
+
+// recreatingSteps replace the container with a new one under the same name,
+// the steps after them go on with that one.
+var recreatingSteps = []string{"recreate", "editLabels", "editCommand", "rescue", "endRescue"}
+
+// recorder holds the actions of the macro being recorded from the list, nil
+// when none is.
+var recorder *macroRecorder
+
+type macroRecorder struct {
+	steps []string
+}
+
+// add records an action run from the list. Leaving whale and playing
+// macros are not steps.
+func (r *macroRecorder) add(action string) {
+	if action == "" || action == "exit" || action == "playMacro" {
+		return
+	}
+	r.steps = append(r.steps, action)
+}
+
+// stopRecording asks the name of the macro just recorded and saves it,
+// returning the outcome for the list.
+func stopRecording(cfg *Config) string {
+	steps := recorder.steps
+	recorder = nil
+	if len(steps) == 0 {
+		return tr("macro.empty")
+	}
+
+	fields, ok, err := fillForm(cfg, tr("macro.saveTitle", formatMacro(steps)), []formField{
+		{Label: tr("macro.name"), Hint: tr("macro.nameHint")},
+	})
+	if err != nil || !ok || strings.TrimSpace(fields[0].Value) == "" {
+		return tr("macro.discarded")
+	}
+	name := strings.TrimSpace(fields[0].Value)
+
+	err = saveMacro(name, steps)
+	if err != nil {
+		return fmt.Sprintf("✗ %s: %v", name, err)
+	}
+	return tr("macro.saved", name, len(steps))
+}
+
+// saveMacro writes a macro to the macros section of the user config, and to
+// the running config so that it can be played right away.
+func saveMacro(name string, steps []string) error {
+	sections, path, err := readUserConfig()
+	if err != nil {
+		return err
+	}
+
+	macros := map[string][]string{}
+	if section, ok := sections["macros"]; ok {
+		err = json.Unmarshal(section, &macros)
+		if err != nil {
+			return fmt.Errorf("error parsing the macros of %s: %v", path, err)
+		}
+	}
+	macros[name] = steps
+	sections["macros"], err = json.Marshal(macros)
+	if err != nil {
+		return err
+	}
+
+	err = writeUserConfig(sections, path)
+	if err != nil {
+		return err
+	}
+
+	current := currentConfig()
+	cfg := *current
+	cfg.Macros = map[string][]string{}
+	for name, steps := range current.Macros {
+		cfg.Macros[name] = steps
+	}
+	cfg.Macros[name] = steps
+	useConfig(&cfg)
+	return nil
+}
+
+func formatMacro(steps []string) string {
+	return strings.Join(steps, " → ")
+}
+
+func macroNames(cfg *Config) []string {
+	var names []string
+	for name, steps := range cfg.Macros {
+		if len(steps) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// chooseMacro asks which macro to play on a container and plays it.
+func chooseMacro(cfg *Config, container Container) error {
+	names := macroNames(cfg)
+	if len(names) == 0 {
+		return fmt.Errorf("%s", tr("macro.none"))
+	}
+
+	var rows []string
+	for _, name := range names {
+		rows = append(rows, fmt.Sprintf("%s  %s", name, renderColor(formatMacro(cfg.Macros[name]), "2")))
+	}
+	choice, err := chooseFromList(cfg, tr("macro.chooseTitle", container.Name), "", rows)
+	if err != nil || choice < 0 {
+		return err
+	}
+
+	return playMacro(cfg, names[choice], cfg.Macros[names[choice]], container)
+}
+
+// playMacro runs the steps of a macro on a container one after the other,
+// stopping at the first that fails or isn't allowed.
+func playMacro(cfg *Config, name string, steps []string, container Container) error {
+	for _, step := range steps {
+		if !isActionAllowed(cfg, step) {
+			return fmt.Errorf("%s", tr("permissions.denied", step))
+		}
+	}
+
+	for i, step := range steps {
+		fmt.Println(renderColor(tr("macro.step", name, i+1, len(steps), macroStepLabel(cfg, step), container.Name), "2"))
+		err := runMacroStep(cfg, step, container)
+		if err != nil {
+			return fmt.Errorf("%s: %v", macroStepLabel(cfg, step), err)
+		}
+		// Later steps inspect the container again, as changed by this one.
+		forgetInspect(container.ID)
+		if containsString(recreatingSteps, step) {
+			container, err = recreatedContainer(cfg, container)
+			if err != nil {
+				return fmt.Errorf("%s: %v", macroStepLabel(cfg, step), err)
+			}
+		}
+	}
+	return nil
+}
+
+// recreatedContainer is the container that took the place of container,
+// found by its name since its ID is gone with it.
+func recreatedContainer(cfg *Config, container Container) (Container, error) {
+	containers, err := listContainers(cfg)
+	if err != nil {
+		return container, err
+	}
+	for _, candidate := range containers {
+		if candidate.Name == container.Name {
+			return candidate, nil
+		}
+	}
+	return container, fmt.Errorf("%s", tr("match.none", container.Name))
+}
+
+func runMacroStep(cfg *Config, step string, container Container) error {
+	switch step {
+	case "pullImage":
+		return runDocker("pull", container.Image)
+	case "recreate":
+		spec, err := inspectSpec(container.ID)
+		if err != nil {
+			return err
+		}
+		return recreateContainer(spec, pullPolicy(cfg))
+	}
+	return doAction(cfg, step, container)
+}
+
+func macroStepLabel(cfg *Config, step string) string {
+	if containsString(macroSteps, step) {
+		return tr("macro.stepLabel." + step)
+	}
+	return actionLabel(cfg, step)
+}
+
+// macroCommand implements `whale macro [name] [names...]`: lists the macros,
+// or plays one on each container in turn, stopping at the first failure.
+func macroCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	requireAction(cfg, "playMacro")
+
+	if len(args) == 0 {
+		names := macroNames(cfg)
+		if len(names) == 0 {
+			fmt.Println(tr("macro.none"))
+			return
+		}
+		for _, name := range names {
+			fmt.Printf("  %-16s %s\n", name, formatMacro(cfg.Macros[name]))
+		}
+		return
+	}
+
+	name := args[0]
+	steps := cfg.Macros[name]
+	if len(steps) == 0 {
+		println(tr("macro.unknown", name))
+		os.Exit(1)
+	}
+	if len(args) < 2 {
+		println(tr("macro.usage"))
+		os.Exit(1)
+	}
+
+	for _, query := range args[1:] {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		err = playMacro(cfg, name, steps, container)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", container.Name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("macro.played", name))
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPlayMacroAfterRecreate checks that the steps after a recreate go on
+// with the new container, the old one being removed by then.
+func TestPlayMacroAfterRecreate(t *testing.T) {
+	calls := useFakeDockerCLI(t)
+	defer func(previous dockerClient) { daemon = previous }(daemon)
+	var actions []string
+	daemon = fakeDocker{containers: []Container{{ID: "new-id", Name: "web", State: "created"}}, actions: &actions}
+
+	old := Container{ID: "old-id", Name: "web", Image: "nginx:latest", State: "exited"}
+	err := playMacro(defaultConfig(), "update", []string{"recreate", "restart"}, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actions) != 1 || actions[0] != "restart new-id" {
+		t.Errorf("actions %q, want the restart of new-id", actions)
+	}
+	log := strings.Join(calls(), "\n")
+	for _, want := range []string{"rename old-id web-whale-", "create --name web", "rm old-id"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("docker not run with %q, ran:\n%s", want, log)
+		}
+	}
+}
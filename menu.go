@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listChoice is a plain single-choice menu for screens that don't need the
+// container list's columns and stats.
+type listChoice struct {
+	config   *Config
+	title    string
+	header   string
+	items    []string
+	cursor   int
+	selected int
+
+	// tab is set when the list is a tab of the dashboard; switchTab is
+	// then the tab the user switched to.
+	tab       string
+	switchTab string
+	notice    string
+	chord     chord
+}
+
+func initialListModel(cfg *Config, title string, header string, items []string) listChoice {
+	return listChoice{
+		config:   cfg,
+		title:    title,
+		header:   header,
+		items:    items,
+		selected: -1,
+	}
+}
+
+func (menu listChoice) Init() tea.Cmd {
+	if menu.tab != "" {
+		return scheduleAgeTick()
+	}
+	return nil
+}
+
+func (menu listChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ageTickMsg:
+		return menu, scheduleAgeTick()
+	case tea.KeyMsg:
+		if key := msg.String(); menu.tab != "" && (isRefreshKey(key) || key == "r") {
+			// The dashboard fetches the tab again when switching to it.
+			menu.switchTab = menu.tab
+			return menu, tea.Quit
+		}
+		if tab := switchTab(msg.String(), menu.tab); menu.tab != "" && tab != "" {
+			menu.switchTab = tab
+			return menu, tea.Quit
+		}
+		if menu.tab != "" && msg.String() == "ctrl+f" {
+			menu.switchTab = "search"
+			return menu, tea.Quit
+		}
+		if menu.tab != "" {
+			// Chords only go to other tabs here, actions need a container.
+			if target, ok := menu.chord.press(menu.config, msg.String()); ok {
+				if isDashboardTab(target) && target != menu.tab {
+					menu.switchTab = target
+					return menu, tea.Quit
+				}
+				return menu, nil
+			}
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return menu, tea.Quit
+		case "up":
+			menu.cursor--
+			if menu.cursor < 0 {
+				menu.cursor = len(menu.items) - 1
+			}
+		case "down":
+			menu.cursor++
+			if menu.cursor >= len(menu.items) {
+				menu.cursor = 0
+			}
+		case "enter":
+			if len(menu.items) == 0 {
+				break
+			}
+			menu.selected = menu.cursor
+			return menu, tea.Quit
+		}
+	}
+
+	return menu, nil
+}
+
+func (menu listChoice) View() string {
+	s := newFrame()
+	s.WriteString(renderHeader(menu.config))
+	if menu.tab != "" {
+		s.WriteString(renderTabs(menu.config, menu.tab))
+	}
+	s.line(menu.title, "\n")
+
+	if menu.header != "" {
+		s.line("  ", menu.header)
+	}
+
+	for i, item := range menu.items {
+		if menu.cursor == i {
+			s.line(renderCursor(menu.config), " ", renderActionSelected(menu.config, item, true))
+		} else {
+			s.line("  ", item)
+		}
+	}
+
+	if menu.tab != "" {
+		s.WriteByte('\n')
+		help := tr("tabs.help")
+		if pending := menu.chord.footer(); pending != "" {
+			help = pending
+		}
+		s.colored(help, "2")
+		s.WriteByte('\n')
+	}
+	if menu.notice != "" {
+		s.colored(menu.notice, "33")
+		s.WriteByte('\n')
+	}
+
+	return s.done()
+}
+
+// chooseFromList returns the index of the chosen item, or -1 if the user quit.
+func chooseFromList(cfg *Config, title string, header string, items []string) (int, error) {
+	if plainMode {
+		return choosePlain(title, header, items)
+	}
+
+	finalModel, err := runProgram(initialListModel(cfg, title, header, items))
+	if err != nil {
+		return -1, err
+	}
+
+	return finalModel.(listChoice).selected, nil
+}
+
+// checkChoice is a multiple-choice menu where items are toggled with space
+// and the selection is confirmed with enter. When reorder is set, items can
+// also be moved to choose the order they are returned in.
+type checkChoice struct {
+	config    *Config
+	title     string
+	header    string
+	items     []string
+	checked   []bool
+	indexes   []int
+	reorder   bool
+	cursor    int
+	confirmed bool
+}
+
+func (menu checkChoice) Init() tea.Cmd {
+	return nil
+}
+
+func (menu checkChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return menu, tea.Quit
+		case "up":
+			menu.cursor--
+			if menu.cursor < 0 {
+				menu.cursor = len(menu.items) - 1
+			}
+		case "down":
+			menu.cursor++
+			if menu.cursor >= len(menu.items) {
+				menu.cursor = 0
+			}
+		case " ", "x":
+			if len(menu.items) > 0 {
+				menu.checked[menu.cursor] = !menu.checked[menu.cursor]
+			}
+		case "a":
+			all := true
+			for _, checked := range menu.checked {
+				all = all && checked
+			}
+			for i := range menu.checked {
+				menu.checked[i] = !all
+			}
+		case "shift+up", "K":
+			if menu.reorder && menu.cursor > 0 {
+				menu.swap(menu.cursor, menu.cursor-1)
+				menu.cursor--
+			}
+		case "shift+down", "J":
+			if menu.reorder && menu.cursor < len(menu.items)-1 {
+				menu.swap(menu.cursor, menu.cursor+1)
+				menu.cursor++
+			}
+		case "enter":
+			menu.confirmed = true
+			return menu, tea.Quit
+		}
+	}
+
+	return menu, nil
+}
+
+func (menu *checkChoice) swap(i, j int) {
+	menu.items[i], menu.items[j] = menu.items[j], menu.items[i]
+	menu.checked[i], menu.checked[j] = menu.checked[j], menu.checked[i]
+	menu.indexes[i], menu.indexes[j] = menu.indexes[j], menu.indexes[i]
+}
+
+func (menu checkChoice) View() string {
+	s := renderHeader(menu.config)
+	s += menu.title + "\n\n"
+
+	if menu.header != "" {
+		s += fmt.Sprintf("      %s\n", menu.header)
+	}
+
+	for i, item := range menu.items {
+		box := "[ ]"
+		if menu.checked[i] {
+			box = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s", box, item)
+		if menu.cursor == i {
+			s += fmt.Sprintf("%s %s\n", renderCursor(menu.config), renderActionSelected(menu.config, line, true))
+		} else {
+			s += fmt.Sprintf("  %s\n", line)
+		}
+	}
+
+	help := tr("check.help")
+	if menu.reorder {
+		help = tr("check.helpReorder")
+	}
+	s += "\n" + help + "\n"
+	return s
+}
+
+// chooseMany returns the indexes of the checked items, or nil if the user
+// quit. checked gives the initial state of each item.
+func chooseMany(cfg *Config, title string, header string, items []string, checked []bool) ([]int, error) {
+	return runCheckChoice(cfg, title, header, items, checked, false)
+}
+
+// chooseOrdered is chooseMany where the user can also reorder the items,
+// returning the indexes in the chosen order. In plain mode, the order of the
+// typed numbers is kept.
+func chooseOrdered(cfg *Config, title string, header string, items []string, checked []bool) ([]int, error) {
+	return runCheckChoice(cfg, title, header, items, checked, true)
+}
+
+func runCheckChoice(cfg *Config, title string, header string, items []string, checked []bool, reorder bool) ([]int, error) {
+	if plainMode {
+		return chooseManyPlain(title, header, items)
+	}
+
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	finalModel, err := runProgram(checkChoice{
+		config:  cfg,
+		title:   title,
+		header:  header,
+		items:   append([]string{}, items...),
+		checked: append([]bool{}, checked...),
+		indexes: indexes,
+		reorder: reorder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	menu := finalModel.(checkChoice)
+	if !menu.confirmed {
+		return nil, nil
+	}
+
+	var chosen []int
+	for i, checked := range menu.checked {
+		if checked {
+			chosen = append(chosen, menu.indexes[i])
+		}
+	}
+	return chosen, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMetricsAddress is where `whale serve` listens without --metrics.
+const defaultMetricsAddress = ":9410"
+
+// containerStates are the values of whale_container_state, one series each
+// so that alerts can match on the state label.
+var containerStates = []string{"created", "running", "paused", "restarting", "exited", "removing", "dead"}
+
+// metricsExporter samples the containers in the background and serves the
+// last sample, since `docker stats` takes a couple of seconds to answer.
+type metricsExporter struct {
+	config   *Config
+	mu       sync.Mutex
+	page     string
+	failures int
+}
+
+// serveCommand implements `whale serve [--metrics ADDR] [--interval N]`:
+// Prometheus metrics about the containers, gathered through the same calls
+// as the list.
+func serveCommand(args []string) {
+	cfg := currentConfig()
+	address := defaultMetricsAddress
+	interval := max(cfg.List.StatsInterval, 1)
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--metrics", "--interval":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			if args[i-1] == "--metrics" {
+				address = args[i]
+				break
+			}
+			value, err := strconv.Atoi(args[i])
+			if err != nil || value <= 0 {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			interval = value
+		}
+	}
+
+	requireAction(cfg, "serve")
+
+	exporter := &metricsExporter{config: cfg}
+	exporter.sample()
+	go func() {
+		for range time.Tick(time.Duration(interval) * time.Second) {
+			exporter.sample()
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", exporter.serveHTTP)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<html><body><a href="/metrics">metrics</a></body></html>`)
+	})
+
+	fmt.Println(tr("serve.listening", address))
+	err := http.ListenAndServe(address, mux)
+	if err != nil {
+		println(tr("error.serve"), err.Error())
+		os.Exit(1)
+	}
+}
+
+func (exporter *metricsExporter) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	exporter.mu.Lock()
+	page := exporter.page
+	exporter.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+func (exporter *metricsExporter) sample() {
+	start := time.Now()
+	containers, err := getContainers(exporter.config)
+	var stats map[string]containerStats
+	if err == nil {
+		stats, err = getContainerStats()
+	}
+	duration := time.Since(start)
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+	if err != nil {
+		exporter.failures++
+		warn(tr("serve.sampleFailed", err))
+	}
+	exporter.page = renderMetrics(containers, stats, err == nil, exporter.failures, duration)
+}
+
+// labelEscaper escapes label values as the text format expects.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricWriter writes the Prometheus text format, one family at a time.
+type metricWriter struct {
+	b strings.Builder
+}
+
+func (w *metricWriter) family(name string, kind string, help string) {
+	fmt.Fprintf(&w.b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (w *metricWriter) sample(name string, labels map[string]string, value float64) {
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, key, labelEscaper.Replace(labels[key])))
+	}
+
+	series := name
+	if len(pairs) > 0 {
+		series += "{" + strings.Join(pairs, ",") + "}"
+	}
+	fmt.Fprintf(&w.b, "%s %s\n", series, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func renderMetrics(containers []Container, stats map[string]containerStats, up bool, failures int, duration time.Duration) string {
+	var w metricWriter
+
+	w.family("whale_up", "gauge", "Whether the last sample of the daemon succeeded.")
+	w.sample("whale_up", nil, boolValue(up))
+	w.family("whale_sample_duration_seconds", "gauge", "How long the last sample took.")
+	w.sample("whale_sample_duration_seconds", nil, duration.Seconds())
+	w.family("whale_sample_failures_total", "counter", "Samples that failed since whale started.")
+	w.sample("whale_sample_failures_total", nil, float64(failures))
+	if !up {
+		return w.b.String()
+	}
+
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Name < containers[j].Name
+	})
+	name := func(container Container) map[string]string {
+		return map[string]string{"name": container.Name}
+	}
+
+	w.family("whale_container_info", "gauge", "Containers, with their ID, image and compose project.")
+	for _, container := range containers {
+		w.sample("whale_container_info", map[string]string{
+			"name":    container.Name,
+			"id":      shortID(container.ID),
+			"image":   container.Image,
+			"project": container.Labels[composeProjectLabel],
+		}, 1)
+	}
+
+	w.family("whale_container_state", "gauge", "Current state of the container, 1 for the series of the current state.")
+	for _, container := range containers {
+		for _, state := range containerStates {
+			w.sample("whale_container_state", map[string]string{"name": container.Name, "state": state}, boolValue(container.State == state))
+		}
+	}
+
+	w.family("whale_container_restarts_total", "counter", "Restarts of the container by the daemon.")
+	for _, container := range containers {
+		w.sample("whale_container_restarts_total", name(container), float64(container.RestartCount))
+	}
+
+	w.family("whale_container_crash_looping", "gauge", "Whether the container is restarting over and over.")
+	for _, container := range containers {
+		w.sample("whale_container_crash_looping", name(container), boolValue(container.CrashLoop))
+	}
+
+	w.family("whale_container_exit_code", "gauge", "Exit code of the last run of stopped containers.")
+	for _, container := range containers {
+		if container.isExited() {
+			w.sample("whale_container_exit_code", name(container), float64(container.ExitCode))
+		}
+	}
+
+	w.family("whale_container_started_at_seconds", "gauge", "When the current run of the container started, as a Unix timestamp.")
+	for _, container := range containers {
+		if container.State == "running" && !container.StartedAt.IsZero() {
+			w.sample("whale_container_started_at_seconds", name(container), float64(container.StartedAt.Unix()))
+		}
+	}
+
+	running := map[string]containerStats{}
+	for _, container := range containers {
+		if stat, ok := stats[shortID(container.ID)]; ok && container.State == "running" {
+			running[container.Name] = stat
+		}
+	}
+	gauges := []struct {
+		name  string
+		kind  string
+		help  string
+		value func(containerStats) float64
+	}{
+		{"whale_container_cpu_percent", "gauge", "CPU usage, 100 for one full core.", func(s containerStats) float64 { return s.CPU }},
+		{"whale_container_memory_bytes", "gauge", "Memory used, the working set on cgroup v2.", func(s containerStats) float64 { return float64(s.Memory) }},
+		{"whale_container_memory_limit_bytes", "gauge", "Memory limit, the host memory when unlimited.", func(s containerStats) float64 { return float64(s.MemLimit) }},
+		{"whale_container_network_receive_bytes_total", "counter", "Bytes received on all interfaces.", func(s containerStats) float64 { return float64(s.NetRx) }},
+		{"whale_container_network_transmit_bytes_total", "counter", "Bytes sent on all interfaces.", func(s containerStats) float64 { return float64(s.NetTx) }},
+		{"whale_container_block_read_bytes_total", "counter", "Bytes read from block devices.", func(s containerStats) float64 { return float64(s.BlockRead) }},
+		{"whale_container_block_write_bytes_total", "counter", "Bytes written to block devices.", func(s containerStats) float64 { return float64(s.BlockWrite) }},
+		{"whale_container_pids", "gauge", "Processes and threads in the container.", func(s containerStats) float64 { return float64(s.PIDs) }},
+	}
+	for _, gauge := range gauges {
+		w.family(gauge.name, gauge.kind, gauge.help)
+		for _, container := range containers {
+			if stat, ok := running[container.Name]; ok {
+				w.sample(gauge.name, name(container), gauge.value(stat))
+			}
+		}
+	}
+
+	return w.b.String()
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
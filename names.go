@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// nameAdjectives and nameNouns make up generated container names, like the
+// daemon does, but from words that read well after a prefix.
+var nameAdjectives = []string{
+	"amber", "brave", "bright", "calm", "clever", "cosy", "crisp", "daring",
+	"eager", "fancy", "gentle", "happy", "jolly", "keen", "kind", "lively",
+	"lucky", "mellow", "merry", "nimble", "noble", "proud", "quick", "quiet",
+	"rapid", "shiny", "silent", "snowy", "steady", "sunny", "swift", "tidy",
+	"vivid", "warm", "wise", "witty", "young", "zesty",
+}
+
+var nameNouns = []string{
+	"badger", "beacon", "brook", "canyon", "cedar", "comet", "coral", "dune",
+	"falcon", "fern", "fjord", "glacier", "harbor", "heron", "island", "lagoon",
+	"lynx", "maple", "meadow", "nebula", "orca", "otter", "panda", "pebble",
+	"pine", "quartz", "raven", "reef", "river", "sparrow", "summit", "tundra",
+	"walrus", "willow", "yak", "zebra",
+}
+
+// containerNamePattern is what the daemon accepts as a container name.
+var containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// namePrefix is run.namePrefix with {image} replaced by the repository of
+// image and {project} by the name of the current directory.
+func namePrefix(cfg *Config, image string) string {
+	project := ""
+	if dir, err := os.Getwd(); err == nil {
+		project = filepath.Base(dir)
+	}
+	prefix := strings.NewReplacer("{image}", imageRepository(image), "{project}", project).Replace(cfg.Run.NamePrefix)
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, prefix)
+}
+
+// generateName returns a memorable name no container has yet, the prefix
+// followed by adjective_noun, with a number when all the tries were taken.
+func generateName(prefix string, taken map[string]bool) string {
+	for try := 0; ; try++ {
+		name := prefix + nameAdjectives[rand.Intn(len(nameAdjectives))] + "_" + nameNouns[rand.Intn(len(nameNouns))]
+		if try >= 20 {
+			name += fmt.Sprintf("_%d", try)
+		}
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// containerNames are the names in use, of every container including the
+// ignored ones, since the daemon refuses to reuse any of them.
+func containerNames() (map[string]bool, error) {
+	output, err := dockerRead("container", "ls", "-a", "--format", "{{.Names}}")
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, name := range strings.Fields(string(output)) {
+		names[name] = true
+	}
+	return names, nil
+}
+
+// checkContainerName is the message to show for a name the daemon would
+// refuse, "" when it's fine. An empty name lets the daemon pick one.
+func checkContainerName(name string, taken map[string]bool) string {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return ""
+	case !containerNamePattern.MatchString(name):
+		return tr("create.nameInvalid", name)
+	case taken[name]:
+		return tr("create.nameTaken", name)
+	}
+	return ""
+}
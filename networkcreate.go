@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// networkNamePattern is what the daemon accepts as a network name.
+var networkNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// createNetwork asks for the usual `docker network create` options, showing
+// the form again with the problem until they make sense.
+func createNetwork(cfg *Config) error {
+	fields := []formField{
+		{Label: tr("networkCreate.name")},
+		{Label: tr("networkCreate.driver"), Value: "bridge", Hint: tr("networkCreate.driverHint")},
+		{Label: tr("networkCreate.subnet"), Hint: tr("networkCreate.subnetHint")},
+		{Label: tr("networkCreate.gateway"), Hint: tr("networkCreate.gatewayHint")},
+		{Label: tr("networkCreate.internal"), Value: yesNo(false), Hint: tr("networkCreate.internalHint")},
+		{Label: tr("networkCreate.attachable"), Value: yesNo(false), Hint: tr("networkCreate.attachableHint")},
+	}
+
+	title := tr("networkCreate.title")
+	for {
+		filled, ok, err := fillForm(cfg, title, fields)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errCancelled
+		}
+		fields = filled
+
+		args, problem := networkCreateArgs(fields)
+		if problem == "" {
+			if !confirmSubnet(strings.TrimSpace(fields[2].Value)) {
+				return errCancelled
+			}
+			return runDocker(args...)
+		}
+		title = tr("networkCreate.title") + "\n" + renderColor("✗ "+problem, "31")
+	}
+}
+
+// confirmSubnet warns about the networks and host routes, VPNs often, that
+// the subnet overlaps and asks whether to create the network anyway. The
+// daemon refuses overlapping docker networks itself, but not routes.
+func confirmSubnet(subnet string) bool {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return true
+	}
+	networks, err := getNetworks()
+	if err != nil {
+		return true
+	}
+	subnets, err := networkSubnets(networks)
+	if err != nil {
+		return true
+	}
+
+	conflicts := subnetConflicts(ipNet, "", networks, subnets, hostRoutes())
+	if len(conflicts) == 0 {
+		return true
+	}
+	for _, conflict := range conflicts {
+		fmt.Println(renderColor(tr("ipam.createOverlap", subnet, conflict), "33"))
+	}
+	fmt.Print(tr("ipam.createPrompt"))
+	answer, _ := plainInput.ReadString('\n')
+	return isYes(answer)
+}
+
+// networkCreateArgs checks the form and turns it into docker arguments, or
+// explains what is wrong with it.
+func networkCreateArgs(fields []formField) ([]string, string) {
+	name := strings.TrimSpace(fields[0].Value)
+	driver := strings.TrimSpace(fields[1].Value)
+	subnet := strings.TrimSpace(fields[2].Value)
+	gateway := strings.TrimSpace(fields[3].Value)
+
+	if !networkNamePattern.MatchString(name) {
+		return nil, tr("networkCreate.invalidName", name)
+	}
+	if driver == "" {
+		driver = "bridge"
+	}
+	args := []string{"network", "create", "--driver", driver}
+
+	var ipNet *net.IPNet
+	if subnet != "" {
+		ip, parsed, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, tr("networkCreate.invalidSubnet", subnet)
+		}
+		// The daemon refuses host bits, say which prefix was meant.
+		if !ip.Equal(parsed.IP) {
+			return nil, tr("networkCreate.subnetHostBits", subnet, parsed.String())
+		}
+		ipNet = parsed
+		args = append(args, "--subnet", subnet)
+	}
+
+	if gateway != "" {
+		ip := net.ParseIP(gateway)
+		switch {
+		case ip == nil:
+			return nil, tr("networkCreate.invalidGateway", gateway)
+		case ipNet == nil:
+			return nil, tr("networkCreate.gatewayWithoutSubnet")
+		case !ipNet.Contains(ip):
+			return nil, tr("networkCreate.gatewayOutside", gateway, subnet)
+		}
+		args = append(args, "--gateway", gateway)
+	}
+
+	if isYes(fields[4].Value) {
+		args = append(args, "--internal")
+	}
+	if isYes(fields[5].Value) {
+		if driver != "overlay" {
+			return nil, tr("networkCreate.attachableOverlay")
+		}
+		args = append(args, "--attachable")
+	}
+
+	return append(args, name), ""
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+type Network struct {
+	ID     string
+	Name   string
+	Driver string
+	Scope  string
+	Labels map[string]string
+}
+
+// networkLine mirrors one line of `docker network ls --format '{{json .}}'`.
+type networkLine struct {
+	ID     string `json:"ID"`
+	Name   string `json:"Name"`
+	Driver string `json:"Driver"`
+	Scope  string `json:"Scope"`
+	Labels string `json:"Labels"`
+}
+
+// builtinNetworks are created by the daemon and can't be removed.
+var builtinNetworks = []string{"bridge", "host", "none"}
+
+func getNetworks() ([]Network, error) {
+	output, err := dockerRead("network", "ls", "--no-trunc", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []Network
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var n networkLine
+		err := json.Unmarshal([]byte(line), &n)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing network: %v", err)
+		}
+
+		networks = append(networks, Network{ID: n.ID, Name: n.Name, Driver: n.Driver, Scope: n.Scope, Labels: whale.ParseLabels(n.Labels)})
+	}
+
+	markFetched()
+	return networks, nil
+}
+
+// networksTab is the networks tab of the dashboard.
+func networksTab(notice string) (string, string) {
+	cfg := currentConfig()
+	networks, err := getNetworks()
+	if err != nil {
+		return "containers", fmt.Sprintf("✗ %s: %v", tr("tabs.networks"), err)
+	}
+
+	subnets, err := networkSubnets(networks)
+	if err != nil {
+		return "containers", fmt.Sprintf("✗ %s: %v", tr("tabs.networks"), err)
+	}
+	routes := hostRoutes()
+
+	title := tr("networks.title")
+	table := [][]string{{tr("column.name"), tr("column.id"), tr("column.driver"), tr("column.scope"), tr("column.subnet")}}
+	for _, network := range networks {
+		var list []string
+		for _, subnet := range subnets[network.ID] {
+			conflicts := subnetConflicts(subnet, network.ID, networks, subnets, routes)
+			if len(conflicts) == 0 {
+				list = append(list, subnet.String())
+				continue
+			}
+			list = append(list, subnet.String()+" ⚠")
+			title += "\n" + renderColor(tr("ipam.overlap", network.Name, subnet, strings.Join(conflicts, ", ")), "33")
+		}
+		table = append(table, []string{network.Name, shortID(network.ID), network.Driver, network.Scope, strings.Join(list, ", ")})
+	}
+	rows := alignColumns(table)
+	keys := make([]string, len(networks))
+	for i, network := range networks {
+		keys[i] = network.ID
+	}
+
+	choice, next, err := chooseInTab(cfg, "networks", title, rows[0], rows[1:], keys, notice)
+	if err != nil {
+		println(tr("error.chooseNetwork"), err)
+		os.Exit(1)
+	}
+	if next != "" || choice < 0 {
+		return next, ""
+	}
+
+	network := networks[choice]
+	actions := []string{"exit", "inspectNetwork", "createNetwork"}
+	if !containsString(builtinNetworks, network.Name) {
+		actions = append(actions, "removeNetwork")
+	}
+	actions = append(actions, "pruneNetworks")
+
+	action, err := chooseResourceAction(cfg, tr("networks.actionsTitle", network.Name), actions)
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+
+	name := network.Name
+	if action == "pruneNetworks" || action == "createNetwork" {
+		name = tr("tabs.networks")
+	}
+	return tabOutcome("networks", name, action, func() error {
+		return doNetworkAction(cfg, action, network)
+	})
+}
+
+func doNetworkAction(cfg *Config, action string, network Network) error {
+	switch action {
+	case "inspectNetwork":
+		return runStream(cfg, tr("networks.inspectTitle", network.Name), exec.Command("docker", "network", "inspect", network.ID))
+	case "createNetwork":
+		return createNetwork(cfg)
+	case "removeNetwork":
+		if !confirmRemoval(network.Name) {
+			return errCancelled
+		}
+		return runDocker("network", "rm", network.ID)
+	case "pruneNetworks":
+		return previewAndPrune(cfg, []string{"networks"}, false)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// notes are free-text annotations users attach to containers, e.g. "holds
+// the staging DB dump". They are keyed by container name, which survives
+// recreating the container, with the ID as a fallback for unnamed ones.
+var notes map[string]string
+
+func notesPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "whale", "notes.json"), nil
+}
+
+// loadNotes reads the notes file once; a missing file means no notes.
+func loadNotes() map[string]string {
+	if notes != nil {
+		return notes
+	}
+
+	notes = map[string]string{}
+	path, err := notesPath()
+	if err != nil {
+		return notes
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return notes
+	}
+	if json.Unmarshal(data, &notes) != nil {
+		warn(tr("notes.invalid", path))
+	}
+	return notes
+}
+
+func saveNotes() error {
+	path, err := notesPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func noteFor(container Container) string {
+	all := loadNotes()
+	if note, ok := all[container.Name]; ok {
+		return note
+	}
+	return all[container.ID]
+}
+
+// setNote stores or, when empty, deletes the note of a container.
+func setNote(container Container, note string) error {
+	all := loadNotes()
+	key := container.Name
+	if key == "" {
+		key = container.ID
+	}
+
+	delete(all, container.ID)
+	if note = strings.TrimSpace(note); note == "" {
+		delete(all, key)
+	} else {
+		all[key] = note
+	}
+	return saveNotes()
+}
+
+func editNote(cfg *Config, container Container) error {
+	fields, ok, err := fillForm(cfg, tr("notes.title", container.Name), []formField{
+		{Label: tr("notes.note"), Value: noteFor(container), Hint: tr("notes.hint")},
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	err = setNote(container, fields[0].Value)
+	if err != nil {
+		return fmt.Errorf("error saving note: %v", err)
+	}
+	fmt.Println(tr("notes.saved", container.Name))
+	return nil
+}
+
+// noteCommand implements `whale note <name> [text...]`, printing the note
+// or replacing it; `whale note <name> ""` deletes it.
+func noteCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	if len(args) == 0 {
+		println(tr("notes.usage"))
+		os.Exit(1)
+	}
+
+	container, err := resolveContainer(cfg, containers, args[0])
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+
+	if len(args) == 1 {
+		if note := noteFor(container); note != "" {
+			fmt.Println(note)
+		}
+		return
+	}
+
+	err = setNote(container, strings.Join(args[1:], " "))
+	if err != nil {
+		println(tr("error.saveNote"), err)
+		os.Exit(1)
+	}
+}
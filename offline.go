@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reconnectInterval is how often the list pings a daemon that went away.
+const reconnectInterval = 5 * time.Second
+
+// offline is set while the daemon can't be reached: the list then shows the
+// last known containers, marked stale, and refuses changes until it's back.
+var offline bool
+
+// cachedAt is when the containers shown offline at startup were fetched.
+var cachedAt time.Time
+
+// containerCache is the last list fetched from a host, kept for offline use.
+type containerCache struct {
+	SavedAt    time.Time   `json:"savedAt"`
+	Containers []Container `json:"containers"`
+}
+
+// containerCachePath names the cache of the active host after a hash of its
+// full address, so that hosts sharing a last path element don't share a
+// cache and the name stays valid on every file system.
+func containerCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	host := activeHost
+	if host == "" {
+		host = "default"
+	}
+	sum := sha256.Sum256([]byte(host))
+	return filepath.Join(dir, "whale", "containers-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// saveContainerCache writes the list for offline use, readable by the user
+// only since it holds the containers' labels and commands.
+func saveContainerCache(containers []Container) error {
+	path, err := containerCachePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(containerCache{SavedAt: time.Now(), Containers: containers})
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// cacheContainers saves the list, where failing to only costs the offline
+// list and is warned about.
+func cacheContainers(containers []Container) {
+	if err := saveContainerCache(containers); err != nil {
+		warn(tr("offline.cacheFailed", err))
+	}
+}
+
+func loadContainerCache() (containerCache, bool) {
+	var cache containerCache
+	path, err := containerCachePath()
+	if err != nil {
+		return cache, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &cache) != nil {
+		return cache, false
+	}
+	return cache, true
+}
+
+type daemonLostMsg struct{}
+
+type reconnectTickMsg struct{}
+
+type reconnectFailedMsg struct{}
+
+// reloadMsg carries a fresh list once the daemon answers again.
+type reloadMsg []Container
+
+func scheduleReconnect() tea.Cmd {
+	return tea.Tick(reconnectInterval, func(time.Time) tea.Msg {
+		return reconnectTickMsg{}
+	})
+}
+
+// reconnect pings the daemon and reloads the list when it answers, or
+// schedules another try.
+func reconnect(cfg *Config) tea.Cmd {
+	return func() tea.Msg {
+		if pingDaemon() != nil {
+			return reconnectFailedMsg{}
+		}
+		containers, err := getContainers(cfg)
+		if err != nil {
+			return reconnectFailedMsg{}
+		}
+		cacheContainers(containers)
+		return reloadMsg(containers)
+	}
+}
+
+// checkDaemon turns a failed request into daemonLostMsg when the daemon is
+// the reason.
+func checkDaemon() tea.Cmd {
+	return func() tea.Msg {
+		if pingDaemon() != nil {
+			return daemonLostMsg{}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// packPrefix marks the actions coming from an action pack, so they never
+// clash with the built-in ones: "pack:dumpThreads".
+const packPrefix = "pack:"
+
+// packFile is what a pack repository holds when the source doesn't name a
+// file after #.
+const packFile = "whale-pack.json"
+
+// actionPack is a set of actions a team shares, loaded from packs.sources.
+type actionPack struct {
+	Name    string       `json:"name"`
+	Actions []PackAction `json:"actions"`
+}
+
+// PackAction runs a command in the container with docker exec, e.g.
+// {"id": "dumpThreads", "label": "Dump JVM threads", "exec": ["jcmd", "1",
+// "Thread.print"], "images": ["temurin"]}.
+type PackAction struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Exec  []string `json:"exec"`
+
+	// Images limits the action to containers whose image contains one of
+	// these, every container when empty.
+	Images []string `json:"images"`
+
+	// Interactive hands the terminal over to the command, for REPLs and
+	// prompts, instead of showing its output.
+	Interactive bool `json:"interactive"`
+
+	// Mutating actions are refused in read-only mode, like the built-in
+	// ones changing state.
+	Mutating bool `json:"mutating"`
+}
+
+// packSet holds the actions of every pack of a config by prefixed ID,
+// loaded on first use.
+type packSet struct {
+	once    sync.Once
+	actions map[string]PackAction
+}
+
+func loadPacks(cfg *Config) map[string]PackAction {
+	if cfg.packs == nil {
+		return nil
+	}
+	cfg.packs.once.Do(func() {
+		actions := map[string]PackAction{}
+		for _, source := range cfg.Packs.Sources {
+			pack, err := readPack(cfg, source, false)
+			if err != nil {
+				warn(tr("packs.unavailable", source, err))
+				continue
+			}
+
+			for _, action := range pack.Actions {
+				if action.ID == "" || len(action.Exec) == 0 {
+					continue
+				}
+				actions[packPrefix+action.ID] = action
+			}
+		}
+		cfg.packs.actions = actions
+	})
+	return cfg.packs.actions
+}
+
+func isPackAction(action string) bool {
+	return strings.HasPrefix(action, packPrefix)
+}
+
+// containerPackActions returns the pack actions offered for the container,
+// sorted by label.
+func containerPackActions(cfg *Config, container Container) []string {
+	var ids []string
+	for id, action := range loadPacks(cfg) {
+		if len(action.Images) == 0 || containsAny(container.Image, action.Images) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return packActionLabel(cfg, ids[i]) < packActionLabel(cfg, ids[j])
+	})
+	return ids
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func packActionLabel(cfg *Config, id string) string {
+	action := loadPacks(cfg)[id]
+	if action.Label == "" {
+		return action.ID
+	}
+	return action.Label
+}
+
+// runPackAction runs the command of the action in the container, in the log
+// viewer or, when interactive, with the terminal attached.
+func runPackAction(cfg *Config, id string, container Container) error {
+	action, ok := loadPacks(cfg)[id]
+	if !ok {
+		return fmt.Errorf("unknown pack action %s", id)
+	}
+
+	if !action.Interactive {
+		cmd := exec.Command("docker", append([]string{"exec", container.ID}, action.Exec...)...)
+		return runStream(cfg, fmt.Sprintf("%s: %s", container.Name, packActionLabel(cfg, id)), cmd)
+	}
+
+	cmd := exec.Command("docker", append([]string{"exec", "-it", container.ID}, action.Exec...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// readPack returns the pack of a source: an http(s) URL, a git repository
+// ("git+https://host/team/repo.git#path/pack.json", or any URL ending in
+// .git) or a local file. Remote packs are cached and only fetched again
+// after packs.refreshHours, or when update is set; the cached copy is used
+// when fetching fails.
+func readPack(cfg *Config, source string, update bool) (actionPack, error) {
+	path, err := packCachePath(source)
+	if err != nil {
+		return actionPack{}, err
+	}
+
+	switch {
+	case isGitSource(source):
+		err = fetchGitPack(cfg, source, path, update)
+	case strings.HasPrefix(source, "https://"), strings.HasPrefix(source, "http://"):
+		err = fetchURLPack(cfg, source, path, update)
+	default:
+		path = source
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		if err != nil {
+			return actionPack{}, err
+		}
+		return actionPack{}, readErr
+	}
+	if err != nil {
+		warn(tr("packs.stale", source, err))
+	}
+
+	var pack actionPack
+	err = json.Unmarshal(data, &pack)
+	if err != nil {
+		return actionPack{}, fmt.Errorf("error parsing pack %s: %v", source, err)
+	}
+	return pack, nil
+}
+
+func isGitSource(source string) bool {
+	repository, _, _ := strings.Cut(source, "#")
+	return strings.HasPrefix(source, "git+") || strings.HasSuffix(repository, ".git")
+}
+
+// packCachePath is where the pack of a remote source is kept: the pack file
+// itself for URLs, the file inside the clone for git repositories.
+func packCachePath(source string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	repository, file, _ := strings.Cut(source, "#")
+	sum := sha256.Sum256([]byte(repository))
+	key := hex.EncodeToString(sum[:])[:16]
+	if !isGitSource(source) {
+		return filepath.Join(dir, "whale", "packs", key+".json"), nil
+	}
+	if file == "" {
+		file = packFile
+	}
+	return filepath.Join(dir, "whale", "packs", key, filepath.FromSlash(file)), nil
+}
+
+// packIsFresh reports cached copies younger than packs.refreshHours.
+func packIsFresh(cfg *Config, path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) < time.Duration(max(cfg.Packs.RefreshHours, 1))*time.Hour
+}
+
+func fetchURLPack(cfg *Config, source string, path string, update bool) error {
+	if !update && packIsFresh(cfg, path) {
+		return nil
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(source)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", source, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// fetchedMarker is touched in the clone after each fetch, to know when the
+// next one is due.
+const fetchedMarker = "whale-fetched"
+
+// fetchGitPack clones the repository once, shallow, and pulls it when the
+// copy is due for a refresh.
+func fetchGitPack(cfg *Config, source string, path string, update bool) error {
+	repository, file, _ := strings.Cut(strings.TrimPrefix(source, "git+"), "#")
+	if file == "" {
+		file = packFile
+	}
+	clone := strings.TrimSuffix(path, filepath.FromSlash(file))
+	clone = filepath.Clean(clone)
+
+	var cmd *exec.Cmd
+	if _, err := os.Stat(filepath.Join(clone, ".git")); err != nil {
+		err = os.MkdirAll(filepath.Dir(clone), 0o755)
+		if err != nil {
+			return err
+		}
+		cmd = exec.Command("git", "clone", "--quiet", "--depth", "1", repository, clone)
+	} else if update || !packIsFresh(cfg, filepath.Join(clone, ".git", fetchedMarker)) {
+		cmd = exec.Command("git", "-C", clone, "pull", "--quiet", "--ff-only")
+	} else {
+		return nil
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return os.WriteFile(filepath.Join(clone, ".git", fetchedMarker), nil, 0o644)
+}
+
+// packsCommand implements `whale packs [--update]`: lists the actions of
+// each source, fetching them again with --update.
+func packsCommand(args []string) {
+	cfg := currentConfig()
+	update := len(args) > 0 && args[0] == "--update"
+	sources := cfg.Packs.Sources
+
+	if len(sources) == 0 {
+		fmt.Println(tr("packs.none"))
+		return
+	}
+
+	for _, source := range sources {
+		pack, err := readPack(cfg, source, update)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", source, err)
+			continue
+		}
+
+		fmt.Printf("✓ %s: %s\n", source, tr("packs.summary", orNone(pack.Name), len(pack.Actions)))
+		for _, action := range pack.Actions {
+			label := action.Label
+			if label == "" {
+				label = action.ID
+			}
+			fmt.Printf("    %-24s %s\n", packPrefix+action.ID, label)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// palettes replace the red, green and yellow whale shows errors, successes
+// and warnings in with colors told apart by people who don't see red and
+// green apart, after the Okabe-Ito palette: vermillion or orange, blue and
+// yellow. The 256-color codes work in more terminals than truecolor.
+var palettes = map[string]map[string]string{
+	"deuteranopia": {"31": "38;5;166", "32": "38;5;32", "33": "38;5;220"},
+	"protanopia":   {"31": "38;5;172", "32": "38;5;74", "33": "38;5;227"},
+}
+
+func (cfg *Config) checkPalette() error {
+	if cfg.Ui.Palette == "" || cfg.Ui.Palette == "default" {
+		return nil
+	}
+	if _, ok := palettes[cfg.Ui.Palette]; !ok {
+		names := []string{"default"}
+		for name := range palettes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown palette %q, expected one of %s", cfg.Ui.Palette, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// palette is the palette of the config, nil for the default one.
+var palette map[string]string
+
+// loadPalette picks the palette the colors are shown with from the config.
+func loadPalette(cfg *Config) {
+	palette = palettes[cfg.Ui.Palette]
+}
+
+// paletteColor is the color to show for color with the palette, color
+// itself unless the palette replaces it.
+func paletteColor(color string) string {
+	if replacement, ok := palette[color]; ok {
+		return replacement
+	}
+	return color
+}
+
+// statusMarker leads the rows of the list colored for their state, so that
+// a crash loop or a failed exit doesn't only show as a color.
+func statusMarker(container Container) string {
+	glyphs := supportsGlyphs() && !plainMode
+	switch {
+	case container.CrashLoop && glyphs:
+		return "↻"
+	case container.CrashLoop:
+		return "~"
+	case container.failed() && glyphs:
+		return "✗"
+	case container.failed():
+		return "!"
+	}
+	return " "
+}
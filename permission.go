@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"runtime"
+	"strings"
+)
+
+// isPermissionError recognizes the CLI failing to open the daemon socket
+// because the user isn't allowed to, rather than the daemon being down.
+func isPermissionError(err error) bool {
+	message := strings.ToLower(errorSummary(err))
+	return strings.Contains(message, "permission denied") && strings.Contains(message, "socket")
+}
+
+// printSocketPermissionHelp explains the ways to get access to the socket on
+// Linux, where the rootful daemon's socket belongs to the docker group.
+func printSocketPermissionHelp(err error) {
+	fmt.Println(renderColor(errorSummary(err), "31"))
+	fmt.Println()
+
+	name := "$USER"
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+
+	if inDockerGroup, active := dockerGroupMembership(); inDockerGroup && !active {
+		fmt.Println(tr("permission.staleSession", name))
+		fmt.Println("  newgrp docker")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println(tr("permission.intro", name))
+	fmt.Println()
+	fmt.Println(tr("permission.group"))
+	fmt.Printf("     sudo usermod -aG docker %s\n", name)
+	fmt.Println(tr("permission.groupRelog"))
+	fmt.Println(renderColor("     "+tr("permission.groupWarning"), "2"))
+	fmt.Println()
+	fmt.Println(tr("permission.rootless"))
+	fmt.Println("     https://docs.docker.com/engine/security/rootless/")
+	fmt.Println("     export DOCKER_HOST=unix://$XDG_RUNTIME_DIR/docker.sock")
+}
+
+// dockerGroupMembership reports whether the user is listed in the docker
+// group, and whether this session already has it: after `usermod -aG`,
+// the group only applies to new login sessions.
+func dockerGroupMembership() (bool, bool) {
+	if runtime.GOOS != "linux" {
+		return false, false
+	}
+
+	group, err := user.LookupGroup("docker")
+	if err != nil {
+		return false, false
+	}
+
+	current, err := user.Current()
+	if err != nil {
+		return false, false
+	}
+
+	groupIDs, err := current.GroupIds()
+	if err != nil {
+		return false, false
+	}
+
+	member := false
+	for _, id := range groupIDs {
+		if id == group.Gid {
+			member = true
+		}
+	}
+
+	active := false
+	gids, err := os.Getgroups()
+	if err == nil {
+		for _, gid := range gids {
+			if fmt.Sprint(gid) == group.Gid {
+				active = true
+			}
+		}
+	}
+
+	return member, active
+}
@@ -0,0 +1,129 @@
+package main
+
+import "os"
+
+// readOnly is the --read-only flag, merged into the config with the readOnly
+// setting of the active host profile.
+var readOnly bool
+
+// mutatingActions are the actions and CLI verbs refused in read-only mode,
+// with the tools running docker exec in the container, which could change
+// anything in it.
+var mutatingActions = map[string]bool{
+	"createContainer":     true,
+	"runTask":             true,
+	"forwardPort":         true,
+	"addHostsEntry":       true,
+	"removeHostsEntry":    true,
+	"devShell":            true,
+	"shell":               true,
+	"browseFiles":         true,
+	"debugDns":            true,
+	"testConnectivity":    true,
+	"runHealthcheck":      true,
+	"checkClock":          true,
+	"checkEnvironment":    true,
+	"showTraffic":         true,
+	"editLabels":          true,
+	"editCommand":         true,
+	"rescue":              true,
+	"endRescue":           true,
+	"recreate":            true,
+	"composeWatch":        true,
+	"composeUp":           true,
+	"composeReconcile":    true,
+	"composeBuild":        true,
+	"composeSuspend":      true,
+	"composeResume":       true,
+	"start":               true,
+	"stop":                true,
+	"restart":             true,
+	"pause":               true,
+	"unpause":             true,
+	"kill":                true,
+	"rm":                  true,
+	"prune":               true,
+	"cleanup":             true,
+	"gc":                  true,
+	"tags":                true,
+	"logout":              true,
+	"pullImage":           true,
+	"tagImage":            true,
+	"removeImage":         true,
+	"restoreVolume":       true,
+	"backupVolume":        true,
+	"browseVolume":        true,
+	"removeOrphanVolumes": true,
+	"removeVolume":        true,
+	"pruneVolumes":        true,
+	"createNetwork":       true,
+	"removeNetwork":       true,
+	"pruneNetworks":       true,
+}
+
+// ActionRules restricts which actions can be used. When Enabled is set, only
+// the listed actions are allowed; Disabled actions are always refused.
+type ActionRules struct {
+	Enabled  []string `json:"enabled"`
+	Disabled []string `json:"disabled"`
+}
+
+func (rules ActionRules) allows(action string) bool {
+	for _, disabled := range rules.Disabled {
+		if canonicalAction(disabled) == action {
+			return false
+		}
+	}
+
+	if len(rules.Enabled) == 0 {
+		return true
+	}
+	for _, enabled := range rules.Enabled {
+		if canonicalAction(enabled) == action {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalAction folds CLI aliases onto a single action name, so a rule on
+// "rm" also covers `whale remove`.
+func canonicalAction(action string) string {
+	if command, ok := lifecycleCommands[action]; ok {
+		return command
+	}
+	return action
+}
+
+// isActionAllowed applies read-only mode, then the global rules, then the
+// rules of the active host profile. Leaving a menu is always allowed.
+func isActionAllowed(cfg *Config, action string) bool {
+	action = canonicalAction(action)
+	if action == "exit" {
+		return true
+	}
+	mutating := mutatingActions[action] || isPackAction(action) && loadPacks(cfg)[action].Mutating
+	if (cfg.readOnly || offline) && mutating {
+		return false
+	}
+	return cfg.Actions.allows(action) && cfg.host.Actions.allows(action)
+}
+
+// allowedActions filters a menu down to the actions currently permitted.
+func allowedActions(cfg *Config, actions []string) []string {
+	var allowed []string
+	for _, action := range actions {
+		if isActionAllowed(cfg, action) {
+			allowed = append(allowed, action)
+		}
+	}
+	return allowed
+}
+
+// requireAction stops a CLI subcommand that the rules forbid.
+func requireAction(cfg *Config, action string) {
+	if !isActionAllowed(cfg, action) {
+		println(tr("permissions.denied", action))
+		os.Exit(1)
+	}
+}
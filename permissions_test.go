@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+// TestExecActionsReadOnly checks that the actions running docker exec in a
+// container are refused in read-only and offline mode.
+func TestExecActionsReadOnly(t *testing.T) {
+	execActions := []string{"shell", "devShell", "browseFiles", "debugDns", "testConnectivity", "runHealthcheck", "checkClock", "checkEnvironment", "showTraffic"}
+	defer func(previous bool) { offline = previous }(offline)
+
+	for _, mode := range []struct {
+		name              string
+		readOnly, offline bool
+	}{{"read-only", true, false}, {"offline", false, true}, {"normal", false, false}} {
+		cfg := defaultConfig()
+		cfg.readOnly, offline = mode.readOnly, mode.offline
+		for _, action := range execActions {
+			if allowed := isActionAllowed(cfg, action); allowed != (mode.name == "normal") {
+				t.Errorf("%s allowed %v in %s mode", action, allowed, mode.name)
+			}
+		}
+	}
+}
+
+// TestPackActionsReadOnly checks that the mutating actions of the packs of
+// a config are refused when it is read-only, and the others allowed.
+func TestPackActionsReadOnly(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.readOnly = true
+	cfg.packs.once.Do(func() {
+		cfg.packs.actions = map[string]PackAction{
+			"pack:flushCache":  {ID: "flushCache", Exec: []string{"redis-cli", "flushall"}, Mutating: true},
+			"pack:dumpThreads": {ID: "dumpThreads", Exec: []string{"jcmd", "1", "Thread.print"}},
+		}
+	})
+
+	if isActionAllowed(cfg, "pack:flushCache") {
+		t.Error("the mutating pack action allowed in read-only mode")
+	}
+	if !isActionAllowed(cfg, "pack:dumpThreads") {
+		t.Error("the read-only pack action refused in read-only mode")
+	}
+}
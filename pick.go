@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exitCancelled is what `whale pick` exits with when nothing was picked,
+// the code fzf uses too.
+const exitCancelled = 130
+
+// pickFields are the values `whale pick --field` can print.
+var pickFields = map[string]func(Container) string{
+	"id":      func(container Container) string { return container.ID },
+	"shortId": func(container Container) string { return shortID(container.ID) },
+	"name":    func(container Container) string { return container.Name },
+	"image":   func(container Container) string { return container.Image },
+	"status":  func(container Container) string { return container.Status },
+	"ports":   func(container Container) string { return container.Ports },
+}
+
+// pickCommand implements `whale pick [--field F] [--stdin] [query]`: it
+// only shows the container picker and prints a field of the chosen
+// container, so whale can be used as a selector, e.g.
+// `docker restart $(whale pick)`. With --stdin, the picker only offers the
+// containers listed on stdin.
+func pickCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	field := "id"
+	fromStdin := false
+	var query string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--stdin":
+			fromStdin = true
+		case "--field", "-f":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			if _, ok := pickFields[args[i]]; !ok {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			field = args[i]
+		default:
+			query = args[i]
+		}
+	}
+
+	var options []tea.ProgramOption
+	if fromStdin {
+		containers = readPickList(cfg, os.Stdin, containers)
+
+		// stdin is the list, so keys come from the terminal itself.
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			println(tr("pick.noTerminal"), err)
+			os.Exit(1)
+		}
+		defer tty.Close()
+		plainInput = bufio.NewReader(tty)
+		options = append(options, tea.WithInput(tty))
+	}
+
+	if query != "" {
+		containers = findContainers(containers, query)
+	}
+	if len(containers) == 0 {
+		os.Exit(1)
+	}
+
+	container, err := pickContainer(cfg, containers, options...)
+	if err != nil {
+		println(tr("error.chooseContainer"), err)
+		os.Exit(1)
+	}
+	if container.ID == "" {
+		os.Exit(exitCancelled)
+	}
+
+	fmt.Println(pickFields[field](container))
+}
+
+// readPickList keeps the containers designated by the lines of r, in the
+// formats other tools produce: `docker ps` tables, `--format '{{json .}}'`
+// lines, or bare IDs and names as printed by `docker ps -q` or `whale
+// list`. JSON lines for containers the daemon doesn't know about are kept
+// as they are.
+func readPickList(cfg *Config, r io.Reader, containers []Container) []Container {
+	var picked []Container
+	seen := map[string]bool{}
+	add := func(container Container) {
+		if !seen[container.ID] {
+			seen[container.ID] = true
+			picked = append(picked, container)
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "CONTAINER ID") {
+			continue
+		}
+
+		var tokens []string
+		var parsed Container
+		if strings.HasPrefix(line, "{") {
+			var err error
+			parsed, err = convertJSONToContainer(cfg, line)
+			if err != nil {
+				continue
+			}
+			tokens = []string{parsed.ID, parsed.Name}
+		} else {
+			// Table rows start with the ID and end with the names.
+			fields := strings.Fields(line)
+			tokens = []string{fields[0], fields[len(fields)-1]}
+		}
+
+		found := false
+		for _, container := range containers {
+			for _, token := range tokens {
+				if token != "" && (container.Name == token || strings.HasPrefix(container.ID, token)) {
+					add(container)
+					found = true
+					break
+				}
+			}
+		}
+		if !found && parsed.ID != "" {
+			add(parsed)
+		}
+	}
+
+	return picked
+}
+
+// pickContainer shows the picker on stderr, leaving stdout to the result
+// since it is usually captured by $(...).
+func pickContainer(cfg *Config, containers []Container, options ...tea.ProgramOption) (Container, error) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+
+	if plainMode {
+		return chooseContainerPlain(cfg, containers)
+	}
+
+	menu := initialContainerModel(cfg, containers)
+	menu.picking = true
+	finalModel, err := runProgram(menu, append(options, tea.WithOutput(os.Stderr))...)
+	if err != nil {
+		return Container{}, err
+	}
+	return finalModel.(containerChoice).selectedContainer, nil
+}
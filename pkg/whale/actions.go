@@ -0,0 +1,57 @@
+package whale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Action is a lifecycle action on containers.
+type Action string
+
+const (
+	Start   Action = "start"
+	Stop    Action = "stop"
+	Restart Action = "restart"
+	Pause   Action = "pause"
+	Unpause Action = "unpause"
+	Kill    Action = "kill"
+	Remove  Action = "rm"
+)
+
+// Actions are the actions Action runs, by the name the whale CLI gives
+// them, "remove" standing for rm too.
+var Actions = map[string]Action{
+	"start":   Start,
+	"stop":    Stop,
+	"restart": Restart,
+	"pause":   Pause,
+	"unpause": Unpause,
+	"kill":    Kill,
+	"rm":      Remove,
+	"remove":  Remove,
+}
+
+// ErrUnknownAction is returned by Action for an action it doesn't run.
+var ErrUnknownAction = errors.New("unknown action")
+
+// Action runs action on each container of ids, names or IDs, one at a time
+// in the order given. It goes on after a container failed and returns the
+// errors of all the ones that did.
+func (client *Client) Action(ctx context.Context, action Action, ids ...string) error {
+	known := false
+	for _, other := range Actions {
+		known = known || other == action
+	}
+	if !known {
+		return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if _, err := client.run(ctx, string(action), id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package whale
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAction(t *testing.T) {
+	client, calls := fakeClient(t, "")
+
+	err := client.Action(context.Background(), Restart, "web", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"restart web", "restart cache"}; !reflect.DeepEqual(calls(), want) {
+		t.Errorf("ran %q, want %q", calls(), want)
+	}
+}
+
+func TestActionGoesOnAfterFailure(t *testing.T) {
+	client, calls := fakeClient(t, "")
+
+	err := client.Action(context.Background(), Stop, "web", "missing", "cache")
+	if err == nil || !strings.Contains(err.Error(), "missing: docker stop: Error response from daemon: No such container: missing") {
+		t.Errorf("got error %v, want the failure of missing", err)
+	}
+	if want := []string{"stop web", "stop missing", "stop cache"}; !reflect.DeepEqual(calls(), want) {
+		t.Errorf("ran %q, want %q", calls(), want)
+	}
+}
+
+func TestActionUnknown(t *testing.T) {
+	client, calls := fakeClient(t, "")
+
+	err := client.Action(context.Background(), Action("exec"), "web")
+	if !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("got error %v, want ErrUnknownAction", err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Errorf("ran %q, want nothing", got)
+	}
+}
+
+func TestActionExec(t *testing.T) {
+	client, _ := fakeClient(t, "")
+	var ran []string
+	client.Exec = func(cmd *exec.Cmd) error {
+		ran = append(ran, strings.Join(cmd.Args[1:], " "))
+		return cmd.Run()
+	}
+
+	err := client.Action(context.Background(), Remove, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"rm web"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("Exec ran %q, want %q", ran, want)
+	}
+}
@@ -0,0 +1,88 @@
+package whale
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Client runs docker commands against one engine.
+type Client struct {
+	// Host is the engine of the commands: the name of a docker context, an
+	// address like ssh://server or unix:///var/run/docker.sock, or "" for
+	// the one the environment and the CLI config select.
+	Host string
+
+	// Binary is the docker CLI to run, "docker" when empty. Podman and
+	// the other compatible CLIs work too.
+	Binary string
+
+	// Exec runs the commands of List, Action and Logs without --follow,
+	// cmd.Run when nil. A program sets it to put them under its own
+	// timeouts or progress display; it must run cmd to completion.
+	Exec func(cmd *exec.Cmd) error
+
+	// TimeLayouts are tried before the CLI's own layout to parse the
+	// creation times, for compatible CLIs printing them differently.
+	TimeLayouts []string
+}
+
+// New returns a Client for host, see Client.Host.
+func New(host string) *Client {
+	return &Client{Host: host}
+}
+
+// command builds the docker command for args. A Host given explicitly
+// wins over DOCKER_HOST and DOCKER_CONTEXT, which are left out then.
+func (client *Client) command(ctx context.Context, args ...string) *exec.Cmd {
+	binary := client.Binary
+	if binary == "" {
+		binary = "docker"
+	}
+	if client.Host == "" {
+		return exec.CommandContext(ctx, binary, args...)
+	}
+
+	target := []string{"--context", client.Host}
+	if strings.Contains(client.Host, "://") {
+		target = []string{"--host", client.Host}
+	}
+	cmd := exec.CommandContext(ctx, binary, append(target, args...)...)
+	for _, entry := range os.Environ() {
+		if !strings.HasPrefix(entry, "DOCKER_HOST=") && !strings.HasPrefix(entry, "DOCKER_CONTEXT=") {
+			cmd.Env = append(cmd.Env, entry)
+		}
+	}
+	return cmd
+}
+
+func (client *Client) exec(cmd *exec.Cmd) error {
+	if client.Exec != nil {
+		return client.Exec(cmd)
+	}
+	return cmd.Run()
+}
+
+// run runs a docker command and returns its output. Its error carries the
+// first line the CLI printed on stderr, which says what went wrong.
+func (client *Client) run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := client.command(ctx, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := client.exec(cmd)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			line, _, _ := strings.Cut(message, "\n")
+			return nil, fmt.Errorf("docker %s: %s", args[0], line)
+		}
+		return nil, fmt.Errorf("docker %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
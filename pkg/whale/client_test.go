@@ -0,0 +1,75 @@
+package whale
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The test binary stands in for the docker CLI when fakeDockerEnv is set:
+// it appends its arguments to the file the variable names, prints
+// fakeStdoutEnv and fails like the CLI for the container called missing.
+const (
+	fakeDockerEnv = "WHALE_FAKE_DOCKER"
+	fakeStdoutEnv = "WHALE_FAKE_STDOUT"
+)
+
+func TestMain(m *testing.M) {
+	if log := os.Getenv(fakeDockerEnv); log != "" {
+		os.Exit(fakeDocker(log, os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func fakeDocker(log string, args []string) int {
+	file, err := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 2
+	}
+	fmt.Fprintln(file, strings.Join(args, " "))
+	file.Close()
+
+	if args[len(args)-1] == "missing" {
+		fmt.Fprintln(os.Stderr, "Error response from daemon: No such container: missing")
+		return 1
+	}
+	fmt.Print(os.Getenv(fakeStdoutEnv))
+	return 0
+}
+
+// fakeClient returns a Client running the fake docker, printing stdout,
+// and a function returning the command lines it was run with.
+func fakeClient(t *testing.T, stdout string) (*Client, func() []string) {
+	t.Helper()
+	log := t.TempDir() + "/calls"
+	t.Setenv(fakeDockerEnv, log)
+	t.Setenv(fakeStdoutEnv, stdout)
+
+	calls := func() []string {
+		data, err := os.ReadFile(log)
+		if err != nil {
+			return nil
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+	return &Client{Binary: os.Args[0]}, calls
+}
+
+func TestCommandHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want []string
+	}{
+		{"", []string{"ps"}},
+		{"prod", []string{"--context", "prod", "ps"}},
+		{"ssh://server", []string{"--host", "ssh://server", "ps"}},
+	}
+	for _, test := range tests {
+		cmd := New(test.host).command(context.Background(), "ps")
+		if got := strings.Join(cmd.Args[1:], " "); got != strings.Join(test.want, " ") {
+			t.Errorf("host %q: args %q, want %q", test.host, got, strings.Join(test.want, " "))
+		}
+	}
+}
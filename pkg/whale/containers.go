@@ -0,0 +1,136 @@
+package whale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Container is a container as `docker container ls` describes it.
+type Container struct {
+	ID      string
+	Name    string
+	Image   string
+	Command string
+	// State is created, running, paused, restarting, removing, exited or
+	// dead.
+	State  string
+	Status string
+	Ports  string
+	Labels map[string]string
+	// CreatedAt is zero when the CLI printed it in a layout this package
+	// doesn't know, see Client.TimeLayouts.
+	CreatedAt time.Time
+	// RunningFor is how long ago the container was created, worded by the
+	// CLI like "3 hours ago". Not all compatible CLIs give it.
+	RunningFor string
+}
+
+// ListOptions are the options of List.
+type ListOptions struct {
+	// All includes the containers that aren't running.
+	All bool
+	// Filters are `docker ps --filter` expressions, like "label=env=prod".
+	Filters []string
+}
+
+// psLine mirrors one line of `docker container ls --format '{{json .}}'`.
+type psLine struct {
+	ID         string `json:"ID"`
+	Image      string `json:"Image"`
+	Command    string `json:"Command"`
+	RunningFor string `json:"RunningFor"`
+	CreatedAt  string `json:"CreatedAt"`
+	Status     string `json:"Status"`
+	State      string `json:"State"`
+	Ports      string `json:"Ports"`
+	Names      string `json:"Names"`
+	Labels     string `json:"Labels"`
+}
+
+// createdLayout is how the docker CLI prints CreatedAt.
+const createdLayout = "2006-01-02 15:04:05 -0700 MST"
+
+// List returns the containers the engine has, in the order the CLI gives
+// them: the most recently created first.
+func (client *Client) List(ctx context.Context, options ListOptions) ([]Container, error) {
+	args := []string{"container", "ls", "--no-trunc", "--format", "{{json .}}"}
+	if options.All {
+		args = append(args, "-a")
+	}
+	for _, filter := range options.Filters {
+		args = append(args, "--filter", filter)
+	}
+	output, err := client.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []Container
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+		container, err := client.ParseContainer(line)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// ParseContainer parses a line of `docker container ls --format
+// '{{json .}}'` the way List does, for output read elsewhere, like piped
+// to a program.
+func (client *Client) ParseContainer(line string) (Container, error) {
+	var ps psLine
+	err := json.Unmarshal([]byte(line), &ps)
+	if err != nil {
+		return Container{}, fmt.Errorf("error parsing container: %v", err)
+	}
+	return Container{
+		ID:         ps.ID,
+		Name:       ps.Names,
+		Image:      ps.Image,
+		Command:    ps.Command,
+		State:      ps.State,
+		Status:     ps.Status,
+		Ports:      ps.Ports,
+		Labels:     ParseLabels(ps.Labels),
+		CreatedAt:  client.parseTime(ps.CreatedAt),
+		RunningFor: ps.RunningFor,
+	}, nil
+}
+
+// parseTime parses a creation time printed by the CLI, zero when none of
+// the layouts fits.
+func (client *Client) parseTime(value string) time.Time {
+	// Go's time.Time String adds the monotonic clock reading, which some
+	// CLIs leave in.
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+	for _, layout := range append(client.TimeLayouts, createdLayout) {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ParseLabels splits docker's "key=value,key=value" label summary, the way
+// the ls commands print labels.
+func ParseLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			continue
+		}
+		labels[key] = value
+	}
+	return labels
+}
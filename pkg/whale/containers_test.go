@@ -0,0 +1,93 @@
+package whale
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const psOutput = `{"ID":"4f2a","Image":"nginx:1.27","Command":"\"/docker-entrypoint.sh\"","RunningFor":"3 hours ago","CreatedAt":"2024-05-01 10:00:00 +0200 CEST","Status":"Up 3 hours","State":"running","Ports":"0.0.0.0:8080->80/tcp","Names":"web","Labels":"com.docker.compose.project=shop,env=prod"}
+{"ID":"9c1b","Image":"redis:7","Command":"\"redis-server\"","CreatedAt":"2024-05-01T08:00:00Z","Status":"Exited (0) 2 hours ago","State":"exited","Ports":"","Names":"cache","Labels":""}
+`
+
+func TestList(t *testing.T) {
+	client, calls := fakeClient(t, psOutput)
+	client.TimeLayouts = []string{time.RFC3339}
+
+	containers, err := client.List(context.Background(), ListOptions{All: true, Filters: []string{"label=env=prod", "status=running"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "container ls --no-trunc --format {{json .}} -a --filter label=env=prod --filter status=running"
+	if got := calls(); len(got) != 1 || got[0] != want {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	if len(containers) != 2 {
+		t.Fatalf("got %d containers, want 2", len(containers))
+	}
+
+	web := containers[0]
+	if web.ID != "4f2a" || web.Name != "web" || web.State != "running" || web.RunningFor != "3 hours ago" {
+		t.Errorf("web: got %+v", web)
+	}
+	if want := map[string]string{"com.docker.compose.project": "shop", "env": "prod"}; !reflect.DeepEqual(web.Labels, want) {
+		t.Errorf("web labels: got %v, want %v", web.Labels, want)
+	}
+	if want := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC); !web.CreatedAt.Equal(want) {
+		t.Errorf("web created at %v, want %v", web.CreatedAt, want)
+	}
+
+	// Created in a layout of TimeLayouts rather than the CLI's.
+	cache := containers[1]
+	if want := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC); !cache.CreatedAt.Equal(want) {
+		t.Errorf("cache created at %v, want %v", cache.CreatedAt, want)
+	}
+	if len(cache.Labels) != 0 {
+		t.Errorf("cache labels: got %v, want none", cache.Labels)
+	}
+}
+
+func TestListRunningOnly(t *testing.T) {
+	client, calls := fakeClient(t, "")
+
+	containers, err := client.List(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 0 {
+		t.Errorf("got %d containers, want none", len(containers))
+	}
+	if got := calls(); len(got) != 1 || strings.Contains(got[0], "-a") {
+		t.Errorf("ran %q, want no -a", got)
+	}
+}
+
+func TestListInvalidOutput(t *testing.T) {
+	client, _ := fakeClient(t, "not json\n")
+
+	_, err := client.List(context.Background(), ListOptions{})
+	if err == nil || !strings.Contains(err.Error(), "error parsing container") {
+		t.Errorf("got error %v, want a parsing error", err)
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[string]string
+	}{
+		{"", map[string]string{}},
+		{"env=prod", map[string]string{"env": "prod"}},
+		{"a=1,b=2", map[string]string{"a": "1", "b": "2"}},
+		{"url=http://x?a=b,empty=", map[string]string{"url": "http://x?a=b", "empty": ""}},
+		{"novalue,=nokey,ok=yes", map[string]string{"ok": "yes"}},
+	}
+	for _, test := range tests {
+		if got := ParseLabels(test.in); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseLabels(%q) = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
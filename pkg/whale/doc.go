@@ -0,0 +1,22 @@
+// Package whale is the container engine of the whale TUI for other Go
+// tools: listing containers, running lifecycle actions on them, watching
+// the daemon's events and reading logs, without the interface.
+//
+// Like the TUI, it drives the docker CLI, so it works against whatever the
+// CLI can reach: the local daemon, a context or an ssh:// host.
+//
+//	client := whale.New("")
+//	containers, err := client.List(ctx, whale.ListOptions{All: true})
+//	if err != nil {
+//		return err
+//	}
+//	for _, container := range containers {
+//		if container.State == "exited" {
+//			err = client.Action(ctx, whale.Start, container.ID)
+//		}
+//	}
+//
+// The API of this package is stable: List, Action, Watch and Logs keep
+// their signatures, new options are added as fields of their option
+// structs.
+package whale
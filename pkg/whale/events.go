@@ -0,0 +1,95 @@
+package whale
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is an event of the daemon, like a container starting or dying.
+type Event struct {
+	// Type is container, image, volume, network...
+	Type string
+	// Action is what happened: start, die, health_status: healthy...
+	Action string
+	// ID is the resource the event is about.
+	ID string
+	// Attributes are the details the daemon gives, the name of the
+	// container, its image and exit code for a die, its labels.
+	Attributes map[string]string
+	Time       time.Time
+}
+
+// eventLine mirrors one line of `docker events --format '{{json .}}'`.
+type eventLine struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+// WatchOptions are the options of Watch.
+type WatchOptions struct {
+	// Filters are `docker events --filter` expressions, like
+	// "type=container" or "event=die".
+	Filters []string
+	// Since replays the events the daemon still has from then on first.
+	Since time.Time
+	// Until ends the stream once the events up to then are sent, so that
+	// with Since it reads a span of the past.
+	Until time.Time
+}
+
+// Watch streams the events of the daemon until ctx is done. The events
+// channel is closed then, or when the stream broke, in which case the
+// error is sent on the other channel first.
+func (client *Client) Watch(ctx context.Context, options WatchOptions) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	args := []string{"events", "--format", "{{json .}}"}
+	for _, filter := range options.Filters {
+		args = append(args, "--filter", filter)
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", fmt.Sprintf("%d.%09d", options.Since.Unix(), options.Since.Nanosecond()))
+	}
+	if !options.Until.IsZero() {
+		args = append(args, "--until", fmt.Sprintf("%d.%09d", options.Until.Unix(), options.Until.Nanosecond()))
+	}
+	cmd := client.command(ctx, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		errs <- err
+		close(events)
+		return events, errs
+	}
+
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			var line eventLine
+			if json.Unmarshal(scanner.Bytes(), &line) != nil || line.Action == "" {
+				continue
+			}
+			event := Event{Type: line.Type, Action: line.Action, ID: line.Actor.ID, Attributes: line.Actor.Attributes, Time: time.Unix(0, line.TimeNano)}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+		if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+			errs <- fmt.Errorf("docker events: %w", err)
+		}
+	}()
+	return events, errs
+}
@@ -0,0 +1,80 @@
+package whale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// LogOptions are the options of Logs.
+type LogOptions struct {
+	// Follow keeps the logs coming until the reader is closed or the
+	// context is done.
+	Follow bool
+	// Tail is how many of the last lines to start with, all of them when
+	// 0.
+	Tail int
+	// Since leaves out the lines older than it.
+	Since time.Time
+	// Timestamps prefixes the lines with their RFC 3339 time.
+	Timestamps bool
+}
+
+// logReader is the output of `docker logs`, stopping it when closed.
+type logReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (reader logReader) Close() error {
+	reader.cancel()
+	return reader.PipeReader.Close()
+}
+
+// Logs returns the logs of the container id, a name or an ID, stdout and
+// stderr interleaved as the container wrote them. The reader returns the
+// error of docker logs once the logs are over, and must be closed.
+func (client *Client) Logs(ctx context.Context, id string, options LogOptions) (io.ReadCloser, error) {
+	args := []string{"logs"}
+	if options.Follow {
+		args = append(args, "--follow")
+	}
+	if options.Tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(options.Tail))
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", fmt.Sprintf("%d.%09d", options.Since.Unix(), options.Since.Nanosecond()))
+	}
+	if options.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	args = append(args, id)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := client.command(ctx, args...)
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	finish := func(err error) {
+		if err != nil && ctx.Err() == nil {
+			err = fmt.Errorf("docker logs %s: %w", id, err)
+		} else {
+			err = nil
+		}
+		writer.CloseWithError(err)
+	}
+
+	if client.Exec != nil && !options.Follow {
+		go func() { finish(client.exec(cmd)) }()
+		return logReader{PipeReader: reader, cancel: cancel}, nil
+	}
+	err := cmd.Start()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go func() { finish(cmd.Wait()) }()
+	return logReader{PipeReader: reader, cancel: cancel}, nil
+}
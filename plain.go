@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// plainMode replaces the full-screen menus with numbered prompts read from
+// stdin: no colors, no box drawing and no redraws, so screen readers can
+// follow the output sequentially.
+var plainMode bool
+
+var plainInput = bufio.NewReader(os.Stdin)
+
+// choosePlain prints a numbered menu and returns the chosen index, or -1 when
+// the user quits with an empty answer, "q" or end of input.
+func choosePlain(title string, header string, options []string) (int, error) {
+	fmt.Println(title)
+	if header != "" {
+		fmt.Printf("    %s\n", header)
+	}
+	for i, option := range options {
+		fmt.Printf("%3d %s\n", i+1, option)
+	}
+
+	for {
+		fmt.Print(tr("plain.prompt", len(options)))
+
+		answer, err := plainInput.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if err != nil && (err != io.EOF || answer == "") {
+			fmt.Println()
+			if err == io.EOF {
+				return -1, nil
+			}
+			return -1, err
+		}
+
+		if answer == "" || answer == "q" {
+			return -1, nil
+		}
+
+		choice, convErr := strconv.Atoi(answer)
+		if convErr == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1, nil
+		}
+
+		fmt.Println(tr("plain.invalid", answer))
+		if err == io.EOF {
+			return -1, nil
+		}
+	}
+}
+
+func chooseContainerPlain(cfg *Config, containers []Container) (Container, error) {
+	if len(containers) == 0 {
+		fmt.Println(tr("list.empty"))
+		return Container{}, nil
+	}
+
+	menu := initialContainerModel(cfg, containers)
+	rows := menu.table(withoutStatsColumns(menu.columns))
+
+	choice, err := choosePlain(tr("list.title"), rows[0], rows[1:])
+	if err != nil || choice < 0 {
+		return Container{}, err
+	}
+
+	return containers[choice], nil
+}
+
+func chooseActionPlain(cfg *Config, container Container) (string, error) {
+	menu := initialActionModel(cfg, container)
+
+	var labels []string
+	for _, action := range menu.actions {
+		label := actionLabel(cfg, action)
+		if reason := actionUnavailable(action, container); reason != "" {
+			label += " (" + reason + ")"
+		}
+		labels = append(labels, label)
+	}
+
+	choice, err := choosePlain(tr("actions.title", container.Name), "", labels)
+	if err != nil || choice < 0 {
+		return "", err
+	}
+
+	if reason := actionUnavailable(menu.actions[choice], container); reason != "" {
+		fmt.Println(reason)
+		return "", nil
+	}
+	return menu.actions[choice], nil
+}
+
+// chooseManyPlain reads a comma separated list of numbers, "all", or an
+// empty answer to choose nothing.
+func chooseManyPlain(title string, header string, options []string) ([]int, error) {
+	fmt.Println(title)
+	if header != "" {
+		fmt.Printf("    %s\n", header)
+	}
+	for i, option := range options {
+		fmt.Printf("%3d %s\n", i+1, option)
+	}
+
+	for {
+		fmt.Print(tr("plain.promptMany", len(options)))
+
+		answer, err := plainInput.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if err != nil && (err != io.EOF || answer == "") {
+			fmt.Println()
+			if err == io.EOF {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		if answer == "" || answer == "q" {
+			return nil, nil
+		}
+
+		var indexes []int
+		valid := true
+		for _, part := range splitList(answer) {
+			if part == "all" {
+				indexes = indexes[:0]
+				for i := range options {
+					indexes = append(indexes, i)
+				}
+				break
+			}
+			choice, convErr := strconv.Atoi(part)
+			if convErr != nil || choice < 1 || choice > len(options) {
+				valid = false
+				break
+			}
+			indexes = append(indexes, choice-1)
+		}
+		if valid {
+			return indexes, nil
+		}
+
+		fmt.Println(tr("plain.invalid", answer))
+		if err == io.EOF {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// hostArchitecture is the engine's architecture, once detectEmulation ran.
+var hostArchitecture string
+
+// imagePlatform is the subset of `docker image inspect` describing what the
+// image was built for.
+type imagePlatform struct {
+	ID           string `json:"Id"`
+	Os           string `json:"Os"`
+	Architecture string `json:"Architecture"`
+	Variant      string `json:"Variant"`
+}
+
+func (platform imagePlatform) String() string {
+	s := platform.Os + "/" + platform.Architecture
+	if platform.Variant != "" {
+		s += "/" + platform.Variant
+	}
+	return s
+}
+
+// daemonArchitecture returns the engine's architecture in Go naming
+// (amd64, arm64), or "" when it can't be determined.
+func daemonArchitecture() string {
+	if daemonVersion.Server != nil {
+		return normalizeArchitecture(daemonVersion.Server.Arch)
+	}
+
+	output, err := dockerRead("version", "--format", "{{.Server.Arch}}")
+	if err != nil {
+		return ""
+	}
+	return normalizeArchitecture(strings.TrimSpace(string(output)))
+}
+
+// normalizeArchitecture folds kernel names onto the names images use.
+func normalizeArchitecture(arch string) string {
+	switch arch {
+	case "x86_64", "x86-64":
+		return "amd64"
+	case "aarch64", "armv8":
+		return "arm64"
+	case "armv7l", "armhf":
+		return "arm"
+	}
+	return arch
+}
+
+func inspectImagePlatforms(ids []string) (map[string]imagePlatform, error) {
+	platforms := make(map[string]imagePlatform)
+	if len(ids) == 0 {
+		return platforms, nil
+	}
+
+	output, err := dockerRead(append([]string{"image", "inspect"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []imagePlatform
+	err = json.Unmarshal(output, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, platform := range list {
+		platforms[platform.ID] = platform
+	}
+	return platforms, nil
+}
+
+// detectEmulation marks containers whose image architecture differs from
+// the engine's, meaning they run under QEMU/Rosetta emulation. Failures
+// only skip the badge, they never prevent listing.
+func detectEmulation(containers []Container, imageIDs map[string]string) {
+	arch := daemonArchitecture()
+	if arch == "" {
+		return
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range imageIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	platforms, err := inspectImagePlatforms(ids)
+	if err != nil {
+		return
+	}
+
+	for i := range containers {
+		platform, ok := platforms[imageIDs[containers[i].ID]]
+		if !ok {
+			continue
+		}
+
+		containers[i].Platform = platform.String()
+		containers[i].Emulated = platform.Architecture != "" && normalizeArchitecture(platform.Architecture) != arch
+	}
+	hostArchitecture = arch
+}
@@ -0,0 +1,19 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// programRunning is set while a bubbletea program owns the terminal.
+var programRunning bool
+
+// runProgram is the single entry point used by every screen to run a
+// bubbletea model. Screens take the alternate screen, where bubbletea only
+// repaints the lines of a frame that changed since the previous one: views
+// must not clear the terminal themselves, which would repaint it in whole
+// at each keypress and flicker over slow links.
+func runProgram(model tea.Model, options ...tea.ProgramOption) (tea.Model, error) {
+	programRunning = true
+	defer func() { programRunning = false }()
+
+	options = append([]tea.ProgramOption{tea.WithAltScreen()}, options...)
+	return tea.NewProgram(model, options...).Run()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+)
+
+// isProtectedImage reports images listed in images.protected, which prune
+// and bulk removals always keep. Entries are "repository:tag", a bare
+// repository covering all its tags, or an image ID prefix.
+func isProtectedImage(cfg *Config, image Image) bool {
+	id := strings.TrimPrefix(image.ID, "sha256:")
+	for _, entry := range cfg.Images.Protected {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		repository, tag, hasTag := strings.Cut(entry, ":")
+		if hasTag && strings.Contains(tag, "/") {
+			// A registry port, not a tag: registry:5000/app.
+			repository, hasTag = entry, false
+		}
+
+		switch {
+		case strings.HasPrefix(id, strings.TrimPrefix(entry, "sha256:")) && len(entry) >= 12:
+			return true
+		case !hasTag && strings.ToLower(image.Repository) == repository:
+			return true
+		case hasTag && strings.ToLower(image.Repository) == repository && strings.ToLower(image.Tag) == tag:
+			return true
+		}
+	}
+	return false
+}
+
+// usedImageIDs returns the images any container uses, looking at every
+// container and not only the ones the list shows.
+func usedImageIDs() (map[string]bool, error) {
+	output, err := dockerRead("container", "ls", "-a", "-q", "--no-trunc")
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return used, nil
+	}
+
+	output, err = dockerRead(append([]string{"container", "inspect", "--format", "{{.Image}}"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range strings.Fields(string(output)) {
+		used[id] = true
+	}
+	return used, nil
+}
+
+// unusedImages lists the images no container uses, split between the ones
+// that can go and the protected ones.
+func unusedImages(cfg *Config) ([]Image, []Image, error) {
+	images, err := getImages(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	used, err := usedImageIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var removable, protected []Image
+	for _, image := range images {
+		if used[image.ID] {
+			continue
+		}
+		if isProtectedImage(cfg, image) {
+			protected = append(protected, image)
+		} else {
+			removable = append(removable, image)
+		}
+	}
+	return removable, protected, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// provenanceLabels are the image labels telling where an image was built
+// from, the OCI ones first and the older label-schema ones as a fallback.
+var provenanceLabels = map[string][]string{
+	"source":   {"org.opencontainers.image.source", "org.label-schema.vcs-url"},
+	"revision": {"org.opencontainers.image.revision", "org.label-schema.vcs-ref"},
+	"version":  {"org.opencontainers.image.version", "org.label-schema.version"},
+	"created":  {"org.opencontainers.image.created", "org.label-schema.build-date"},
+	"url":      {"org.opencontainers.image.url", "org.label-schema.url"},
+}
+
+// provenanceFields is the order of the fields in the view.
+var provenanceFields = []string{"source", "revision", "version", "created", "url", "dockerfile", "builder"}
+
+// slsaProvenance is the subset of the SLSA provenance attached by BuildKit
+// that `docker buildx imagetools inspect` prints.
+type slsaProvenance struct {
+	SLSA struct {
+		BuildType string `json:"buildType"`
+		Builder   struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Invocation struct {
+			ConfigSource struct {
+				URI        string            `json:"uri"`
+				Digest     map[string]string `json:"digest"`
+				EntryPoint string            `json:"entryPoint"`
+			} `json:"configSource"`
+		} `json:"invocation"`
+	} `json:"SLSA"`
+}
+
+// imageProvenance gathers the provenance of an image from its labels, then
+// from its BuildKit attestation when the registry has one, which also fills
+// in what the labels left out.
+func imageProvenance(inspect imageInspect) map[string]string {
+	provenance := map[string]string{}
+	for field, labels := range provenanceLabels {
+		for _, label := range labels {
+			if value := inspect.Config.Labels[label]; value != "" {
+				provenance[field] = value
+				break
+			}
+		}
+	}
+
+	if len(inspect.RepoDigests) == 0 {
+		return provenance
+	}
+	attestation, ok := readAttestation(inspect.RepoDigests[0])
+	if !ok {
+		return provenance
+	}
+	provenance["attested"] = "yes"
+
+	source := attestation.SLSA.Invocation.ConfigSource
+	uri, ref, _ := strings.Cut(source.URI, "#")
+	if provenance["source"] == "" && uri != "" {
+		provenance["source"] = uri
+	}
+	if provenance["revision"] == "" {
+		if digest := source.Digest["sha1"]; digest != "" {
+			provenance["revision"] = digest
+		} else if ref != "" {
+			provenance["revision"] = ref
+		}
+	}
+	provenance["dockerfile"] = source.EntryPoint
+	provenance["builder"] = attestation.SLSA.Builder.ID
+	return provenance
+}
+
+// readAttestation fetches the provenance attestation of a pushed image. A
+// multi-platform image has one per platform, keyed by platform, and they
+// come from the same build so the first one does.
+func readAttestation(reference string) (slsaProvenance, bool) {
+	output, err := dockerRead("buildx", "imagetools", "inspect", reference, "--format", "{{json .Provenance}}")
+	if err != nil {
+		return slsaProvenance{}, false
+	}
+
+	var attestation slsaProvenance
+	if json.Unmarshal(output, &attestation) == nil && attestation.SLSA.BuildType != "" {
+		return attestation, true
+	}
+
+	var platforms map[string]slsaProvenance
+	if json.Unmarshal(output, &platforms) != nil {
+		return slsaProvenance{}, false
+	}
+	for _, attestation := range platforms {
+		if attestation.SLSA.BuildType != "" {
+			return attestation, true
+		}
+	}
+	return slsaProvenance{}, false
+}
+
+// commitURL links a revision to its page on the forge hosting the source,
+// or returns "" for a forge whale doesn't know.
+func commitURL(source string, revision string) string {
+	if source == "" || revision == "" {
+		return ""
+	}
+
+	repository := strings.TrimSuffix(strings.TrimSuffix(source, "/"), ".git")
+	if rest, ok := strings.CutPrefix(repository, "git@"); ok {
+		host, path, _ := strings.Cut(rest, ":")
+		repository = "https://" + host + "/" + path
+	}
+	repository = strings.Replace(repository, "git+https://", "https://", 1)
+	if !strings.HasPrefix(repository, "https://") && !strings.HasPrefix(repository, "http://") {
+		return ""
+	}
+
+	switch host := strings.Split(strings.SplitN(repository, "://", 2)[1], "/")[0]; {
+	case host == "github.com" || strings.HasPrefix(host, "github."):
+		return repository + "/commit/" + revision
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return repository + "/-/commit/" + revision
+	case host == "bitbucket.org":
+		return repository + "/commits/" + revision
+	case host == "codeberg.org" || strings.HasPrefix(host, "gitea."):
+		return repository + "/commit/" + revision
+	}
+	return ""
+}
+
+// showProvenance answers what code an image, or the image of a container,
+// was built from, then offers to copy the link to the commit.
+func showProvenance(reference string) error {
+	inspect, err := inspectImage(reference)
+	if err != nil {
+		return err
+	}
+
+	provenance := imageProvenance(inspect)
+	fmt.Println(tr("provenance.title", reference))
+	if provenance["source"] == "" && provenance["revision"] == "" {
+		fmt.Println(renderColor(tr("provenance.none"), "2"))
+		return nil
+	}
+	for _, field := range provenanceFields {
+		if value := provenance[field]; value != "" {
+			fmt.Printf("  %-12s %s\n", tr("provenance."+field), value)
+		}
+	}
+	if provenance["attested"] == "" {
+		fmt.Println(renderColor(tr("provenance.labelsOnly"), "2"))
+	}
+
+	link := commitURL(provenance["source"], provenance["revision"])
+	if link == "" {
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("  %-12s %s\n", tr("provenance.commit"), link)
+	if !isInteractive() {
+		return nil
+	}
+
+	fmt.Print(tr("provenance.copyPrompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		return nil
+	}
+	err = copyToClipboard(link)
+	if err != nil {
+		return fmt.Errorf("error copying link: %v", err)
+	}
+	fmt.Println(tr("provenance.copied"))
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+)
+
+// networkInfo is the subset of `docker info` about how the daemon reaches
+// registries.
+type networkInfo struct {
+	HTTPProxy      string `json:"HTTPProxy"`
+	HTTPSProxy     string `json:"HTTPSProxy"`
+	NoProxy        string `json:"NoProxy"`
+	RegistryConfig struct {
+		InsecureRegistryCIDRs []string `json:"InsecureRegistryCIDRs"`
+		IndexConfigs          map[string]struct {
+			Secure bool `json:"Secure"`
+		} `json:"IndexConfigs"`
+	} `json:"RegistryConfig"`
+}
+
+func getNetworkInfo() (networkInfo, error) {
+	var info networkInfo
+	output, err := dockerRead("info", "--format", "{{json .}}")
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(output, &info)
+	return info, err
+}
+
+// bypassesLocalhost reports whether a NO_PROXY list covers local registries.
+func bypassesLocalhost(noProxy string) bool {
+	for _, entry := range strings.Split(noProxy, ",") {
+		switch strings.TrimSpace(entry) {
+		case "localhost", "127.0.0.1", "127.0.0.0/8", "::1", "*":
+			return true
+		}
+	}
+	return false
+}
+
+// checkProxy reports the daemon's proxy, which pulls go through, and flags
+// a proxy without a localhost exception: pulls from a local registry then
+// hit the proxy and fail.
+func checkProxy(info networkInfo) diagnostic {
+	result := diagnostic{Name: tr("doctor.proxy"), Status: diagnosticOK, Detail: tr("doctor.proxyNone")}
+
+	var proxies []string
+	if info.HTTPProxy != "" {
+		proxies = append(proxies, "HTTP "+info.HTTPProxy)
+	}
+	if info.HTTPSProxy != "" {
+		proxies = append(proxies, "HTTPS "+info.HTTPSProxy)
+	}
+
+	if len(proxies) > 0 {
+		result.Detail = strings.Join(proxies, ", ")
+		if info.NoProxy != "" {
+			result.Detail += ", " + tr("doctor.proxyExcept", info.NoProxy)
+		}
+		if !bypassesLocalhost(info.NoProxy) {
+			result.Status = diagnosticWarn
+			result.Hint = tr("doctor.proxyLocalhostHint")
+		}
+		return result
+	}
+
+	// The CLI environment doesn't configure the daemon, which is a common
+	// surprise when pulls fail behind a corporate proxy.
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if os.Getenv(name) != "" {
+			result.Status = diagnosticWarn
+			result.Hint = tr("doctor.proxyClientOnlyHint", name)
+			break
+		}
+	}
+	return result
+}
+
+// checkInsecureRegistries lists the registries pulled from over plain HTTP
+// or with unverified certificates, beyond the default loopback range.
+func checkInsecureRegistries(info networkInfo) diagnostic {
+	result := diagnostic{Name: tr("doctor.insecure"), Status: diagnosticOK, Detail: tr("doctor.insecureNone")}
+
+	var insecure []string
+	for name, index := range info.RegistryConfig.IndexConfigs {
+		if !index.Secure {
+			insecure = append(insecure, name)
+		}
+	}
+	for _, cidr := range info.RegistryConfig.InsecureRegistryCIDRs {
+		if cidr != "127.0.0.0/8" && cidr != "::1/128" {
+			insecure = append(insecure, cidr)
+		}
+	}
+	sort.Strings(insecure)
+
+	if len(insecure) > 0 {
+		result.Status = diagnosticWarn
+		result.Detail = strings.Join(insecure, ", ")
+		result.Hint = tr("doctor.insecureHint")
+	}
+	return result
+}
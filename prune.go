@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// pruneCommand implements `whale prune [--yes]`: lists the stopped
+// containers, unused images, networks, volumes and build cache with their
+// sizes, lets the user uncheck some, and removes the others. Unused images
+// are removed one by one rather than with --all, so that the protected ones
+// are kept.
+func pruneCommand(args []string) {
+	cfg := currentConfig()
+	assumeYes := false
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			assumeYes = true
+		}
+	}
+
+	requireAction(cfg, "prune")
+
+	_, protected, err := unusedImages(cfg)
+	if err != nil {
+		println(tr("error.getImages"), err)
+		os.Exit(1)
+	}
+	for _, image := range protected {
+		fmt.Println(tr("prune.keptProtected", image.Reference()))
+	}
+
+	err = previewAndPrune(cfg, pruneKindsAll, assumeYes)
+	recordAction("prune", "host", currentDockerContext(), err)
+	if err != nil {
+		if err != errCancelled {
+			println(err.Error())
+		}
+		os.Exit(1)
+	}
+}
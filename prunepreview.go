@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// pruneItem is one thing a prune deletes, with the command deleting it on
+// its own so that the user can leave some out.
+type pruneItem struct {
+	Kind string
+	Name string
+	// Size is in bytes, 0 when unknown.
+	Size int64
+	Args []string
+}
+
+// pruneKindsAll are the kinds `whale prune` goes through, in order.
+var pruneKindsAll = []string{"containers", "images", "networks", "volumes", "buildCache"}
+
+// pruneItems lists what pruning kinds would delete. Unused images are the
+// ones no container uses, protected images excepted; volumes are the
+// anonymous ones no container mounts, which is what `docker volume prune`
+// removes.
+func pruneItems(cfg *Config, kinds []string) ([]pruneItem, error) {
+	var items []pruneItem
+	for _, kind := range kinds {
+		var found []pruneItem
+		var err error
+		switch kind {
+		case "containers":
+			found, err = prunableContainers()
+		case "images":
+			found, err = prunableImages(cfg)
+		case "networks":
+			found, err = prunableNetworks()
+		case "volumes":
+			found, err = prunableVolumes()
+		case "buildCache":
+			found = prunableBuildCache()
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func prunableContainers() ([]pruneItem, error) {
+	output, err := dockerRead("container", "ls", "-a", "--size", "--no-trunc", "--filter", "status=exited", "--filter", "status=created", "--filter", "status=dead", "--format", "{{.ID}}\t{{.Names}}\t{{.Size}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var items []pruneItem
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		// The size reads "1.2kB (virtual 80MB)", the first part is what goes.
+		size, _, _ := strings.Cut(fields[2], " ")
+		items = append(items, pruneItem{Kind: "container", Name: fields[1], Size: parseBytes(size), Args: []string{"rm", fields[0]}})
+	}
+	return items, nil
+}
+
+func prunableImages(cfg *Config) ([]pruneItem, error) {
+	removable, _, err := unusedImages(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []pruneItem
+	for _, image := range removable {
+		items = append(items, pruneItem{Kind: "image", Name: image.Reference(), Size: parseBytes(image.Size), Args: []string{"image", "rm", image.Reference()}})
+	}
+	return items, nil
+}
+
+func prunableNetworks() ([]pruneItem, error) {
+	networks, err := getNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, network := range networks {
+		if !containsString(builtinNetworks, network.Name) {
+			ids = append(ids, network.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	output, err := dockerRead(append([]string{"network", "inspect", "--format", "{{.Id}}\t{{.Name}}\t{{len .Containers}}"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	var items []pruneItem
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) == 3 && fields[2] == "0" {
+			items = append(items, pruneItem{Kind: "network", Name: fields[1], Args: []string{"network", "rm", fields[0]}})
+		}
+	}
+	return items, nil
+}
+
+func prunableVolumes() ([]pruneItem, error) {
+	volumes, err := getVolumes()
+	if err != nil {
+		return nil, err
+	}
+	usage, err := getVolumeUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []pruneItem
+	for _, volume := range volumes {
+		u := usage[volume.Name]
+		if !volume.Anonymous || u != nil && len(u.Containers) > 0 {
+			continue
+		}
+		item := pruneItem{Kind: "volume", Name: shortID(volume.Name), Args: []string{"volume", "rm", volume.Name}}
+		if u != nil {
+			item.Size = parseBytes(u.Size)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// prunableBuildCache is the reclaimable build cache as a single item, none
+// when the daemon can't tell.
+func prunableBuildCache() []pruneItem {
+	output, err := dockerRead("system", "df", "--format", "{{json .}}")
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var row struct {
+			Type        string `json:"Type"`
+			Reclaimable string `json:"Reclaimable"`
+		}
+		if json.Unmarshal([]byte(line), &row) != nil || row.Type != "Build Cache" {
+			continue
+		}
+		size, _, _ := strings.Cut(row.Reclaimable, " ")
+		if parseBytes(size) == 0 {
+			return nil
+		}
+		return []pruneItem{{Kind: "buildCache", Name: tr("prune.buildCache"), Size: parseBytes(size), Args: []string{"builder", "prune", "--force"}}}
+	}
+	return nil
+}
+
+func totalSize(items []pruneItem) int64 {
+	var total int64
+	for _, item := range items {
+		total += item.Size
+	}
+	return total
+}
+
+// reviewPrune shows the items with their sizes, all checked but volumes,
+// which hold data, and returns the ones the user kept checked.
+func reviewPrune(cfg *Config, items []pruneItem) ([]pruneItem, error) {
+	table := [][]string{{tr("cleanup.kind"), tr("column.name"), tr("column.size")}}
+	checked := make([]bool, len(items))
+	for i, item := range items {
+		size := ""
+		if item.Size > 0 {
+			size = formatBytes(item.Size)
+		}
+		table = append(table, []string{tr("prune.kind." + item.Kind), item.Name, size})
+		checked[i] = item.Kind != "volume"
+	}
+	rows := alignColumns(table)
+
+	chosen, err := chooseMany(cfg, tr("prune.previewTitle", len(items), formatBytes(totalSize(items))), rows[0], rows[1:], checked)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []pruneItem
+	for _, i := range chosen {
+		kept = append(kept, items[i])
+	}
+	return kept, nil
+}
+
+// removePruneItems deletes the items one by one, printing each outcome, and
+// returns how many failed.
+func removePruneItems(items []pruneItem) int {
+	failed := 0
+	var freed int64
+	for _, item := range items {
+		output, err := runWithSpinner(exec.Command("docker", item.Args...))
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s %s: %v: %s\n", tr("prune.kind."+item.Kind), item.Name, err, strings.TrimSpace(string(output)))
+			continue
+		}
+		freed += item.Size
+		recordPruned(item)
+		fmt.Printf("✓ %s %s\n", tr("prune.kind."+item.Kind), item.Name)
+	}
+	fmt.Println(tr("prune.freed", len(items)-failed, formatBytes(freed)))
+	return failed
+}
+
+// previewAndPrune lists what pruning kinds would delete so that the user
+// can uncheck some, then removes the rest one by one after the usual typed
+// confirmation. assumeYes takes everything without asking.
+func previewAndPrune(cfg *Config, kinds []string, assumeYes bool) error {
+	items, err := pruneItems(cfg, kinds)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Println(tr("prune.nothing"))
+		return nil
+	}
+
+	if !assumeYes && isInteractive() {
+		items, err = reviewPrune(cfg, items)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return errCancelled
+		}
+	}
+	summary := tr("prune.chosen", len(items), formatBytes(totalSize(items)))
+	for _, item := range items {
+		if item.Kind == "volume" {
+			summary += "\n" + tr("prune.volumeData")
+			break
+		}
+	}
+	if !confirmBulk(summary, confirmWord, assumeYes) {
+		return errCancelled
+	}
+
+	if failed := removePruneItems(items); failed > 0 {
+		return fmt.Errorf("%s", tr("prune.failed", failed, len(items)))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pullPolicies are the values of run.pullPolicy, as docker's --pull takes
+// them.
+var pullPolicies = []string{"always", "missing", "never"}
+
+// pullPolicy returns run.pullPolicy, or missing, docker's own default, when
+// it is unset or unknown.
+func pullPolicy(cfg *Config) string {
+	policy := strings.ToLower(strings.TrimSpace(cfg.Run.PullPolicy))
+	if !containsString(pullPolicies, policy) {
+		return "missing"
+	}
+	return policy
+}
+
+func parsePullPolicy(value string) (string, error) {
+	policy := strings.ToLower(strings.TrimSpace(value))
+	if !containsString(pullPolicies, policy) {
+		return "", fmt.Errorf("%s", tr("pull.invalid", value))
+	}
+	return policy, nil
+}
+
+// pullSummary tells whether creating a container from image under policy
+// will hit the registry.
+func pullSummary(policy string, image string) string {
+	switch policy {
+	case "always":
+		return tr("pull.always", image)
+	case "never":
+		return tr("pull.never", image)
+	}
+	if _, err := dockerRead("image", "inspect", "--format", "{{.Id}}", image); err == nil {
+		return tr("pull.present", image)
+	}
+	return tr("pull.absent", image)
+}
+
+// composePullSummary is pullSummary for the services of a compose project,
+// whose images docker compose checks one by one.
+func composePullSummary(policy string) string {
+	return tr("pull.compose." + policy)
+}
+
+// lastLine is the container ID in the output of `docker run -d` or `docker
+// create`, after what pulling the image printed.
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,46 @@
+package main
+
+// addRecent puts name first in the recently used containers, keeping the
+// list.recent most recent ones.
+func addRecent(s *state, name string, limit int) {
+	recent := []string{name}
+	for _, other := range s.Recent {
+		if other != name && len(recent) < limit {
+			recent = append(recent, other)
+		}
+	}
+	s.Recent = recent
+}
+
+// groupRecent moves the recently used containers to the top of the list,
+// most recent first, the others keeping their order, and the cursor on the
+// same container.
+func (menu *containerChoice) groupRecent() {
+	menu.recentShown = 0
+	if len(menu.recent) == 0 || len(menu.containers) == 0 {
+		return
+	}
+
+	current := menu.containers[menu.cursor].ID
+	var recent, others []Container
+	for _, name := range menu.recent {
+		for _, container := range menu.containers {
+			if container.Name == name {
+				recent = append(recent, container)
+			}
+		}
+	}
+	for _, container := range menu.containers {
+		if !containsString(menu.recent, container.Name) {
+			others = append(others, container)
+		}
+	}
+	menu.containers = append(recent, others...)
+	menu.recentShown = len(recent)
+
+	for i, container := range menu.containers {
+		if container.ID == current {
+			menu.cursor = i
+		}
+	}
+}
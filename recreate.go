@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// containerSpec is the part of `docker container inspect` needed to create
+// an identical container again.
+type containerSpec struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Config struct {
+		Hostname     string              `json:"Hostname"`
+		User         string              `json:"User"`
+		Env          []string            `json:"Env"`
+		Cmd          []string            `json:"Cmd"`
+		Entrypoint   []string            `json:"Entrypoint"`
+		Image        string              `json:"Image"`
+		WorkingDir   string              `json:"WorkingDir"`
+		Labels       map[string]string   `json:"Labels"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+		Tty          bool                `json:"Tty"`
+		OpenStdin    bool                `json:"OpenStdin"`
+		StopSignal   string              `json:"StopSignal"`
+	} `json:"Config"`
+	HostConfig struct {
+		NetworkMode   string `json:"NetworkMode"`
+		RestartPolicy struct {
+			Name              string `json:"Name"`
+			MaximumRetryCount int    `json:"MaximumRetryCount"`
+		} `json:"RestartPolicy"`
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"PortBindings"`
+		Privileged  bool     `json:"Privileged"`
+		CapAdd      []string `json:"CapAdd"`
+		CapDrop     []string `json:"CapDrop"`
+		ExtraHosts  []string `json:"ExtraHosts"`
+		DNS         []string `json:"Dns"`
+		SecurityOpt []string `json:"SecurityOpt"`
+		Memory      int64    `json:"Memory"`
+		NanoCpus    int64    `json:"NanoCpus"`
+		ShmSize     int64    `json:"ShmSize"`
+		Init        *bool    `json:"Init"`
+		AutoRemove  bool     `json:"AutoRemove"`
+		Runtime     string   `json:"Runtime"`
+		Devices     []struct {
+			PathOnHost        string `json:"PathOnHost"`
+			PathInContainer   string `json:"PathInContainer"`
+			CgroupPermissions string `json:"CgroupPermissions"`
+		} `json:"Devices"`
+		DeviceRequests []deviceRequest `json:"DeviceRequests"`
+		LogConfig      struct {
+			Type   string            `json:"Type"`
+			Config map[string]string `json:"Config"`
+		} `json:"LogConfig"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+		RW          bool   `json:"RW"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			Aliases []string `json:"Aliases"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+}
+
+// inspectSpec inspects a container afresh for recreating it, a cached
+// document could have outdated env, mounts or labels.
+func inspectSpec(id string) (containerSpec, error) {
+	var spec containerSpec
+	output, err := freshInspect(id)
+	if err != nil {
+		return spec, err
+	}
+	err = json.Unmarshal(output, &spec)
+	return spec, err
+}
+
+// createArgs turns a spec back into `docker create` arguments. Settings the
+// image already provides (env, cmd, labels inherited from it) are passed
+// again, which is harmless. The first network is set at creation, the
+// others are returned to be connected afterwards.
+func createArgs(spec containerSpec, name string, policy string) ([]string, []string) {
+	c := spec.Config
+	h := spec.HostConfig
+	args := []string{"create", "--name", name, "--pull", policy}
+
+	add := func(flag string, values ...string) {
+		for _, value := range values {
+			args = append(args, flag, value)
+		}
+	}
+
+	if c.Hostname != "" && !strings.HasPrefix(spec.ID, c.Hostname) {
+		add("--hostname", c.Hostname)
+	}
+	if c.User != "" {
+		add("--user", c.User)
+	}
+	if c.WorkingDir != "" {
+		add("--workdir", c.WorkingDir)
+	}
+	add("--env", c.Env...)
+	add("--label", sortedPairs(c.Labels)...)
+	if c.Tty {
+		args = append(args, "--tty")
+	}
+	if c.OpenStdin {
+		args = append(args, "--interactive")
+	}
+	if c.StopSignal != "" {
+		add("--stop-signal", c.StopSignal)
+	}
+	for port := range c.ExposedPorts {
+		add("--expose", port)
+	}
+
+	for port, bindings := range h.PortBindings {
+		for _, binding := range bindings {
+			mapping := binding.HostPort + ":" + port
+			if binding.HostIP != "" {
+				mapping = binding.HostIP + ":" + mapping
+			}
+			add("--publish", mapping)
+		}
+	}
+
+	for _, mount := range spec.Mounts {
+		source := mount.Source
+		if mount.Type == "volume" {
+			source = mount.Name
+		}
+		value := fmt.Sprintf("type=%s,destination=%s", mount.Type, mount.Destination)
+		if source != "" && mount.Type != "tmpfs" {
+			value += ",source=" + source
+		}
+		if !mount.RW {
+			value += ",readonly"
+		}
+		add("--mount", value)
+	}
+
+	if h.RestartPolicy.Name != "" && h.RestartPolicy.Name != "no" {
+		policy := h.RestartPolicy.Name
+		if policy == "on-failure" && h.RestartPolicy.MaximumRetryCount > 0 {
+			policy += ":" + strconv.Itoa(h.RestartPolicy.MaximumRetryCount)
+		}
+		add("--restart", policy)
+	}
+	if h.Privileged {
+		args = append(args, "--privileged")
+	}
+	add("--cap-add", h.CapAdd...)
+	add("--cap-drop", h.CapDrop...)
+	add("--add-host", h.ExtraHosts...)
+	add("--dns", h.DNS...)
+	add("--security-opt", h.SecurityOpt...)
+	if h.Memory > 0 {
+		add("--memory", strconv.FormatInt(h.Memory, 10))
+	}
+	if h.NanoCpus > 0 {
+		add("--cpus", strconv.FormatFloat(float64(h.NanoCpus)/1e9, 'f', -1, 64))
+	}
+	if h.ShmSize > 0 && h.ShmSize != 64<<20 {
+		add("--shm-size", strconv.FormatInt(h.ShmSize, 10))
+	}
+	if h.Init != nil && *h.Init {
+		args = append(args, "--init")
+	}
+	if h.AutoRemove {
+		args = append(args, "--rm")
+	}
+	if h.Runtime != "" && h.Runtime != "runc" {
+		add("--runtime", h.Runtime)
+	}
+	for _, device := range h.Devices {
+		value := device.PathOnHost + ":" + device.PathInContainer
+		if device.CgroupPermissions != "" {
+			value += ":" + device.CgroupPermissions
+		}
+		add("--device", value)
+	}
+	for _, request := range h.DeviceRequests {
+		if !request.isGPU() {
+			continue
+		}
+		switch {
+		case len(request.DeviceIDs) > 0:
+			add("--gpus", `"device=`+strings.Join(request.DeviceIDs, ",")+`"`)
+		case request.Count < 0:
+			add("--gpus", "all")
+		case request.Count > 0:
+			add("--gpus", strconv.Itoa(request.Count))
+		}
+	}
+	if h.LogConfig.Type != "" {
+		add("--log-driver", h.LogConfig.Type)
+		add("--log-opt", sortedPairs(h.LogConfig.Config)...)
+	}
+
+	var networks []string
+	for network := range spec.NetworkSettings.Networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	var extra []string
+	switch {
+	case strings.HasPrefix(h.NetworkMode, "container:") || h.NetworkMode == "host" || h.NetworkMode == "none":
+		add("--network", h.NetworkMode)
+	case len(networks) > 0:
+		first := networks[0]
+		for _, network := range networks {
+			if network == h.NetworkMode {
+				first = network
+			}
+		}
+		add("--network", first)
+		add("--network-alias", networkAliases(spec, first)...)
+		for _, network := range networks {
+			if network != first {
+				extra = append(extra, network)
+			}
+		}
+	}
+
+	if len(c.Entrypoint) > 0 {
+		add("--entrypoint", c.Entrypoint[0])
+	} else if c.Entrypoint != nil {
+		add("--entrypoint", "")
+	}
+	args = append(args, c.Image)
+	if len(c.Entrypoint) > 1 {
+		args = append(args, c.Entrypoint[1:]...)
+	}
+	args = append(args, c.Cmd...)
+
+	return args, extra
+}
+
+// networkAliases keeps the user-given aliases, dropping the short ID the
+// daemon adds by itself.
+func networkAliases(spec containerSpec, network string) []string {
+	var aliases []string
+	for _, alias := range spec.NetworkSettings.Networks[network].Aliases {
+		if !strings.HasPrefix(spec.ID, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+func sortedPairs(values map[string]string) []string {
+	var pairs []string
+	for key, value := range values {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// recreateContainer replaces a container by one created from spec, under
+// the same name, pulling its image according to policy. The old container is only renamed until the new one is
+// created and started, and is brought back if anything fails.
+func recreateContainer(spec containerSpec, policy string) error {
+	name := strings.TrimPrefix(spec.Name, "/")
+	backup := fmt.Sprintf("%s-whale-%d", name, time.Now().Unix())
+	run := func(args ...string) error {
+		output, err := runWithSpinner(exec.Command("docker", args...))
+		if err != nil {
+			return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	if spec.State.Running {
+		err := run("stop", spec.ID)
+		if err != nil {
+			return err
+		}
+	}
+	err := run("rename", spec.ID, backup)
+	if err != nil {
+		return err
+	}
+
+	rollback := func(cause error) error {
+		run("rm", "-f", name)
+		run("rename", spec.ID, name)
+		if spec.State.Running {
+			run("start", spec.ID)
+		}
+		return fmt.Errorf("%v (%s)", cause, tr("recreate.rolledBack"))
+	}
+
+	args, networks := createArgs(spec, name, policy)
+	err = run(args...)
+	if err != nil {
+		return rollback(err)
+	}
+	for _, network := range networks {
+		connect := []string{"network", "connect"}
+		for _, alias := range networkAliases(spec, network) {
+			connect = append(connect, "--alias", alias)
+		}
+		err = run(append(connect, network, name)...)
+		if err != nil {
+			return rollback(err)
+		}
+	}
+	if spec.State.Running {
+		err = run("start", name)
+		if err != nil {
+			return rollback(err)
+		}
+	}
+
+	return run("rm", spec.ID)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fetchedAt is when the containers, images, volumes or networks on screen
+// were fetched from the daemon, in Unix nanoseconds, for the data age of
+// the header. It is set from the commands of the programs too.
+var fetchedAt atomic.Int64
+
+// staleAfter is the age past which the header shows the data age even on
+// a host without a header, in yellow.
+const staleAfter = 30 * time.Second
+
+func markFetched() {
+	fetchedAt.Store(time.Now().UnixNano())
+}
+
+// dataAge returns how old the data on screen is, "" before anything was
+// fetched, and whether it is older than staleAfter.
+func dataAge() (string, bool) {
+	at := fetchedAt.Load()
+	if at == 0 {
+		return "", false
+	}
+	age := time.Since(time.Unix(0, at))
+	return tr("header.age", age.Truncate(time.Second)), age > staleAfter
+}
+
+type ageTickMsg struct{}
+
+// scheduleAgeTick redraws the screen every second so the data age keeps up.
+func scheduleAgeTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return ageTickMsg{}
+	})
+}
+
+// isRefreshKey reports the keys refreshing every list, r too where it
+// isn't bound to an action.
+func isRefreshKey(key string) bool {
+	return key == "f5" || key == "ctrl+r"
+}
+
+type refreshFailedMsg struct {
+	err error
+}
+
+// refreshContainerList fetches the containers again for the list.
+func refreshContainerList(cfg *Config) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := getContainers(cfg)
+		if err != nil {
+			if pingDaemon() != nil {
+				return daemonLostMsg{}
+			}
+			return refreshFailedMsg{err: err}
+		}
+		cacheContainers(containers)
+		return reloadMsg(containers)
+	}
+}
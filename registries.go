@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// registryLogin is one registry the docker CLI holds credentials for.
+type registryLogin struct {
+	Registry string
+	Username string
+	Source   string
+}
+
+// cliAuthConfig is the credentials part of the docker CLI config.json.
+type cliAuthConfig struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// getRegistryLogins lists credentials from config.json and from the
+// credential helpers it delegates to. Helpers that fail are reported as
+// logins with an unknown user rather than hiding the registry.
+func getRegistryLogins() ([]registryLogin, error) {
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cliConfig cliAuthConfig
+	err = json.Unmarshal(data, &cliConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", filepath.Join(dir, "config.json"), err)
+	}
+
+	logins := map[string]registryLogin{}
+	for registry, auth := range cliConfig.Auths {
+		login := registryLogin{Registry: registry, Source: "config.json"}
+		if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+			login.Username, _, _ = strings.Cut(string(decoded), ":")
+		}
+		if auth.Auth == "" && cliConfig.CredsStore != "" {
+			login.Source = cliConfig.CredsStore
+		}
+		logins[registry] = login
+	}
+
+	if cliConfig.CredsStore != "" {
+		for registry, username := range listHelperCredentials(cliConfig.CredsStore) {
+			logins[registry] = registryLogin{Registry: registry, Username: username, Source: cliConfig.CredsStore}
+		}
+	}
+	for registry, helper := range cliConfig.CredHelpers {
+		login := registryLogin{Registry: registry, Source: helper}
+		if username, ok := listHelperCredentials(helper)[registry]; ok {
+			login.Username = username
+		}
+		logins[registry] = login
+	}
+
+	var list []registryLogin
+	for _, login := range logins {
+		list = append(list, login)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Registry < list[j].Registry
+	})
+	return list, nil
+}
+
+// listHelperCredentials runs `docker-credential-<helper> list`, which maps
+// server URLs to usernames without revealing secrets.
+func listHelperCredentials(helper string) map[string]string {
+	output, err := exec.Command("docker-credential-"+helper, "list").Output()
+	if err != nil {
+		return nil
+	}
+
+	var credentials map[string]string
+	if json.Unmarshal(output, &credentials) != nil {
+		return nil
+	}
+	return credentials
+}
+
+// registriesCommand implements `whale registries`: shows where the CLI is
+// logged in and offers to log out of one.
+func registriesCommand() {
+	cfg := currentConfig()
+	requireAction(cfg, "registries")
+
+	logins, err := getRegistryLogins()
+	if err != nil {
+		println(tr("error.registries"), err)
+		os.Exit(1)
+	}
+	if len(logins) == 0 {
+		fmt.Println(tr("registries.none"))
+		return
+	}
+
+	table := [][]string{{tr("registries.registry"), tr("registries.user"), tr("registries.source")}}
+	for _, login := range logins {
+		username := login.Username
+		if username == "" {
+			username = "?"
+		}
+		table = append(table, []string{login.Registry, username, login.Source})
+	}
+	rows := alignColumns(table)
+
+	if !isInteractive() || !isActionAllowed(cfg, "logout") {
+		for _, row := range rows {
+			fmt.Println(row)
+		}
+		return
+	}
+
+	choice, err := chooseFromList(cfg, tr("registries.title"), rows[0], rows[1:])
+	if err != nil {
+		println(tr("error.registries"), err)
+		os.Exit(1)
+	}
+	if choice < 0 {
+		return
+	}
+
+	login := logins[choice]
+	output, err := runWithSpinner(exec.Command("docker", "logout", login.Registry))
+	if err != nil {
+		fmt.Printf("✗ %s: %v: %s\n", login.Registry, err, strings.TrimSpace(string(output)))
+		os.Exit(1)
+	}
+	fmt.Printf("✓ %s\n", tr("registries.loggedOut", login.Registry))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+func renderCursor(cfg *Config) string {
+	render := fmt.Sprintf("\033[%sm>\033[0m", paletteColor(cfg.Ui.CursorColor))
+	return render
+}
+
+func renderColor(s string, color string) string {
+	if color == "" || plainMode {
+		return s
+	}
+	return "\033[" + paletteColor(color) + "m" + s + "\033[0m"
+}
+
+// framePool recycles the buffers the views build their frames in, so that
+// redrawing a long list doesn't grow a new one each time. A strings.Builder
+// can't be reused once String() handed its bytes out, hence bytes.Buffer
+// and a single copy when the frame is done.
+var framePool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// frame is the output of a View under construction.
+type frame struct {
+	*bytes.Buffer
+}
+
+func newFrame() frame {
+	b := framePool.Get().(*bytes.Buffer)
+	b.Reset()
+	return frame{b}
+}
+
+// line writes the parts followed by a newline.
+func (f frame) line(parts ...string) {
+	for _, part := range parts {
+		f.WriteString(part)
+	}
+	f.WriteByte('\n')
+}
+
+// colored writes s in color, as renderColor would return it.
+func (f frame) colored(s string, color string) {
+	if color == "" || plainMode {
+		f.WriteString(s)
+		return
+	}
+	f.WriteString("\033[")
+	f.WriteString(paletteColor(color))
+	f.WriteByte('m')
+	f.WriteString(s)
+	f.WriteString("\033[0m")
+}
+
+// done returns the frame and gives its buffer back to the pool.
+func (f frame) done() string {
+	s := f.String()
+	framePool.Put(f.Buffer)
+	return s
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// hostReport is `whale report --format json`: what the host runs, with the
+// full inspect document of every resource.
+type hostReport struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Host        string               `json:"host"`
+	Daemon      *dockerServerVersion `json:"daemon"`
+	Containers  []json.RawMessage    `json:"containers"`
+	Images      []json.RawMessage    `json:"images"`
+	Volumes     []json.RawMessage    `json:"volumes"`
+	Networks    []json.RawMessage    `json:"networks"`
+
+	// The lists the Markdown report tabulates.
+	containers []Container
+	images     []Image
+	volumes    []Volume
+	networks   []Network
+}
+
+// reportContainer is the subset of the container inspect the Markdown report
+// details.
+type reportContainer struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Image      string   `json:"Image"`
+		Env        []string `json:"Env"`
+		Entrypoint []string `json:"Entrypoint"`
+		Cmd        []string `json:"Cmd"`
+	} `json:"Config"`
+	HostConfig struct {
+		RestartPolicy struct {
+			Name string `json:"Name"`
+		} `json:"RestartPolicy"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// redactedValue replaces environment values unless --with-env is given,
+// since reports end up in tickets.
+const redactedValue = "<redacted>"
+
+// reportCommand implements `whale report [--format markdown|json]
+// [--output FILE] [--with-env]`: a snapshot of the containers, images,
+// volumes and networks of the host, to attach to an incident or handover.
+func reportCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	format := "markdown"
+	output := ""
+	withEnv := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--format", "--output", "-o":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			if args[i-1] == "--format" {
+				format = args[i]
+			} else {
+				output = args[i]
+			}
+		case "--json":
+			format = "json"
+		case "--with-env":
+			withEnv = true
+		}
+	}
+	if format != "markdown" && format != "md" && format != "json" {
+		println(tr("cli.invalidValue", "--format", format))
+		os.Exit(1)
+	}
+
+	requireAction(cfg, "report")
+
+	report, err := gatherReport(cfg, containers, withEnv)
+	if err != nil {
+		println(tr("error.report"), err)
+		os.Exit(1)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			println(tr("error.report"), err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = writeMarkdownReport(w, report)
+	}
+	if err != nil {
+		println(tr("error.report"), err)
+		os.Exit(1)
+	}
+
+	if output != "" {
+		fmt.Println(tr("report.written", output))
+	}
+}
+
+func gatherReport(cfg *Config, containers []Container, withEnv bool) (hostReport, error) {
+	report := hostReport{
+		GeneratedAt: time.Now().UTC(),
+		Host:        activeHost,
+		Daemon:      daemonVersion.Server,
+		containers:  containers,
+	}
+
+	var ids []string
+	for _, container := range containers {
+		ids = append(ids, container.ID)
+	}
+	documents, err := inspectAll("container", ids)
+	if err != nil {
+		return hostReport{}, err
+	}
+	for _, document := range documents {
+		if !withEnv {
+			document = redactEnv(document)
+		}
+		report.Containers = append(report.Containers, document)
+	}
+
+	images, err := getImages(cfg)
+	if err != nil {
+		return hostReport{}, err
+	}
+	report.images = images
+	ids = nil
+	for _, image := range images {
+		ids = append(ids, image.ID)
+	}
+	report.Images, err = inspectAll("image", ids)
+	if err != nil {
+		return hostReport{}, err
+	}
+
+	volumes, err := getVolumes()
+	if err != nil {
+		return hostReport{}, err
+	}
+	report.volumes = volumes
+	ids = nil
+	for _, volume := range volumes {
+		ids = append(ids, volume.Name)
+	}
+	report.Volumes, err = inspectAll("volume", ids)
+	if err != nil {
+		return hostReport{}, err
+	}
+
+	networks, err := getNetworks()
+	if err != nil {
+		return hostReport{}, err
+	}
+	report.networks = networks
+	ids = nil
+	for _, network := range networks {
+		ids = append(ids, network.ID)
+	}
+	report.Networks, err = inspectAll("network", ids)
+	if err != nil {
+		return hostReport{}, err
+	}
+
+	return report, nil
+}
+
+// inspectAll returns the inspect document of each object, with a single
+// `docker <kind> inspect` call.
+func inspectAll(kind string, ids []string) ([]json.RawMessage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	output, err := dockerRead(append([]string{kind, "inspect"}, ids...)...)
+	if err != nil {
+		return nil, fmt.Errorf("error inspecting %ss: %v", kind, err)
+	}
+
+	var documents []json.RawMessage
+	err = json.Unmarshal(output, &documents)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %ss inspect: %v", kind, err)
+	}
+	return documents, nil
+}
+
+// redactEnv keeps the names of the environment variables of a container
+// inspect document but not their values.
+func redactEnv(document json.RawMessage) json.RawMessage {
+	var fields map[string]any
+	if json.Unmarshal(document, &fields) != nil {
+		return document
+	}
+	containerConfig, ok := fields["Config"].(map[string]any)
+	if !ok {
+		return document
+	}
+	env, ok := containerConfig["Env"].([]any)
+	if !ok {
+		return document
+	}
+
+	for i, variable := range env {
+		if s, ok := variable.(string); ok {
+			name, _, _ := strings.Cut(s, "=")
+			env[i] = name + "=" + redactedValue
+		}
+	}
+
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return document
+	}
+	return redacted
+}
+
+func writeMarkdownReport(w io.Writer, report hostReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", tr("report.title", orNone(report.Host)))
+	fmt.Fprintf(&b, "- %s: %s\n", tr("report.generated"), report.GeneratedAt.Format(time.RFC3339))
+	if report.Daemon != nil {
+		fmt.Fprintf(&b, "- %s: %s (API %s, %s/%s)\n", tr("report.daemon"), report.Daemon.Version, report.Daemon.ApiVersion, report.Daemon.Os, report.Daemon.Arch)
+	}
+
+	fmt.Fprintf(&b, "\n## %s (%d)\n\n", tr("tabs.containers"), len(report.containers))
+	rows := [][]string{{tr("column.name"), tr("column.image"), tr("column.status"), tr("column.ports"), tr("column.restarts")}}
+	for _, container := range report.containers {
+		rows = append(rows, []string{container.Name, container.Image, container.Status, container.Ports, fmt.Sprint(container.RestartCount)})
+	}
+	writeMarkdownTable(&b, rows)
+
+	for _, document := range report.Containers {
+		var container reportContainer
+		if json.Unmarshal(document, &container) != nil {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n### %s\n\n", strings.TrimPrefix(container.Name, "/"))
+		fmt.Fprintf(&b, "- %s: `%s`\n", tr("report.image"), container.Config.Image)
+		if command := strings.TrimSpace(strings.Join(append(container.Config.Entrypoint, container.Config.Cmd...), " ")); command != "" {
+			fmt.Fprintf(&b, "- %s: `%s`\n", tr("report.command"), command)
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", tr("report.restartPolicy"), orNone(container.HostConfig.RestartPolicy.Name))
+
+		var networks []string
+		for name, network := range container.NetworkSettings.Networks {
+			networks = append(networks, strings.TrimSpace(name+" "+network.IPAddress))
+		}
+		sort.Strings(networks)
+		if len(networks) > 0 {
+			fmt.Fprintf(&b, "- %s: %s\n", tr("report.networks"), strings.Join(networks, ", "))
+		}
+		for _, mount := range container.Mounts {
+			source := mount.Source
+			if mount.Type == "volume" {
+				source = mount.Name
+			}
+			fmt.Fprintf(&b, "- %s: %s `%s` → `%s`\n", tr("report.mount"), mount.Type, source, mount.Destination)
+		}
+		if len(container.Config.Env) > 0 {
+			fmt.Fprintf(&b, "- %s:\n", tr("report.env"))
+			for _, variable := range container.Config.Env {
+				fmt.Fprintf(&b, "  - `%s`\n", variable)
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## %s (%d)\n\n", tr("tabs.images"), len(report.images))
+	rows = [][]string{{tr("column.repository"), tr("column.tag"), tr("column.id"), tr("column.size"), tr("column.created")}}
+	for _, image := range report.images {
+		rows = append(rows, []string{image.Repository, image.Tag, shortID(strings.TrimPrefix(image.ID, "sha256:")), image.Size, image.Created})
+	}
+	writeMarkdownTable(&b, rows)
+
+	fmt.Fprintf(&b, "\n## %s (%d)\n\n", tr("tabs.volumes"), len(report.volumes))
+	rows = [][]string{{tr("column.name"), tr("column.driver"), tr("column.scope")}}
+	for _, volume := range report.volumes {
+		rows = append(rows, []string{volume.Name, volume.Driver, volume.Scope})
+	}
+	writeMarkdownTable(&b, rows)
+
+	fmt.Fprintf(&b, "\n## %s (%d)\n\n", tr("tabs.networks"), len(report.networks))
+	rows = [][]string{{tr("column.name"), tr("column.id"), tr("column.driver"), tr("column.scope")}}
+	for _, network := range report.networks {
+		rows = append(rows, []string{network.Name, shortID(network.ID), network.Driver, network.Scope})
+	}
+	writeMarkdownTable(&b, rows)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownTable writes a header row followed by the other rows, with
+// pipes escaped inside cells.
+func writeMarkdownTable(b *strings.Builder, rows [][]string) {
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+		if i == 0 {
+			fmt.Fprintf(b, "|%s\n", strings.Repeat(" --- |", len(row)))
+		}
+	}
+}
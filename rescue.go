@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// rescueLabel marks a container recreated by the rescue, holding what it
+// ran before so that ending the rescue can put it back.
+const rescueLabel = "whale.rescue"
+
+// rescueScript keeps the container up doing nothing, and stops at once on
+// docker stop rather than after the grace period.
+const rescueScript = "trap 'exit 0' TERM INT; while :; do sleep 1; done"
+
+type rescueRecord struct {
+	Entrypoint []string `json:"entrypoint"`
+	Cmd        []string `json:"cmd"`
+	Restart    string   `json:"restart"`
+	Retries    int      `json:"retries"`
+}
+
+func isRescued(container Container) bool {
+	return container.hasLabel(rescueLabel)
+}
+
+// rescueContainer takes a crash-looping container out of its loop for
+// investigation: its restart policy is turned off, it is recreated with
+// the same mounts, environment and networks but an idle command, and a
+// shell is opened inside. endRescue brings back its command and policy.
+func rescueContainer(container Container) error {
+	spec, err := inspectSpec(container.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(tr("rescue.plan", container.Name))
+	fmt.Println(renderColor(tr("recreate.warning", container.Name), "33"))
+	fmt.Print(tr("rescue.prompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		fmt.Println(tr("confirm.aborted"))
+		return nil
+	}
+
+	record, _ := json.Marshal(rescueRecord{
+		Entrypoint: spec.Config.Entrypoint,
+		Cmd:        spec.Config.Cmd,
+		Restart:    spec.HostConfig.RestartPolicy.Name,
+		Retries:    spec.HostConfig.RestartPolicy.MaximumRetryCount,
+	})
+
+	// Stop the loop first, the container would otherwise keep restarting
+	// while it is stopped and renamed.
+	err = runDocker("update", "--restart", "no", spec.ID)
+	if err != nil {
+		return err
+	}
+
+	if spec.Config.Labels == nil {
+		spec.Config.Labels = map[string]string{}
+	}
+	spec.Config.Labels[rescueLabel] = string(record)
+	spec.Config.Entrypoint = []string{"sh"}
+	spec.Config.Cmd = []string{"-c", rescueScript}
+	spec.HostConfig.RestartPolicy.Name = "no"
+	spec.HostConfig.RestartPolicy.MaximumRetryCount = 0
+	// Rescuing a running container stops it, a crash-looping one may be
+	// caught between two restarts: start it either way.
+	spec.State.Running = true
+
+	err = recreateContainer(spec, "missing")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s: %s\n", container.Name, tr("rescue.done"))
+
+	return openShell(Container{ID: container.Name, Name: container.Name})
+}
+
+// endRescue recreates a rescued container with the command and restart
+// policy it had before the rescue.
+func endRescue(container Container) error {
+	spec, err := inspectSpec(container.ID)
+	if err != nil {
+		return err
+	}
+
+	var record rescueRecord
+	err = json.Unmarshal([]byte(spec.Config.Labels[rescueLabel]), &record)
+	if err != nil {
+		return fmt.Errorf("error reading the %s label: %v", rescueLabel, err)
+	}
+
+	command := formatCommandLine(append(append([]string{}, record.Entrypoint...), record.Cmd...))
+	fmt.Println(tr("rescue.endPlan", container.Name, command))
+	fmt.Print(tr("recreate.prompt"))
+	answer, _ := plainInput.ReadString('\n')
+	if !isYes(answer) {
+		fmt.Println(tr("confirm.aborted"))
+		return nil
+	}
+
+	delete(spec.Config.Labels, rescueLabel)
+	spec.Config.Entrypoint = record.Entrypoint
+	spec.Config.Cmd = record.Cmd
+	spec.HostConfig.RestartPolicy.Name = record.Restart
+	spec.HostConfig.RestartPolicy.MaximumRetryCount = record.Retries
+	spec.State.Running = true
+
+	err = recreateContainer(spec, "missing")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s: %s\n", container.Name, tr("rescue.ended"))
+	return nil
+}
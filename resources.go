@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// hostResources is the subset of `docker info` describing the engine host.
+type hostResources struct {
+	NCPU     int   `json:"NCPU"`
+	MemTotal int64 `json:"MemTotal"`
+}
+
+// reservation is what one running container was granted. Zero means the
+// container is unbounded.
+type reservation struct {
+	Name              string
+	MemoryLimit       int64
+	MemoryReservation int64
+	CPUs              float64
+}
+
+func getHostResources() (hostResources, error) {
+	var host hostResources
+	output, err := dockerRead("info", "--format", "{{json .}}")
+	if err != nil {
+		return host, err
+	}
+	err = json.Unmarshal(output, &host)
+	return host, err
+}
+
+// inspectCPUs converts --cpus or --cpu-quota/--cpu-period into a CPU count.
+func inspectCPUs(inspect containerInspect) float64 {
+	if inspect.HostConfig.NanoCpus > 0 {
+		return float64(inspect.HostConfig.NanoCpus) / 1e9
+	}
+	if inspect.HostConfig.CpuQuota > 0 {
+		period := inspect.HostConfig.CpuPeriod
+		if period <= 0 {
+			period = 100000
+		}
+		return float64(inspect.HostConfig.CpuQuota) / float64(period)
+	}
+	return 0
+}
+
+func getReservations(containers []Container) ([]reservation, error) {
+	var ids []string
+	for _, container := range containers {
+		if container.State == "running" {
+			ids = append(ids, container.ID)
+		}
+	}
+
+	inspects, err := inspectContainers(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []reservation
+	for _, container := range containers {
+		inspect, ok := inspects[container.ID]
+		if !ok {
+			continue
+		}
+		reservations = append(reservations, reservation{
+			Name:              container.Name,
+			MemoryLimit:       inspect.HostConfig.Memory,
+			MemoryReservation: inspect.HostConfig.MemoryReservation,
+			CPUs:              inspectCPUs(inspect),
+		})
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].Name < reservations[j].Name
+	})
+	return reservations, nil
+}
+
+// resourcesCommand implements `whale resources`, summing what running
+// containers were promised against what the host has, to spot over-commit.
+func resourcesCommand(containers []Container) {
+	cfg := currentConfig()
+	requireAction(cfg, "resources")
+
+	host, err := getHostResources()
+	if err != nil {
+		println(tr("error.getResources"), err)
+		os.Exit(1)
+	}
+
+	reservations, err := getReservations(containers)
+	if err != nil {
+		println(tr("error.getResources"), err)
+		os.Exit(1)
+	}
+
+	unbounded := func(value string, set bool) string {
+		if !set {
+			return "-"
+		}
+		return value
+	}
+
+	var limits, reserved int64
+	var cpus float64
+	unlimited := 0
+	table := [][]string{{tr("column.name"), tr("resources.memLimit"), tr("resources.memReservation"), tr("resources.cpus")}}
+	for _, r := range reservations {
+		limits += r.MemoryLimit
+		reserved += r.MemoryReservation
+		cpus += r.CPUs
+		if r.MemoryLimit == 0 || r.CPUs == 0 {
+			unlimited++
+		}
+
+		table = append(table, []string{
+			r.Name,
+			unbounded(formatBytes(r.MemoryLimit), r.MemoryLimit > 0),
+			unbounded(formatBytes(r.MemoryReservation), r.MemoryReservation > 0),
+			unbounded(fmt.Sprintf("%.2f", r.CPUs), r.CPUs > 0),
+		})
+	}
+	table = append(table, []string{
+		tr("resources.total"),
+		formatBytes(limits),
+		formatBytes(reserved),
+		fmt.Sprintf("%.2f", cpus),
+	})
+
+	for _, row := range alignColumns(table) {
+		fmt.Println(row)
+	}
+
+	fmt.Println()
+	printCommitment(tr("resources.memLimit"), float64(limits), float64(host.MemTotal), formatBytes(limits), formatBytes(host.MemTotal))
+	printCommitment(tr("resources.memReservation"), float64(reserved), float64(host.MemTotal), formatBytes(reserved), formatBytes(host.MemTotal))
+	printCommitment(tr("resources.cpus"), cpus, float64(host.NCPU), fmt.Sprintf("%.2f", cpus), fmt.Sprint(host.NCPU))
+	if unlimited > 0 {
+		fmt.Println(renderColor(tr("resources.unbounded", unlimited), "2"))
+	}
+}
+
+// printCommitment prints one "used of total" line, red past 100%.
+func printCommitment(label string, used float64, total float64, usedText string, totalText string) {
+	if total <= 0 {
+		return
+	}
+
+	percent := used / total * 100
+	line := tr("resources.commitment", label, usedText, totalText, percent)
+	switch {
+	case percent > 100:
+		line = renderColor(line+" "+tr("resources.overcommitted"), "31")
+	case percent > 80:
+		line = renderColor(line, "33")
+	}
+	fmt.Println(line)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const readAttempts = 4
+
+// transientErrors are daemon errors worth retrying, typically seen while
+// Docker Desktop is still starting or the engine restarts.
+var transientErrors = []string{
+	"eof",
+	"connection reset",
+	"broken pipe",
+	"i/o timeout",
+	"connection refused",
+	"cannot connect to the docker daemon",
+	"is the docker daemon running",
+	"error during connect",
+}
+
+// dockerRead runs an idempotent docker command (ls, inspect, stats...) and
+// returns its stdout, retrying with exponential backoff on transient
+// errors. Retries are reported as warnings instead of failing right away.
+func dockerRead(args ...string) ([]byte, error) {
+	return retryRead(strings.Join(args[:min(2, len(args))], " "), func() ([]byte, error) {
+		cmd := exec.Command("docker", args...)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err := runTimed(cmd)
+		// Kept for errorSummary, like cmd.Output does.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = stderr.Bytes()
+		}
+		return stdout.Bytes(), err
+	})
+}
+
+// retryRead calls read until it succeeds, fails for good or runs out of
+// attempts, what naming the read in the warnings.
+func retryRead(what string, read func() ([]byte, error)) ([]byte, error) {
+	backoff := 250 * time.Millisecond
+
+	for attempt := 1; ; attempt++ {
+		output, err := read()
+		if err == nil || attempt == readAttempts || !isTransient(err) {
+			return output, err
+		}
+
+		warn(tr("retry.warning", what, errorSummary(err), attempt, readAttempts-1))
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func isTransient(err error) bool {
+	message := strings.ToLower(errorSummary(err))
+	for _, transient := range transientErrors {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorSummary prefers the first line docker printed on stderr over the
+// bare exit status.
+func errorSummary(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		line, _, _ := strings.Cut(strings.TrimSpace(string(exitErr.Stderr)), "\n")
+		return line
+	}
+	return err.Error()
+}
+
+// warn prints a warning on stderr, unless a full-screen program is drawing
+// on the terminal, in which case it would garble the screen.
+func warn(message string) {
+	if programRunning {
+		return
+	}
+	fmt.Fprintln(os.Stderr, renderColor(tr("warning", message), "33"))
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// opsFile is what `whale run-file` executes: steps run in order, stopping
+// at the first that fails unless continueOnError is set.
+type opsFile struct {
+	ContinueOnError bool      `json:"continueOnError"`
+	Steps           []opsStep `json:"steps"`
+}
+
+// opsStep is one action of an ops file. Action is a lifecycle verb (start,
+// stop, restart, pause, unpause, kill, rm), wait, pull, prune or macro.
+// rm also removes the listed volumes and networks.
+type opsStep struct {
+	Action     string   `json:"action"`
+	Containers []string `json:"containers"`
+	Images     []string `json:"images"`
+	Volumes    []string `json:"volumes"`
+	Networks   []string `json:"networks"`
+	// What is what prune removes: containers, images, volumes or networks.
+	What string `json:"what"`
+	// Macro is the name of the macro to play on the containers.
+	Macro string `json:"macro"`
+	// Timeout bounds wait, like 90s, wait.timeout by default.
+	Timeout string `json:"timeout"`
+}
+
+// opsPermissions maps the actions of ops files to the action rules checked
+// before running them.
+var opsPermissions = map[string]string{
+	"wait":  "wait",
+	"pull":  "pullImage",
+	"prune": "prune",
+	"macro": "playMacro",
+}
+
+// opsPruneKinds are what a prune step can remove, the way `whale prune`
+// does: one item at a time, protected images kept.
+var opsPruneKinds = []string{"containers", "images", "volumes", "networks"}
+
+// opsResult is the outcome of a step on one of its targets.
+type opsResult struct {
+	target string
+	done   string
+	err    error
+}
+
+func readOpsFile(cfg *Config, path string) (opsFile, error) {
+	var ops opsFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ops, err
+	}
+
+	if filepath.Ext(path) != ".json" {
+		document, err := parseYAML(string(data))
+		if err != nil {
+			return ops, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		data, err = json.Marshal(document)
+		if err != nil {
+			return ops, err
+		}
+	}
+
+	err = json.Unmarshal(data, &ops)
+	if err != nil {
+		return ops, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	for i, step := range ops.Steps {
+		err := checkOpsStep(cfg, step)
+		if err != nil {
+			return ops, fmt.Errorf("%s, step %d: %v", path, i+1, err)
+		}
+	}
+	return ops, nil
+}
+
+// checkOpsStep rejects the steps that can't run before anything does, so
+// that a typo at the end of a file doesn't leave it half applied.
+func checkOpsStep(cfg *Config, step opsStep) error {
+	switch {
+	case step.Action == "rm":
+		if len(step.Containers)+len(step.Volumes)+len(step.Networks) == 0 {
+			return fmt.Errorf("rm needs containers, volumes or networks")
+		}
+		if len(step.Volumes) > 0 && !isActionAllowed(cfg, "removeVolume") {
+			return fmt.Errorf("%s", tr("permissions.denied", "removeVolume"))
+		}
+		if len(step.Networks) > 0 && !isActionAllowed(cfg, "removeNetwork") {
+			return fmt.Errorf("%s", tr("permissions.denied", "removeNetwork"))
+		}
+		if len(step.Containers) == 0 {
+			return nil
+		}
+	case isLifecycleVerb(step.Action), step.Action == "wait":
+		if len(step.Containers) == 0 {
+			return fmt.Errorf("%s needs containers", step.Action)
+		}
+		if _, err := time.ParseDuration(step.Timeout); step.Timeout != "" && err != nil {
+			return fmt.Errorf("invalid timeout %q", step.Timeout)
+		}
+	case step.Action == "pull":
+		if len(step.Images) == 0 {
+			return fmt.Errorf("pull needs images")
+		}
+	case step.Action == "prune":
+		if !containsString(opsPruneKinds, step.What) {
+			return fmt.Errorf("prune needs what: containers, images, volumes or networks")
+		}
+	case step.Action == "macro":
+		if len(cfg.Macros[step.Macro]) == 0 {
+			return fmt.Errorf("%s", tr("macro.unknown", step.Macro))
+		}
+		if len(step.Containers) == 0 {
+			return fmt.Errorf("macro needs containers")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+
+	permission := step.Action
+	if name, ok := opsPermissions[step.Action]; ok {
+		permission = name
+	}
+	if !isActionAllowed(cfg, permission) {
+		return fmt.Errorf("%s", tr("permissions.denied", permission))
+	}
+	return nil
+}
+
+// describe is the step as shown before it runs.
+func (step opsStep) describe() string {
+	switch step.Action {
+	case "pull":
+		return "pull " + strings.Join(step.Images, ", ")
+	case "prune":
+		return "prune " + step.What
+	case "macro":
+		return fmt.Sprintf("macro %s on %s", step.Macro, strings.Join(step.Containers, ", "))
+	}
+	targets := append(append(append([]string{}, step.Containers...), step.Volumes...), step.Networks...)
+	return step.Action + " " + strings.Join(targets, ", ")
+}
+
+// runOpsStep runs a step on each of its targets, carrying on with the
+// other targets when one fails.
+func runOpsStep(cfg *Config, step opsStep) []opsResult {
+	switch step.Action {
+	case "pull":
+		var results []opsResult
+		for _, image := range step.Images {
+			results = append(results, opsResult{target: image, done: tr("runFile.pulled"), err: runDocker("pull", image)})
+		}
+		return results
+	case "prune":
+		return []opsResult{{target: step.What, done: tr("runFile.pruned"), err: opsPrune(cfg, step.What)}}
+	}
+
+	var removed []opsResult
+	for _, volume := range step.Volumes {
+		removed = append(removed, opsResult{target: volume, done: tr("runFile.removed"), err: runDocker("volume", "rm", volume)})
+	}
+	for _, network := range step.Networks {
+		removed = append(removed, opsResult{target: network, done: tr("runFile.removed"), err: runDocker("network", "rm", network)})
+	}
+	if len(step.Containers) == 0 {
+		return removed
+	}
+
+	// Earlier steps changed the containers, so they are listed again.
+	containers, err := getContainers(cfg)
+	if err != nil {
+		return []opsResult{{target: strings.Join(step.Containers, ", "), err: err}}
+	}
+
+	var results []opsResult
+	for _, query := range step.Containers {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			results = append(results, opsResult{target: query, err: err})
+			continue
+		}
+		result := opsResult{target: container.Name}
+
+		switch {
+		case step.Action == "wait":
+			timeout := time.Duration(max(cfg.Wait.Timeout, 1)) * time.Second
+			if step.Timeout != "" {
+				timeout, _ = time.ParseDuration(step.Timeout)
+			}
+			result.done = tr("wait.ready")
+			result.err = waitReady(container, timeout)
+		case step.Action == "macro":
+			result.done = tr("macro.played", step.Macro)
+			result.err = playMacro(cfg, step.Macro, cfg.Macros[step.Macro], container)
+		default:
+			result.done = tr("lifecycle.done." + lifecycleCommands[step.Action])
+			if reason := actionUnavailable(step.Action, container); reason != "" {
+				result.err = fmt.Errorf("%s", reason)
+			} else {
+				result.err = runLifecycle(step.Action, container)
+			}
+		}
+		results = append(results, result)
+	}
+	return append(results, removed...)
+}
+
+// opsPrune removes what `whale prune` lists of kind, without asking.
+func opsPrune(cfg *Config, kind string) error {
+	items, err := pruneItems(cfg, []string{kind})
+	if err != nil {
+		return err
+	}
+	if failed := removePruneItems(items); failed > 0 {
+		return fmt.Errorf("%s", tr("prune.failed", failed, len(items)))
+	}
+	return nil
+}
+
+// runFileCommand implements `whale run-file FILE [--dry-run]`: executes
+// the steps of a YAML or JSON ops file with the result of each, and exits
+// with 1 when one failed. --dry-run prints the steps without running them.
+func runFileCommand(args []string) {
+	cfg := currentConfig()
+	dryRun := false
+	path := ""
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			path = arg
+		}
+	}
+	if path == "" {
+		println(tr("runFile.usage"))
+		os.Exit(1)
+	}
+
+	ops, err := readOpsFile(cfg, path)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+	os.Exit(runOps(cfg, ops, dryRun))
+}
+
+// runOps runs the steps of ops with the result of each and returns the
+// exit code: 1 when a step failed, 0 otherwise.
+func runOps(cfg *Config, ops opsFile, dryRun bool) int {
+	failed := 0
+	for i, step := range ops.Steps {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(ops.Steps), step.describe())
+		if dryRun {
+			continue
+		}
+
+		stepFailed := false
+		for _, result := range runOpsStep(cfg, step) {
+			if result.err != nil {
+				stepFailed = true
+				fmt.Println(renderColor(fmt.Sprintf("  ✗ %s: %v", result.target, result.err), "31"))
+				continue
+			}
+			fmt.Printf("  ✓ %s: %s\n", result.target, result.done)
+		}
+		if !stepFailed {
+			continue
+		}
+
+		failed++
+		if !ops.ContinueOnError {
+			fmt.Println(tr("runFile.stopped", len(ops.Steps)-i-1))
+			return 1
+		}
+	}
+
+	if dryRun {
+		fmt.Println(tr("runFile.dryRun"))
+		return 0
+	}
+	fmt.Println(tr("runFile.summary", len(ops.Steps), len(ops.Steps)-failed, failed))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sanityScript prints what the process of the container actually gets, as
+// key=value lines, with the tools any image has.
+const sanityScript = `echo "tz=${TZ:-}"
+echo "date=$(date '+%Y-%m-%d %H:%M:%S %Z %z' 2>/dev/null)"
+[ -L /etc/localtime ] && echo "localtime=$(readlink /etc/localtime)"
+[ -f /etc/timezone ] && echo "timezone=$(cat /etc/timezone)"
+echo "lang=${LC_ALL:-${LANG:-}}"
+echo "id=$(id 2>/dev/null)"
+echo "pwd=$(pwd)"
+echo "nofile=$(ulimit -n 2>/dev/null)"
+echo "nproc=$(ulimit -u 2>/dev/null)"`
+
+// sanityConfig is the part of the inspect document the panel shows.
+type sanityConfig struct {
+	Config struct {
+		Env        []string `json:"Env"`
+		User       string   `json:"User"`
+		WorkingDir string   `json:"WorkingDir"`
+	} `json:"Config"`
+	HostConfig struct {
+		Ulimits []struct {
+			Name string `json:"Name"`
+			Soft int64  `json:"Soft"`
+			Hard int64  `json:"Hard"`
+		} `json:"Ulimits"`
+	} `json:"HostConfig"`
+}
+
+func (c sanityConfig) env(name string) string {
+	for _, entry := range c.Config.Env {
+		if key, value, _ := strings.Cut(entry, "="); key == name {
+			return value
+		}
+	}
+	return ""
+}
+
+func (c sanityConfig) ulimit(name string) string {
+	for _, limit := range c.HostConfig.Ulimits {
+		if limit.Name == name {
+			return fmt.Sprintf("%d:%d", limit.Soft, limit.Hard)
+		}
+	}
+	return ""
+}
+
+// probeSanity runs sanityScript in the container. Images without sh get
+// the configured side of the panel alone.
+func probeSanity(container Container) (map[string]string, error) {
+	output, err := runChild(exec.Command("docker", "exec", container.ID, "sh", "-c", sanityScript), true)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	probed := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			probed[key] = strings.TrimSpace(value)
+		}
+	}
+	return probed, nil
+}
+
+// showSanity prints the timezone, locale, user, working directory and
+// limits of the container as configured and, when it runs, as its
+// processes see them, with the usual surprises flagged.
+func showSanity(container Container) error {
+	output, err := cachedInspect(container.ID)
+	if err != nil {
+		return err
+	}
+	var config sanityConfig
+	err = json.Unmarshal(output, &config)
+	if err != nil {
+		return err
+	}
+
+	probed := map[string]string{}
+	if container.State == "running" {
+		probed, err = probeSanity(container)
+		if err != nil {
+			fmt.Println(renderColor(tr("sanity.probeFailed", err), "33"))
+			probed = map[string]string{}
+		}
+	} else {
+		fmt.Println(tr("sanity.notRunning", container.Name))
+	}
+
+	configured := func(name, value string) string {
+		if value == "" {
+			return "-"
+		}
+		if name == "" {
+			return value
+		}
+		return name + "=" + value
+	}
+	timezone := probed["date"]
+	if zone := probed["timezone"] + probed["localtime"]; zone != "" {
+		timezone += " (" + strings.TrimPrefix(zone, "/usr/share/zoneinfo/") + ")"
+	}
+	lang := config.env("LC_ALL")
+	langName := "LC_ALL"
+	if lang == "" {
+		lang, langName = config.env("LANG"), "LANG"
+	}
+
+	table := [][]string{
+		{"", tr("sanity.configured"), tr("sanity.probed")},
+		{tr("sanity.timezone"), configured("TZ", config.env("TZ")), timezone},
+		{tr("sanity.locale"), configured(langName, lang), probed["lang"]},
+		{tr("sanity.user"), configured("", config.Config.User), probed["id"]},
+		{tr("sanity.workdir"), configured("", config.Config.WorkingDir), probed["pwd"]},
+		{tr("sanity.openFiles"), configured("", config.ulimit("nofile")), probed["nofile"]},
+		{tr("sanity.processes"), configured("", config.ulimit("nproc")), probed["nproc"]},
+	}
+	fmt.Println(tr("sanity.title", container.Name))
+	for _, row := range alignColumns(table) {
+		fmt.Println("  " + strings.TrimRight(row, " "))
+	}
+
+	var warnings []string
+	if config.env("TZ") == "" && probed["timezone"]+probed["localtime"] == "" {
+		warnings = append(warnings, tr("sanity.utc"))
+	}
+	if lang == "" && probed["lang"] == "" {
+		warnings = append(warnings, tr("sanity.noLocale"))
+	}
+	if config.Config.User == "" || config.Config.User == "root" || config.Config.User == "0" || strings.HasPrefix(probed["id"], "uid=0(") {
+		warnings = append(warnings, tr("sanity.root"))
+	}
+	for _, warning := range warnings {
+		fmt.Println(renderColor("⚠ "+warning, "33"))
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchLimit is how many results the search screen shows at most, the
+// query is to be refined past that.
+const searchLimit = 20
+
+// searchItem is a container, image, volume or network the search matches
+// against. Key is what its tab finds it by.
+type searchItem struct {
+	Tab    string
+	Key    string
+	Name   string
+	ID     string
+	Labels map[string]string
+}
+
+// match returns what of the item matches query, its name, the start of its
+// ID or one of its labels, and false when nothing does.
+func (item searchItem) match(query string) (string, bool) {
+	query = strings.ToLower(query)
+	if strings.Contains(strings.ToLower(item.Name), query) {
+		return "", true
+	}
+	if id := strings.TrimPrefix(item.ID, "sha256:"); len(query) >= 2 && strings.HasPrefix(id, query) {
+		return shortID(id), true
+	}
+	keys := make([]string, 0, len(item.Labels))
+	for key := range item.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		label := key + "=" + item.Labels[key]
+		if strings.Contains(strings.ToLower(label), query) {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// searchItems lists everything the search covers. A kind that can't be
+// listed is left out rather than failing the search.
+func searchItems(cfg *Config) []searchItem {
+	var items []searchItem
+	if containers, err := listContainers(cfg); err == nil {
+		for _, container := range containers {
+			items = append(items, searchItem{Tab: "containers", Key: container.ID, Name: container.Name, ID: container.ID, Labels: container.Labels})
+		}
+	}
+	if images, err := getImages(cfg); err == nil {
+		labels := imageLabels(images)
+		for _, image := range images {
+			items = append(items, searchItem{Tab: "images", Key: image.ID + " " + image.Reference(), Name: image.Reference(), ID: image.ID, Labels: labels[image.ID]})
+		}
+	}
+	if volumes, err := getVolumes(); err == nil {
+		for _, volume := range volumes {
+			items = append(items, searchItem{Tab: "volumes", Key: volume.Name, Name: volume.Name, Labels: volume.Labels})
+		}
+	}
+	if networks, err := getNetworks(); err == nil {
+		for _, network := range networks {
+			items = append(items, searchItem{Tab: "networks", Key: network.ID, Name: network.Name, ID: network.ID, Labels: network.Labels})
+		}
+	}
+	return items
+}
+
+// imageLabels maps image IDs to their labels, which `docker image ls`
+// doesn't give, nil when they can't be inspected.
+func imageLabels(images []Image) map[string]map[string]string {
+	if len(images) == 0 {
+		return nil
+	}
+	args := []string{"image", "inspect", "--format", "{{.Id}}\t{{json .Config.Labels}}"}
+	for _, image := range images {
+		args = append(args, image.ID)
+	}
+	output, err := dockerRead(args...)
+	if err != nil {
+		return nil
+	}
+
+	labels := map[string]map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		id, data, _ := strings.Cut(line, "\t")
+		var imageLabels map[string]string
+		if json.Unmarshal([]byte(data), &imageLabels) == nil {
+			labels[id] = imageLabels
+		}
+	}
+	return labels
+}
+
+// searchView is the ctrl+f screen of the dashboard: a query, and what it
+// matches among all the resources in one list.
+type searchView struct {
+	config  *Config
+	items   []searchItem
+	query   string
+	matches []searchItem
+	details []string
+	cursor  int
+	chosen  *searchItem
+}
+
+func (view *searchView) filter() {
+	view.matches, view.details = nil, nil
+	view.cursor = 0
+	if strings.TrimSpace(view.query) == "" {
+		return
+	}
+	for _, item := range view.items {
+		if detail, ok := item.match(strings.TrimSpace(view.query)); ok {
+			view.matches = append(view.matches, item)
+			view.details = append(view.details, detail)
+		}
+	}
+}
+
+func (view searchView) Init() tea.Cmd {
+	return nil
+}
+
+func (view searchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return view, nil
+	}
+
+	switch key.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return view, tea.Quit
+	case tea.KeyUp:
+		if view.cursor > 0 {
+			view.cursor--
+		}
+	case tea.KeyDown:
+		if view.cursor < min(len(view.matches), searchLimit)-1 {
+			view.cursor++
+		}
+	case tea.KeyEnter:
+		if len(view.matches) > 0 {
+			view.chosen = &view.matches[view.cursor]
+			return view, tea.Quit
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(view.query); len(runes) > 0 {
+			view.query = string(runes[:len(runes)-1])
+			view.filter()
+		}
+	case tea.KeyCtrlU:
+		view.query = ""
+		view.filter()
+	case tea.KeySpace:
+		view.query += " "
+		view.filter()
+	case tea.KeyRunes:
+		view.query += string(key.Runes)
+		view.filter()
+	}
+	return view, nil
+}
+
+func (view searchView) View() string {
+	s := newFrame()
+	s.WriteString(renderHeader(view.config))
+	s.line(tr("search.title"), "\n")
+	s.line(renderCursor(view.config), " ", view.query, "_", "\n")
+
+	switch {
+	case strings.TrimSpace(view.query) == "":
+	case len(view.matches) == 0:
+		s.colored(tr("search.none"), "2")
+		s.WriteByte('\n')
+	default:
+		table := [][]string{{tr("search.kind"), tr("column.name"), tr("search.match")}}
+		for i, item := range view.matches[:min(len(view.matches), searchLimit)] {
+			table = append(table, []string{tr("tabs." + item.Tab), item.Name, view.details[i]})
+		}
+		rows := alignColumns(table)
+		s.line("  ", rows[0])
+		for i, row := range rows[1:] {
+			if view.cursor == i {
+				s.line(renderCursor(view.config), " ", renderActionSelected(view.config, row, true))
+			} else {
+				s.line("  ", row)
+			}
+		}
+		if len(view.matches) > searchLimit {
+			s.colored(tr("search.more", len(view.matches)-searchLimit), "2")
+			s.WriteByte('\n')
+		}
+	}
+
+	s.WriteByte('\n')
+	s.colored(tr("search.help"), "2")
+	s.WriteByte('\n')
+	return s.done()
+}
+
+// tabFocus is the Key of the item the next tab of the dashboard opens on,
+// the one chosen in the search.
+var tabFocus string
+
+// takeFocus returns the index of the item of keys the tab opens on, given
+// as the search left it, or fallback, and forgets it.
+func takeFocus(keys []string, fallback int) int {
+	defer func() { tabFocus = "" }()
+	for i, key := range keys {
+		if tabFocus != "" && key == tabFocus {
+			return i
+		}
+	}
+	return fallback
+}
+
+// searchTab shows the search screen and returns the tab of the chosen
+// result, opened on it, or from when the search is left.
+func searchTab(from string) string {
+	cfg := currentConfig()
+	finalModel, err := runProgram(searchView{config: cfg, items: searchItems(cfg)})
+	if err != nil {
+		println(fmt.Sprintf("%s %v", tr("error.search"), err))
+		return from
+	}
+
+	chosen := finalModel.(searchView).chosen
+	if chosen == nil {
+		return from
+	}
+	tabFocus = chosen.Key
+	return chosen.Tab
+}
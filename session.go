@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// sessionPath is the file --record writes the session to, "" when not
+// recording.
+var sessionPath string
+
+// sessionEntry is an action run while recording and how it went.
+type sessionEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	// Kind is container, image, volume or network.
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	// Result is "ok", "cancelled" or the error.
+	Result string `json:"result"`
+}
+
+// session is what --record writes: the host the actions ran against and
+// the actions in order.
+type session struct {
+	Host    string         `json:"host"`
+	Started time.Time      `json:"started"`
+	Entries []sessionEntry `json:"entries"`
+}
+
+var recording *session
+
+// recordAction adds the outcome of an action to the session, rewriting the
+// file each time so that it is complete even if whale is killed.
+func recordAction(action string, kind string, target string, err error) {
+	if sessionPath == "" || action == "" || action == "exit" {
+		return
+	}
+	if recording == nil {
+		recording = &session{Host: currentDockerContext(), Started: time.Now()}
+	}
+
+	result := "ok"
+	switch {
+	case errors.Is(err, errCancelled):
+		result = "cancelled"
+	case err != nil:
+		result = err.Error()
+	}
+	recording.Entries = append(recording.Entries, sessionEntry{Time: time.Now(), Action: canonicalAction(action), Kind: kind, Target: target, Result: result})
+
+	data, _ := json.MarshalIndent(recording, "", "  ")
+	if err := os.WriteFile(sessionPath, append(data, '\n'), 0644); err != nil {
+		warn(tr("session.writeFailed", sessionPath, err))
+	}
+}
+
+// replayStep turns an entry into the step of an ops file doing it again,
+// false for the actions that can't be replayed unattended: shells, logs,
+// forms and the like.
+func replayStep(entry sessionEntry) (opsStep, bool) {
+	switch {
+	case entry.Kind == "container" && isLifecycleVerb(entry.Action):
+		return opsStep{Action: entry.Action, Containers: []string{entry.Target}}, true
+	case entry.Action == "pullImage":
+		return opsStep{Action: "pull", Images: []string{entry.Target}}, true
+	case entry.Action == "removeVolume":
+		return opsStep{Action: "rm", Volumes: []string{entry.Target}}, true
+	case entry.Action == "removeNetwork":
+		return opsStep{Action: "rm", Networks: []string{entry.Target}}, true
+	}
+	return opsStep{}, false
+}
+
+// recordPruned adds a volume or network a prune removed to the session on
+// its own. The prune isn't replayed as such, on the day of the replay it
+// would also remove what the user had left out.
+func recordPruned(item pruneItem) {
+	switch item.Kind {
+	case "volume":
+		recordAction("removeVolume", "volume", item.Args[len(item.Args)-1], nil)
+	case "network":
+		recordAction("removeNetwork", "network", item.Name, nil)
+	}
+}
+
+// replayCommand implements `whale replay FILE [--dry-run]`: runs again the
+// actions of a recorded session that succeeded, against the active host,
+// like the steps of `whale run-file`. --dry-run prints them, with the
+// ones left out and why, without running anything.
+func replayCommand(args []string) {
+	cfg := currentConfig()
+	dryRun := false
+	path := ""
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			path = arg
+		}
+	}
+	if path == "" {
+		println(tr("session.usage"))
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+	var recorded session
+	err = json.Unmarshal(data, &recorded)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("session.parseFailed", path, err))
+		os.Exit(1)
+	}
+
+	fmt.Println(tr("session.header", recorded.Host, recorded.Started.Format("2006-01-02 15:04"), currentDockerContext()))
+	var ops opsFile
+	for _, entry := range recorded.Entries {
+		step, ok := replayStep(entry)
+		switch {
+		case entry.Result != "ok":
+			fmt.Println(renderColor(tr("session.skipFailed", entry.Action, entry.Target, entry.Result), "2"))
+		case !ok:
+			fmt.Println(renderColor(tr("session.skipAction", entry.Action, entry.Target), "2"))
+		default:
+			if err := checkOpsStep(cfg, step); err != nil {
+				fmt.Fprintln(os.Stderr, tr("session.stepInvalid", entry.Action, entry.Target, err))
+				os.Exit(1)
+			}
+			ops.Steps = append(ops.Steps, step)
+		}
+	}
+	if len(ops.Steps) == 0 {
+		fmt.Println(tr("session.nothing"))
+		return
+	}
+
+	os.Exit(runOps(cfg, ops, dryRun))
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// openShell drops the user into an interactive shell in a running
+// container, bash when the image has it, sh otherwise.
+func openShell(container Container) error {
+	cmd := exec.Command("docker", "exec", "-it", container.ID, "sh", "-c", "command -v bash >/dev/null && exec bash || exec sh")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
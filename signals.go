@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// children are the docker commands whale is waiting for, killed when whale
+// is interrupted so that none outlives it.
+var children = struct {
+	sync.Mutex
+	cmds map[*exec.Cmd]bool
+}{cmds: map[*exec.Cmd]bool{}}
+
+// runChild runs cmd like cmd.Run, or with its combined output when combined
+// is set, killing it if whale receives a signal meanwhile or its timeout
+// expires.
+func runChild(cmd *exec.Cmd, combined bool) ([]byte, error) {
+	children.Lock()
+	children.cmds[cmd] = true
+	children.Unlock()
+	defer func() {
+		children.Lock()
+		delete(children.cmds, cmd)
+		children.Unlock()
+	}()
+
+	if !combined {
+		return nil, runTimed(cmd)
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := runTimed(cmd)
+	return output.Bytes(), err
+}
+
+// childOutput is runChild returning the stdout of cmd alone, with stderr
+// kept in the exit error, like cmd.Output.
+func childOutput(cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	_, err := runChild(cmd, false)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+func killChildren() {
+	children.Lock()
+	defer children.Unlock()
+	for cmd := range children.cmds {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+	}
+}
+
+// restoreTerminal undoes what a screen may have left behind: the spinner
+// line, the alternate screen and the hidden cursor.
+func restoreTerminal() {
+	if !isTerminal(os.Stderr) {
+		return
+	}
+	fmt.Fprint(os.Stderr, "\r\033[K\033[?1049l\033[?25h")
+}
+
+// signalExitCodes are the usual shell codes, 128 plus the signal number.
+var signalExitCodes = map[os.Signal]int{
+	syscall.SIGHUP:  129,
+	os.Interrupt:    130,
+	syscall.SIGTERM: 143,
+}
+
+// handleSignals makes SIGINT, SIGTERM and SIGHUP stop whale cleanly: the
+// running docker commands are killed and the terminal restored. A screen
+// being shown quits on its own first, bubbletea restores the raw mode.
+func handleSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	go func() {
+		received := <-signals
+		killChildren()
+
+		deadline := time.Now().Add(time.Second)
+		for programRunning && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		restoreTerminal()
+		os.Exit(signalExitCodes[received])
+	}()
+}
+
+// recoverTerminal is deferred by main: on a panic, it kills the docker
+// commands and restores the terminal before the panic is reported.
+func recoverTerminal() {
+	if r := recover(); r != nil {
+		killChildren()
+		restoreTerminal()
+		panic(r)
+	}
+}
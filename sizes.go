@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sizesMsg carries the disk usage of every container, keyed by full ID.
+type sizesMsg map[string]containerSize
+
+type containerSize struct {
+	Rw     int64
+	RootFs int64
+}
+
+// getContainerSizes asks the daemon for the writable layer of each
+// container. Computing it walks the overlay filesystem, so it is only done
+// when the size column is shown, and off the UI thread.
+func getContainerSizes() (map[string]containerSize, error) {
+	output, err := dockerRead("container", "ls", "-a", "--size", "--no-trunc", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]containerSize)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var ps struct {
+			ID   string `json:"ID"`
+			Size string `json:"Size"`
+		}
+		if json.Unmarshal([]byte(line), &ps) != nil {
+			continue
+		}
+		sizes[ps.ID] = parseContainerSize(ps.Size)
+	}
+	return sizes, nil
+}
+
+// parseContainerSize reads docker's "1.09kB (virtual 187MB)" size column.
+func parseContainerSize(s string) containerSize {
+	rw, virtual, _ := strings.Cut(s, "(")
+	virtual = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(virtual), "virtual")), ")")
+	return containerSize{
+		Rw:     parseBytes(rw),
+		RootFs: parseBytes(strings.TrimSpace(virtual)),
+	}
+}
+
+func fetchSizes() tea.Cmd {
+	return func() tea.Msg {
+		sizes, err := getContainerSizes()
+		if err != nil {
+			return sizesMsg(nil)
+		}
+		return sizesMsg(sizes)
+	}
+}
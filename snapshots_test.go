@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/abroudoux/whale/pkg/whale"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata/snapshots")
+
+// snapshotScenario drives one screen with fixture data instead of the
+// daemon: the keys are sent one by one and the frame after each of them is
+// compared with the golden file next to the scenario.
+type snapshotScenario struct {
+	// Screen is "list", "actions" or "logs".
+	Screen     string      `json:"screen"`
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	Containers []Container `json:"containers"`
+	// Logs are the log lines of the logs screen, and of the details panel
+	// of the list for whichever container is under the cursor.
+	Logs []string `json:"logs"`
+	Keys []string `json:"keys"`
+}
+
+// fakeDocker answers the docker client with the fixtures of a scenario,
+// noting the actions it is asked for in actions when set.
+type fakeDocker struct {
+	containers []Container
+	logs       []string
+	actions    *[]string
+}
+
+func (fake fakeDocker) List(ctx context.Context, options whale.ListOptions) ([]whale.Container, error) {
+	containers := make([]whale.Container, len(fake.containers))
+	for i, container := range fake.containers {
+		containers[i] = whale.Container{ID: container.ID, Name: container.Name, Image: container.Image, Command: container.Command, State: container.State, Status: container.Status, Ports: container.Ports, Labels: container.Labels, CreatedAt: container.CreatedAt, RunningFor: container.Created}
+	}
+	return containers, nil
+}
+
+func (fake fakeDocker) Action(ctx context.Context, action whale.Action, ids ...string) error {
+	if fake.actions != nil {
+		*fake.actions = append(*fake.actions, string(action)+" "+strings.Join(ids, " "))
+	}
+	return nil
+}
+
+func (fake fakeDocker) Watch(ctx context.Context, options whale.WatchOptions) (<-chan whale.Event, <-chan error) {
+	events := make(chan whale.Event)
+	close(events)
+	return events, make(chan error, 1)
+}
+
+func (fake fakeDocker) Logs(ctx context.Context, id string, options whale.LogOptions) (io.ReadCloser, error) {
+	lines := fake.logs
+	if options.Tail > 0 {
+		lines = lines[max(len(lines)-options.Tail, 0):]
+	}
+	return io.NopCloser(strings.NewReader(strings.Join(lines, "\n"))), nil
+}
+
+// snapshotScreens build the model of each screen from a scenario, through
+// the same constructors the program uses, with the default config.
+var snapshotScreens = map[string]func(snapshotScenario) (tea.Model, error){
+	"list": func(scenario snapshotScenario) (tea.Model, error) {
+		menu := initialContainerModel(defaultConfig(), scenario.Containers)
+		menu.lastAction = ""
+		return menu, nil
+	},
+	"actions": func(scenario snapshotScenario) (tea.Model, error) {
+		if len(scenario.Containers) == 0 {
+			return nil, fmt.Errorf("the actions screen needs a container")
+		}
+		return initialActionModel(defaultConfig(), scenario.Containers[0]), nil
+	},
+	"logs": func(scenario snapshotScenario) (tea.Model, error) {
+		var model tea.Model = newStreamView(defaultConfig(), "logs", exec.Command("true"))
+		for _, line := range scenario.Logs {
+			model, _ = model.Update(streamLineMsg(line))
+		}
+		model, _ = model.Update(streamEndMsg{})
+		return model, nil
+	},
+}
+
+// ansiPattern matches the escape sequences of the frames, left out of the
+// golden files to keep them readable.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// TestSnapshots replays every scenario of testdata/snapshots and compares
+// its frames with the golden file, rewritten instead with -update.
+func TestSnapshots(t *testing.T) {
+	useConfig(defaultConfig())
+	defer func(previous dockerClient) { daemon = previous }(daemon)
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "snapshots", "*.json"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no scenario in testdata/snapshots: %v", err)
+	}
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		golden := strings.TrimSuffix(path, ".json") + ".golden"
+		t.Run(name, func(t *testing.T) {
+			frames, err := runSnapshot(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *update {
+				err = os.WriteFile(golden, []byte(frames), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line, wantLine, gotLine, ok := firstDifference(string(want), frames); !ok {
+				t.Errorf("frames differ from %s at line %d:\nwant %q\ngot  %q\nrun go test -run TestSnapshots -update after an intended change", golden, line, wantLine, gotLine)
+			}
+		})
+	}
+}
+
+// runSnapshot plays a scenario and returns its frames, each under the key
+// that led to it.
+func runSnapshot(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var scenario snapshotScenario
+	err = json.Unmarshal(data, &scenario)
+	if err != nil {
+		return "", fmt.Errorf("error parsing scenario: %v", err)
+	}
+	daemon = fakeDocker{containers: scenario.Containers, logs: scenario.Logs}
+
+	build, ok := snapshotScreens[scenario.Screen]
+	if !ok {
+		return "", fmt.Errorf("unknown screen %q", scenario.Screen)
+	}
+	model, err := build(scenario)
+	if err != nil {
+		return "", err
+	}
+
+	model, _ = model.Update(tea.WindowSizeMsg{Width: max(scenario.Width, 80), Height: max(scenario.Height, 24)})
+	model = feedSnapshot(model)
+
+	var b strings.Builder
+	writeFrame(&b, "start", model)
+	for _, key := range scenario.Keys {
+		model, _ = model.Update(snapshotKey(key))
+		model = feedSnapshot(model)
+		writeFrame(&b, key, model)
+	}
+	return b.String(), nil
+}
+
+// feedSnapshot fetches what the list shows of the container under the
+// cursor, its log preview, from the fake daemon.
+func feedSnapshot(model tea.Model) tea.Model {
+	menu, ok := model.(containerChoice)
+	if !ok || len(menu.containers) == 0 {
+		return model
+	}
+	model, _ = menu.Update(fetchLogTail(menu.containers[menu.cursor].ID, max(menu.config.List.PreviewLines, 1))())
+	return model
+}
+
+func writeFrame(b *strings.Builder, key string, model tea.Model) {
+	fmt.Fprintf(b, "--- %s\n", key)
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(model.View(), ""), "\n") {
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+}
+
+// snapshotKey turns a key name of a scenario into the message bubbletea
+// would send: "enter", "ctrl+c", or the characters to type.
+func snapshotKey(key string) tea.KeyMsg {
+	names := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab, "shift+tab": tea.KeyShiftTab,
+		"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+		"pgup": tea.KeyPgUp, "pgdown": tea.KeyPgDown, "home": tea.KeyHome, "end": tea.KeyEnd,
+		"backspace": tea.KeyBackspace, "space": tea.KeySpace, "ctrl+c": tea.KeyCtrlC,
+		"ctrl+s": tea.KeyCtrlS, "ctrl+u": tea.KeyCtrlU, "ctrl+f": tea.KeyCtrlF,
+	}
+	if keyType, ok := names[key]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// firstDifference returns the first line, 1-based, where the frames differ
+// and both versions of it.
+func firstDifference(want string, got string) (int, string, string, bool) {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var wantLine, gotLine string
+		if i < len(wantLines) {
+			wantLine = wantLines[i]
+		}
+		if i < len(gotLines) {
+			gotLine = gotLines[i]
+		}
+		if i >= len(wantLines) || i >= len(gotLines) || wantLine != gotLine {
+			return i + 1, wantLine, gotLine, false
+		}
+	}
+	return 0, "", "", true
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+const defaultSocket = "/var/run/docker.sock"
+
+// defaultPipe is where Docker Desktop and the Windows engine listen. The
+// docker CLI talks to it natively, as npipe:////./pipe/docker_engine.
+const defaultPipe = `\\.\pipe\docker_engine`
+
+// configureDockerHost points the docker CLI at a non-default engine: the
+// configured host first, otherwise the first socket that answers among the
+// usual rootless and podman locations when the default one doesn't. The
+// choice is exported as DOCKER_HOST so every docker command inherits it.
+func configureDockerHost(cfg *Config) {
+	if os.Getenv("DOCKER_HOST") != "" || os.Getenv("DOCKER_CONTEXT") != "" {
+		return
+	}
+
+	if cfg.Docker.Host != "" {
+		os.Setenv("DOCKER_HOST", cfg.Docker.Host)
+		return
+	}
+
+	// Windows has no unix sockets to probe, the CLI already defaults to
+	// the engine's named pipe.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	if !cfg.Docker.ProbeSockets || currentDockerContext() != "default" || canDial(defaultSocket) {
+		return
+	}
+
+	for _, socket := range candidateSockets() {
+		if canDial(socket) {
+			os.Setenv("DOCKER_HOST", "unix://"+socket)
+			return
+		}
+	}
+}
+
+// candidateSockets lists where rootless Docker, Docker Desktop and podman
+// put their sockets.
+func candidateSockets() []string {
+	var sockets []string
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join("/run/user", strconv.Itoa(os.Getuid()))
+	}
+	sockets = append(sockets, filepath.Join(runtimeDir, "docker.sock"))
+
+	if home, err := os.UserHomeDir(); err == nil {
+		sockets = append(sockets, filepath.Join(home, ".docker", "run", "docker.sock"))
+		sockets = append(sockets, filepath.Join(home, ".docker", "desktop", "docker.sock"))
+	}
+
+	sockets = append(sockets,
+		filepath.Join(runtimeDir, "podman", "podman.sock"),
+		"/run/podman/podman.sock",
+	)
+
+	return sockets
+}
+
+func canDial(socket string) bool {
+	conn, err := net.DialTimeout("unix", socket, 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
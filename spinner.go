@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const spinnerDelay = 300 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerColor is the cursor color of the config whale connected with.
+var spinnerColor = defaultConfig().Ui.CursorColor
+
+// runWithSpinner runs cmd and returns its combined output. When it takes
+// longer than spinnerDelay, a spinner with the elapsed time and the command
+// line is drawn on stderr so slow operations don't look like a hang.
+func runWithSpinner(cmd *exec.Cmd) ([]byte, error) {
+	var output []byte
+	err := withSpinner(commandLine(cmd), func() error {
+		var err error
+		output, err = runChild(cmd, true)
+		return err
+	})
+	return output, err
+}
+
+// withSpinner calls run with the spinner of runWithSpinner, labelled label.
+func withSpinner(label string, run func() error) error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go spin(label, done, stopped)
+
+	err := run()
+	close(done)
+	<-stopped
+
+	return err
+}
+
+// runPipedWithSpinner is runWithSpinner for commands whose stdin or stdout
+// is already wired to a file, returning stderr in the error when it fails.
+func runPipedWithSpinner(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := withSpinner(commandLine(cmd), func() error {
+		_, err := runChild(cmd, false)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func spin(label string, done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	start := time.Now()
+	select {
+	case <-done:
+		return
+	case <-time.After(spinnerDelay):
+	}
+
+	if !isTerminal(os.Stderr) {
+		return
+	}
+
+	// Screen readers would read every frame, so plain mode announces the
+	// command once instead of animating it.
+	if plainMode {
+		fmt.Fprintln(os.Stderr, tr("spinner.running", label))
+		<-done
+		return
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		elapsed := time.Since(start).Truncate(100 * time.Millisecond)
+		fmt.Fprintf(os.Stderr, "\r\033[K%s %s %s", renderColor(spinnerFrames[frame%len(spinnerFrames)], spinnerColor), label, renderColor(elapsed.String(), "2"))
+
+		select {
+		case <-done:
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// commandLine renders a command for display, shortening container IDs.
+func commandLine(cmd *exec.Cmd) string {
+	args := make([]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		if len(arg) == 64 && strings.Trim(arg, "0123456789abcdef") == "" {
+			arg = shortID(arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// runDocker runs a docker command that changes state, with the spinner, and
+// returns its output as the error when it fails.
+func runDocker(args ...string) error {
+	output, err := runWithSpinner(exec.Command("docker", args...))
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// state is what whale remembers between runs, next to the config file.
+type state struct {
+	LastAction string `json:"lastAction,omitempty"`
+
+	// ContainerActions is the last action used on each container, by name,
+	// to preselect it in the action menu.
+	ContainerActions map[string]string `json:"containerActions,omitempty"`
+
+	// Recent are the names of the containers actions were last run on,
+	// most recent first, shown at the top of the list.
+	Recent []string `json:"recent,omitempty"`
+
+	// Suspended holds the IDs of the containers stopped by a compose
+	// suspend, by project.
+	Suspended map[string][]string `json:"suspended,omitempty"`
+
+	// Starts is the image each running container was started from, by
+	// container ID, see digestsCommand.
+	Starts map[string]startRecord `json:"starts,omitempty"`
+}
+
+func statePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "whale", "state.json"), nil
+}
+
+// loadState reads the state file; a missing or broken file is an empty state
+// since nothing in it is worth failing for.
+func loadState() state {
+	var s state
+	path, err := statePath()
+	if err != nil {
+		return s
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s
+	}
+	json.Unmarshal(data, &s)
+	return s
+}
+
+func saveState(s state) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// rememberAction records the action just run so `.` can repeat it, the
+// container among the recent ones and, with ui.rememberActions, so the menu
+// of the container opens on it.
+func rememberAction(cfg *Config, container Container, action string) {
+	if action == "" || action == "exit" {
+		return
+	}
+	s := loadState()
+	s.LastAction = action
+	if cfg.Ui.RememberActions && container.Name != "" {
+		if s.ContainerActions == nil {
+			s.ContainerActions = map[string]string{}
+		}
+		s.ContainerActions[container.Name] = action
+	}
+	if limit := cfg.List.Recent; limit > 0 && container.Name != "" {
+		addRecent(&s, container.Name, limit)
+	}
+	saveState(s)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type containerStats struct {
+	ID         string
+	Name       string
+	CPU        float64
+	Memory     int64
+	MemUsage   string
+	MemLimit   int64
+	MemPerc    float64
+	NetRx      int64
+	NetTx      int64
+	BlockRead  int64
+	BlockWrite int64
+	PIDs       int
+
+	// Cgroup is set when the figures come from cgroup v2 files: Memory is
+	// then the working set instead of the raw usage, which includes cache.
+	Cgroup           bool
+	ThrottledPeriods int64
+	ThrottledUsec    int64
+}
+
+// statsLine mirrors one line of `docker stats --no-stream --format '{{json .}}'`.
+type statsLine struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+	NetIO    string `json:"NetIO"`
+	BlockIO  string `json:"BlockIO"`
+	PIDs     string `json:"PIDs"`
+}
+
+type statsMsg map[string]containerStats
+
+type statsTickMsg struct{}
+
+// getContainerStats samples the stats API once, keyed by short container ID.
+func getContainerStats() (map[string]containerStats, error) {
+	output, err := dockerRead("stats", "--no-stream", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]containerStats)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var s statsLine
+		if json.Unmarshal([]byte(line), &s) != nil {
+			continue
+		}
+
+		usage, limit := splitPair(s.MemUsage)
+		rx, tx := splitPair(s.NetIO)
+		read, write := splitPair(s.BlockIO)
+		pids, _ := strconv.Atoi(s.PIDs)
+
+		stat := containerStats{
+			ID:         s.ID,
+			Name:       s.Name,
+			CPU:        parsePercent(s.CPUPerc),
+			Memory:     parseBytes(usage),
+			MemUsage:   usage,
+			MemLimit:   parseBytes(limit),
+			MemPerc:    parsePercent(s.MemPerc),
+			NetRx:      parseBytes(rx),
+			NetTx:      parseBytes(tx),
+			BlockRead:  parseBytes(read),
+			BlockWrite: parseBytes(write),
+			PIDs:       pids,
+		}
+
+		if cgroup, ok := readCgroupStats(s.ID); ok {
+			stat.Cgroup = true
+			stat.Memory = cgroup.WorkingSet
+			stat.MemUsage = formatBytes(cgroup.WorkingSet)
+			if stat.MemLimit > 0 {
+				stat.MemPerc = float64(cgroup.WorkingSet) / float64(stat.MemLimit) * 100
+			}
+			stat.ThrottledPeriods = cgroup.ThrottledPeriods
+			stat.ThrottledUsec = cgroup.ThrottledUsec
+		}
+
+		stats[shortID(s.ID)] = stat
+	}
+
+	return stats, nil
+}
+
+func sampleStats() tea.Cmd {
+	return func() tea.Msg {
+		stats, err := getContainerStats()
+		if err != nil {
+			return statsMsg(nil)
+		}
+		return statsMsg(stats)
+	}
+}
+
+func scheduleStats(cfg *Config) tea.Cmd {
+	interval := time.Duration(cfg.List.StatsInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return statsTickMsg{}
+	})
+}
+
+// splitPair splits docker's "used / total" and "in / out" columns.
+func splitPair(s string) (string, string) {
+	first, second, _ := strings.Cut(s, "/")
+	return strings.TrimSpace(first), strings.TrimSpace(second)
+}
+
+// formatBytes renders a byte count with binary units, e.g. 1.5GiB.
+func formatBytes(bytes int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	value := float64(bytes)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d%s", bytes, units[unit])
+	}
+	return fmt.Sprintf("%.1f%s", value, units[unit])
+}
+
+func parsePercent(s string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// parseBytes converts docker's human-readable sizes ("12.5MiB", "1.2kB") to bytes.
+func parseBytes(s string) int64 {
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+		{"B", 1},
+	}
+
+	s = strings.TrimSpace(s)
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit.suffix) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(s, unit.suffix), 64)
+			if err != nil {
+				return 0
+			}
+			return int64(value * unit.multiplier)
+		}
+	}
+
+	return 0
+}
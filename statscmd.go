@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// statsJSON is the machine-readable form of `whale stats --json`, with raw
+// byte counts instead of human-readable sizes.
+type statsJSON struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpuPercent"`
+	MemUsage   int64   `json:"memUsage"`
+	MemLimit   int64   `json:"memLimit"`
+	MemPercent float64 `json:"memPercent"`
+	NetRx      int64   `json:"netRx"`
+	NetTx      int64   `json:"netTx"`
+	BlockRead  int64   `json:"blockRead"`
+	BlockWrite int64   `json:"blockWrite"`
+	PIDs       int     `json:"pids"`
+
+	WorkingSet       bool  `json:"workingSet"`
+	ThrottledPeriods int64 `json:"throttledPeriods"`
+	ThrottledUsec    int64 `json:"throttledUsec"`
+}
+
+// statsCommand implements `whale stats [names...] [--stream] [--json]
+// [--interval N]`, printing container resource usage without the TUI.
+func statsCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	stream := false
+	asJSON := false
+	interval := max(cfg.List.StatsInterval, 1)
+	var queries []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--stream", "-s":
+			stream = true
+		case "--json":
+			asJSON = true
+		case "--interval":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			value, err := strconv.Atoi(args[i])
+			if err != nil || value <= 0 {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			interval = value
+		default:
+			queries = append(queries, args[i])
+		}
+	}
+
+	requireAction(cfg, "stats")
+
+	ids := map[string]bool{}
+	for _, query := range queries {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		ids[shortID(container.ID)] = true
+	}
+
+	// The rates need two samples: streaming shows the column from the start.
+	var previous map[string]containerStats
+	var previousAt time.Time
+	if stream {
+		previous = map[string]containerStats{}
+	}
+	for {
+		at := time.Now()
+		stats, err := getContainerStats()
+		if err != nil {
+			println(tr("error.getStats"), err)
+			os.Exit(1)
+		}
+
+		var selected []containerStats
+		for id, s := range stats {
+			if len(ids) == 0 || ids[id] {
+				selected = append(selected, s)
+			}
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].Name < selected[j].Name
+		})
+
+		if asJSON {
+			printStatsJSON(selected)
+		} else {
+			if stream {
+				fmt.Print("\033[H")
+			}
+			printStatsTable(selected, previous, at.Sub(previousAt))
+			if stream {
+				fmt.Print("\033[J")
+			}
+		}
+		previous, previousAt = stats, at
+
+		if !stream {
+			return
+		}
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+// printStatsTable prints a sample, with the network rates since the
+// previous one when streaming.
+func printStatsTable(stats []containerStats, previous map[string]containerStats, elapsed time.Duration) {
+	header := []string{
+		tr("column.name"), tr("column.cpu"), tr("stats.memUsage"), tr("stats.memPerc"),
+		tr("stats.netIO"), tr("stats.blockIO"), tr("stats.pids"), tr("stats.throttled"),
+	}
+	if previous != nil {
+		header = append(header, tr("stats.netRate"))
+	}
+	table := [][]string{header}
+	cgroup := false
+	for _, s := range stats {
+		throttled := "-"
+		if s.Cgroup {
+			cgroup = true
+			throttled = fmt.Sprintf("%d (%s)", s.ThrottledPeriods, time.Duration(s.ThrottledUsec)*time.Microsecond)
+		}
+
+		row := []string{
+			s.Name,
+			fmt.Sprintf("%.2f%%", s.CPU),
+			formatBytes(s.Memory) + " / " + formatBytes(s.MemLimit),
+			fmt.Sprintf("%.2f%%", s.MemPerc),
+			formatBytes(s.NetRx) + " / " + formatBytes(s.NetTx),
+			formatBytes(s.BlockRead) + " / " + formatBytes(s.BlockWrite),
+			strconv.Itoa(s.PIDs),
+			throttled,
+		}
+		if previous != nil {
+			rate := "-"
+			if before, ok := previous[shortID(s.ID)]; ok && elapsed > 0 && s.NetRx >= before.NetRx && s.NetTx >= before.NetTx {
+				perSecond := func(n int64) string { return formatBytes(int64(float64(n)/elapsed.Seconds())) + "/s" }
+				rate = perSecond(s.NetRx-before.NetRx) + " / " + perSecond(s.NetTx-before.NetTx)
+			}
+			row = append(row, rate)
+		}
+		table = append(table, row)
+	}
+
+	// Streamed samples are drawn over the previous one rather than after a
+	// clear of the screen, erasing what it left at the end of the lines.
+	end := ""
+	if previous != nil {
+		end = "\033[K"
+	}
+	for _, row := range alignColumns(table) {
+		fmt.Println(row + end)
+	}
+	if cgroup {
+		fmt.Println(renderColor(tr("stats.workingSet"), "2") + end)
+	}
+}
+
+// printStatsJSON writes one JSON object per sample so `--stream --json`
+// can be consumed line by line.
+func printStatsJSON(stats []containerStats) {
+	list := make([]statsJSON, len(stats))
+	for i, s := range stats {
+		list[i] = statsJSON{
+			ID:         s.ID,
+			Name:       s.Name,
+			CPUPercent: s.CPU,
+			MemUsage:   s.Memory,
+			MemLimit:   s.MemLimit,
+			MemPercent: s.MemPerc,
+			NetRx:      s.NetRx,
+			NetTx:      s.NetTx,
+			BlockRead:  s.BlockRead,
+			BlockWrite: s.BlockWrite,
+			PIDs:       s.PIDs,
+
+			WorkingSet:       s.Cgroup,
+			ThrottledPeriods: s.ThrottledPeriods,
+			ThrottledUsec:    s.ThrottledUsec,
+		}
+	}
+
+	output, err := json.Marshal(list)
+	if err != nil {
+		println(err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(string(output))
+}
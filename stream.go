@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const maxStreamLines = 10000
+
+type streamLineMsg string
+
+// stderrLineMsg is a line the command wrote on stderr, once the view keeps
+// the two streams apart.
+type stderrLineMsg string
+
+type streamLine struct {
+	text   string
+	stderr bool
+}
+
+type streamEndMsg struct {
+	err error
+}
+
+// streamView runs a long-lived command and shows its combined output in a
+// scrollable viewport that follows new lines until the user scrolls up.
+type streamView struct {
+	config *Config
+	title  string
+	cmd    *exec.Cmd
+	lines  []string
+	ch     chan streamLine
+	errCh  chan error
+	done   chan struct{}
+	quit   sync.Once
+	offset int
+	height int
+	width  int
+	wrap   bool
+	column int
+	ended  bool
+	err    error
+	notice string
+
+	// split keeps stdout and stderr apart, stderr telling which lines came
+	// from the latter, so that only tells the stream shown: "", "stdout"
+	// or "stderr".
+	split  bool
+	stderr []bool
+	only   string
+}
+
+func newStreamView(cfg *Config, title string, cmd *exec.Cmd) *streamView {
+	return &streamView{
+		config: cfg,
+		title:  title,
+		cmd:    cmd,
+		ch:     make(chan streamLine, 256),
+		errCh:  make(chan error, 1),
+		done:   make(chan struct{}),
+		height: 24,
+		wrap:   cfg.Logs.Wrap,
+	}
+}
+
+func (view *streamView) start() error {
+	reader, writer := io.Pipe()
+	view.cmd.Stdout = writer
+	view.cmd.Stderr = writer
+	readers := []*io.PipeReader{reader}
+	writers := []*io.PipeWriter{writer}
+	if view.split {
+		errReader, errWriter := io.Pipe()
+		view.cmd.Stderr = errWriter
+		readers = append(readers, errReader)
+		writers = append(writers, errWriter)
+	}
+
+	err := view.cmd.Start()
+	if err != nil {
+		return err
+	}
+	children.Lock()
+	children.cmds[view.cmd] = true
+	children.Unlock()
+
+	go func() {
+		view.errCh <- view.cmd.Wait()
+		children.Lock()
+		delete(children.cmds, view.cmd)
+		children.Unlock()
+		for _, writer := range writers {
+			writer.Close()
+		}
+	}()
+
+	var scanners sync.WaitGroup
+	for i, reader := range readers {
+		scanners.Add(1)
+		go func(reader *io.PipeReader, stderr bool) {
+			defer scanners.Done()
+			// Closing the reader once the view is gone fails the writes of
+			// the command, rather than leaving them blocked on the pipe.
+			defer reader.Close()
+			scanner := bufio.NewScanner(reader)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				select {
+				case view.ch <- streamLine{text: scanner.Text(), stderr: stderr}:
+				case <-view.done:
+					return
+				}
+			}
+		}(reader, i == 1)
+	}
+	go func() {
+		scanners.Wait()
+		close(view.ch)
+	}()
+
+	return nil
+}
+
+func (view *streamView) wait() tea.Cmd {
+	return func() tea.Msg {
+		var line streamLine
+		var ok bool
+		select {
+		case line, ok = <-view.ch:
+		case <-view.done:
+			return nil
+		}
+		if !ok {
+			return streamEndMsg{err: <-view.errCh}
+		}
+		if line.stderr {
+			return stderrLineMsg(line.text)
+		}
+		return streamLineMsg(line.text)
+	}
+}
+
+// stop kills the command if it still runs and lets go of the goroutines
+// reading its output.
+func (view *streamView) stop() {
+	view.quit.Do(func() {
+		close(view.done)
+		if view.cmd.Process != nil && !view.ended {
+			view.cmd.Process.Kill()
+		}
+	})
+}
+
+func (view *streamView) Init() tea.Cmd {
+	return view.wait()
+}
+
+func (view *streamView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case streamLineMsg:
+		view.add(string(msg), false)
+		return view, view.wait()
+	case stderrLineMsg:
+		view.add(string(msg), true)
+		return view, view.wait()
+	case streamEndMsg:
+		view.ended = true
+		view.err = msg.err
+	case tea.WindowSizeMsg:
+		view.height = msg.Height
+		view.width = msg.Width
+	case tea.KeyMsg:
+		page := max(view.pageSize()-1, 1)
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			view.stop()
+			return view, tea.Quit
+		case "up", "k":
+			view.scroll(1)
+		case "down", "j":
+			view.scroll(-1)
+		case "pgup", "b":
+			view.scroll(page)
+		case "pgdown", " ":
+			view.scroll(-page)
+		case "g", "home":
+			view.scroll(len(view.lines))
+		case "s":
+			if view.split {
+				view.only = map[string]string{"": "stdout", "stdout": "stderr", "stderr": ""}[view.only]
+				view.offset = 0
+			}
+		case "G", "end":
+			view.offset = 0
+		case "w":
+			view.wrap = !view.wrap
+			view.column = 0
+		case "left", "h":
+			if !view.wrap {
+				view.column = max(view.column-view.horizontalStep(), 0)
+			}
+		case "right", "l":
+			if !view.wrap {
+				view.column += view.horizontalStep()
+			}
+		case "e", "E":
+			format := "json"
+			if msg.String() == "E" {
+				format = "csv"
+			}
+			path, err := exportLogs(format, view.lines)
+			view.notice = tr("stream.exported", path)
+			if err != nil {
+				view.notice = tr("stream.exportFailed", err)
+			}
+		}
+	}
+
+	return view, nil
+}
+
+func (view *streamView) add(line string, stderr bool) {
+	view.lines = append(view.lines, line)
+	view.stderr = append(view.stderr, stderr)
+	if len(view.lines) > maxStreamLines {
+		view.lines = view.lines[len(view.lines)-maxStreamLines:]
+		view.stderr = view.stderr[len(view.stderr)-maxStreamLines:]
+	}
+	if view.offset > 0 && (view.only == "" || (view.only == "stderr") == stderr) {
+		view.offset++
+	}
+}
+
+// shown returns the lines of the stream being shown.
+func (view *streamView) shown() []string {
+	if view.only == "" {
+		return view.lines
+	}
+
+	var lines []string
+	for i, line := range view.lines {
+		if view.stderr[i] == (view.only == "stderr") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (view *streamView) pageSize() int {
+	return max(view.height-4, 1)
+}
+
+func (view *streamView) scroll(lines int) {
+	view.offset = min(max(view.offset+lines, 0), max(len(view.shown())-view.pageSize(), 0))
+}
+
+func (view *streamView) horizontalStep() int {
+	return max(view.width/2, 8)
+}
+
+// visibleRows returns the screen rows of the page ending at the scroll
+// offset, either wrapping long lines or cutting them at the current column.
+func (view *streamView) visibleRows() []string {
+	lines := view.shown()
+	end := len(lines) - view.offset
+	if view.width <= 0 {
+		return lines[max(end-view.pageSize(), 0):end]
+	}
+
+	var rows []string
+	for i := end - 1; i >= 0 && len(rows) < view.pageSize(); i-- {
+		var lineRows []string
+		if view.wrap {
+			lineRows = wrapLine(lines[i], view.width)
+		} else {
+			lineRows = []string{scrollLine(lines[i], view.column, view.width)}
+		}
+		rows = append(lineRows, rows...)
+	}
+
+	return rows[max(len(rows)-view.pageSize(), 0):]
+}
+
+// wrapLine splits a line into rows of at most width runes.
+func wrapLine(line string, width int) []string {
+	runes := []rune(line)
+	if len(runes) <= width {
+		return []string{line}
+	}
+
+	var rows []string
+	for len(runes) > width {
+		rows = append(rows, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(rows, string(runes))
+}
+
+// scrollLine shows width runes of line starting at column, marking the cut
+// sides so it's clear there is more to the left or the right.
+func scrollLine(line string, column int, width int) string {
+	runes := []rune(line)
+	if column >= len(runes) {
+		if column > 0 && len(runes) > 0 {
+			return "«"
+		}
+		return ""
+	}
+
+	runes = runes[column:]
+	clipped := clipString(string(runes), width)
+	if column > 0 && width > 1 {
+		clipped = "«" + string([]rune(clipped)[1:])
+	}
+	return clipped
+}
+
+func (view *streamView) View() string {
+	s := newFrame()
+	s.line(view.title, "\n")
+
+	rows := view.visibleRows()
+	for _, row := range rows {
+		s.line(highlight(view.config, row))
+	}
+	for i := len(rows); i < view.pageSize(); i++ {
+		s.WriteByte('\n')
+	}
+
+	status := tr("stream.following")
+	if !view.wrap && view.column > 0 {
+		status = tr("stream.column", view.column)
+	}
+	if view.offset > 0 {
+		status = tr("stream.scrolled", view.offset)
+	}
+	if view.ended {
+		status = tr("stream.ended")
+		if view.err != nil {
+			status = tr("stream.failed", view.err)
+		}
+	}
+
+	if view.only != "" {
+		status = tr("stream.only."+view.only) + ", " + status
+	}
+	if view.notice != "" {
+		status += "  " + view.notice
+	}
+
+	help := tr("stream.help")
+	if view.split {
+		help = tr("stream.helpSplit")
+	}
+	s.colored(status+"  "+help, "2")
+	return s.done()
+}
+
+// runStream shows the output of cmd until it exits and the user quits.
+func runStream(cfg *Config, title string, cmd *exec.Cmd) error {
+	return runStreamView(cfg, title, cmd, false)
+}
+
+// runSplitStream is runStream where s switches between stdout and stderr,
+// for `docker logs` which replays the container's streams on its own.
+func runSplitStream(cfg *Config, title string, cmd *exec.Cmd) error {
+	return runStreamView(cfg, title, cmd, true)
+}
+
+func runStreamView(cfg *Config, title string, cmd *exec.Cmd, split bool) error {
+	if plainMode {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+
+	view := newStreamView(cfg, title, cmd)
+	view.split = split
+	err := view.start()
+	if err != nil {
+		return err
+	}
+
+	_, err = runProgram(view)
+	view.stop()
+	return err
+}
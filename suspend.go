@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// suspendProject stops the running containers of a compose project and
+// records them, so resumeProject brings back exactly that set rather than
+// every service of the project.
+func suspendProject(project composeProject) error {
+	var ids, names []string
+	for _, container := range project.Containers {
+		if container.State == "running" {
+			ids = append(ids, container.ID)
+			names = append(names, container.Name)
+		}
+	}
+	if len(ids) == 0 {
+		fmt.Println(tr("suspend.nothingRunning", project.Name))
+		return nil
+	}
+
+	// Recorded first: if stopping fails half-way, resuming still starts
+	// the ones that did stop.
+	s := loadState()
+	if s.Suspended == nil {
+		s.Suspended = map[string][]string{}
+	}
+	s.Suspended[project.Name] = ids
+	err := saveState(s)
+	if err != nil {
+		return fmt.Errorf("error recording suspended containers: %v", err)
+	}
+
+	output, err := runWithSpinner(exec.Command("docker", append([]string{"stop"}, ids...)...))
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	fmt.Printf("✓ %s: %s\n", project.Name, tr("suspend.done", strings.Join(names, ", ")))
+	return nil
+}
+
+// resumeProject starts the containers recorded by suspendProject, skipping
+// the ones removed since.
+func resumeProject(project composeProject) error {
+	s := loadState()
+	recorded := s.Suspended[project.Name]
+
+	var ids, names []string
+	for _, id := range recorded {
+		for _, container := range project.Containers {
+			if container.ID == id {
+				ids = append(ids, id)
+				names = append(names, container.Name)
+			}
+		}
+	}
+	if missing := len(recorded) - len(ids); missing > 0 {
+		fmt.Println(tr("suspend.missing", missing))
+	}
+
+	if len(ids) > 0 {
+		output, err := runWithSpinner(exec.Command("docker", append([]string{"start"}, ids...)...))
+		if err != nil {
+			return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+		}
+		fmt.Printf("✓ %s: %s\n", project.Name, tr("suspend.resumed", strings.Join(names, ", ")))
+	}
+
+	delete(s.Suspended, project.Name)
+	return saveState(s)
+}
+
+func isSuspended(project composeProject) bool {
+	return len(loadState().Suspended[project.Name]) > 0
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tagGroup is every tag of one repository, newest first.
+type tagGroup struct {
+	Repository string
+	Images     []Image
+}
+
+func groupTags(images []Image) []tagGroup {
+	byRepository := map[string][]Image{}
+	for _, image := range images {
+		if image.Repository == "<none>" || image.Tag == "<none>" {
+			continue
+		}
+		byRepository[image.Repository] = append(byRepository[image.Repository], image)
+	}
+
+	var groups []tagGroup
+	for repository, images := range byRepository {
+		if len(images) < 2 {
+			continue
+		}
+		sort.SliceStable(images, func(i, j int) bool {
+			return images[i].CreatedAt.After(images[j].CreatedAt)
+		})
+		groups = append(groups, tagGroup{Repository: repository, Images: images})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Repository < groups[j].Repository
+	})
+	return groups
+}
+
+// tagsCommand implements `whale tags [--keep N]`: lists repositories with
+// several tags and offers to remove all but the newest N, never touching
+// tags used by a container or protected in the config.
+func tagsCommand(args []string) {
+	cfg := currentConfig()
+	keep := max(cfg.Images.KeepTags, 1)
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--keep", "-k":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			value, err := strconv.Atoi(args[i])
+			if err != nil || value < 1 {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			keep = value
+		}
+	}
+
+	requireAction(cfg, "tags")
+
+	images, err := getImages(cfg)
+	if err != nil {
+		println(tr("error.getImages"), err)
+		os.Exit(1)
+	}
+	used, err := usedImageIDs()
+	if err != nil {
+		println(tr("error.getImages"), err)
+		os.Exit(1)
+	}
+
+	var candidates []Image
+	var checked []bool
+	table := [][]string{{tr("column.repository"), tr("column.tag"), tr("column.created"), tr("column.size"), tr("tags.state")}}
+	for _, group := range groupTags(images) {
+		for i, image := range group.Images {
+			state := ""
+			removable := false
+			switch {
+			case used[image.ID]:
+				state = tr("tags.inUse")
+			case isProtectedImage(cfg, image):
+				state = tr("tags.protected")
+			case i < keep:
+				state = tr("tags.kept")
+			default:
+				state = tr("tags.old")
+				removable = true
+			}
+
+			candidates = append(candidates, image)
+			checked = append(checked, removable)
+			table = append(table, []string{group.Repository, image.Tag, image.Created, image.Size, state})
+		}
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println(tr("tags.nothing"))
+		return
+	}
+
+	rows := alignColumns(table)
+	chosen, err := chooseMany(cfg, tr("tags.title", keep), rows[0], rows[1:], checked)
+	if err != nil {
+		println(tr("error.chooseImage"), err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, i := range chosen {
+		image := candidates[i]
+		if used[image.ID] || isProtectedImage(cfg, image) {
+			fmt.Printf("✗ %s: %s\n", image.Reference(), tr("tags.refused"))
+			failed = true
+			continue
+		}
+
+		output, err := runWithSpinner(exec.Command("docker", "image", "rm", image.Reference()))
+		if err != nil {
+			fmt.Printf("✗ %s: %v: %s\n", image.Reference(), err, strings.TrimSpace(string(output)))
+			failed = true
+			continue
+		}
+		fmt.Printf("✓ %s\n", image.Reference())
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
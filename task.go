@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// taskWorkdir is where the current directory is mounted in task containers.
+const taskWorkdir = "/work"
+
+// runTask runs a one-shot `docker run --rm` container, typically a linter or
+// a build tool working on the current directory, and shows its output until
+// it exits. Nothing is left behind once the task is done.
+func runTask(cfg *Config, image Image) error {
+	imgConfig, err := inspectImageConfig(image.Reference())
+	if err != nil {
+		return err
+	}
+
+	fields, ok, err := fillForm(cfg, tr("task.title", image.Reference()), []formField{
+		{Label: tr("task.command"), Value: strings.Join(imgConfig.Cmd, " "), Hint: tr("task.commandHint")},
+		{Label: tr("task.mount"), Value: yesNo(true), Hint: tr("task.mountHint", taskWorkdir)},
+		{Label: tr("create.env"), Hint: tr("create.envHint")},
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	args := []string{"run", "--rm", "--init"}
+	workdir := ""
+	if isYes(fields[1].Value) {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		args = append(args, "-v", dir+":"+taskWorkdir, "-w", taskWorkdir)
+		workdir = taskWorkdir
+	}
+	for _, env := range splitList(fields[2].Value) {
+		args = append(args, "-e", env)
+	}
+	args = append(args, image.Reference())
+	args = append(args, strings.Fields(fields[0].Value)...)
+
+	cmd := exec.Command("docker", args...)
+	overrides := argvOverrides{Workdir: workdir}
+	if command := strings.Fields(fields[0].Value); strings.Join(command, " ") != strings.Join(imgConfig.Cmd, " ") {
+		overrides.Cmd = command
+	}
+	explanation := explainArgv(imgConfig, overrides)
+	return runStream(cfg, tr("task.running", commandLine(cmd))+"\n"+strings.Join(explanation, "\n"), cmd)
+}
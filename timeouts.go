@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// commandGroups are the docker subcommands whose own subcommand names the
+// operation: `docker image pull` is a pull like `docker pull`.
+var commandGroups = []string{"image", "container", "volume", "network", "builder", "buildx", "system"}
+
+// parseTimeouts checks the timeouts section, durations like 10m or plain
+// seconds, 0 for no limit.
+func (cfg *Config) parseTimeouts() error {
+	cfg.timeouts = map[string]time.Duration{}
+	for operation, value := range cfg.Timeouts {
+		timeout, err := parseTimeout(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("invalid timeout %q for %s", value, operation)
+		}
+		cfg.timeouts[operation] = timeout
+	}
+	return nil
+}
+
+// operationKeys are the keys of the timeouts section that can apply to a
+// docker command, most specific first: "volume rm", then "rm".
+func operationKeys(args []string) []string {
+	for len(args) > 1 && (args[0] == "--context" || args[0] == "--host") {
+		args = args[2:]
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	if containsString(commandGroups, args[0]) && len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		return []string{args[0] + " " + args[1], args[1]}
+	}
+	return []string{args[0]}
+}
+
+// commandTimeouts are the timeouts section of the config whale connected
+// to the daemon with, the docker commands run under them.
+var commandTimeouts map[string]time.Duration
+
+// commandTimeout is how long cmd may run before whale kills it: its entry
+// in commandTimeouts, else the default one, 0 meaning no limit. Logs being
+// followed only end when the user is done with them.
+func commandTimeout(cmd *exec.Cmd) (string, time.Duration) {
+	if len(cmd.Args) == 0 || filepath.Base(cmd.Args[0]) != "docker" || containsString(cmd.Args, "--follow") {
+		return "", 0
+	}
+
+	return operationTimeout(commandTimeouts, operationKeys(cmd.Args[1:]))
+}
+
+// operationTimeout is the first of keys set in timeouts, else the default.
+func operationTimeout(timeouts map[string]time.Duration, keys []string) (string, time.Duration) {
+	for _, key := range keys {
+		if timeout, ok := timeouts[key]; ok {
+			return key, timeout
+		}
+	}
+	return "default", timeouts["default"]
+}
+
+// runTimed runs cmd like cmd.Run, killing it when its timeout expires.
+func runTimed(cmd *exec.Cmd) error {
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	expired := startTimeout(cmd)
+	err = cmd.Wait()
+	if timeoutErr := expired(); timeoutErr != nil {
+		return timeoutErr
+	}
+	return err
+}
+
+// startTimeout kills cmd, once started, when its timeout expires. The
+// returned function stops the timer and returns the error to report
+// instead of the one of the killed command, nil when it didn't expire.
+func startTimeout(cmd *exec.Cmd) func() error {
+	operation, timeout := commandTimeout(cmd)
+	if timeout == 0 {
+		return func() error { return nil }
+	}
+
+	expired := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() {
+		close(expired)
+		cmd.Process.Kill()
+	})
+	return func() error {
+		if timer.Stop() {
+			return nil
+		}
+		<-expired
+		return fmt.Errorf("%s", tr("timeout.expired", commandLine(cmd), timeout, operation))
+	}
+}
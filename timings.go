@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// timing is how long one startup step took, for `whale --timings`.
+type timing struct {
+	Step     string
+	Duration time.Duration
+}
+
+var (
+	startedAt = time.Now()
+	timings   []timing
+)
+
+// track starts timing a step; call the returned function when it is done.
+func track(step string) func() {
+	start := time.Now()
+	return func() {
+		timings = append(timings, timing{Step: step, Duration: time.Since(start)})
+	}
+}
+
+func timingsPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "whale", "timings.log"), nil
+}
+
+// timingsCommand implements `whale --timings`: it prints how long each
+// startup step took and appends the figures to a local log, nothing is sent
+// anywhere. Comparing runs shows where slow daemons spend their time.
+func timingsCommand() {
+	total := time.Since(startedAt)
+
+	table := [][]string{{tr("timings.step"), tr("timings.duration")}}
+	fields := []string{time.Now().Format(time.RFC3339), "host=" + dockerEndpoint()}
+	for _, t := range timings {
+		table = append(table, []string{t.Step, formatDuration(t.Duration)})
+		fields = append(fields, fmt.Sprintf("%s=%dms", strings.ReplaceAll(t.Step, " ", "-"), t.Duration.Milliseconds()))
+	}
+	table = append(table, []string{tr("timings.total"), formatDuration(total)})
+	fields = append(fields, fmt.Sprintf("total=%dms", total.Milliseconds()))
+
+	for _, row := range alignColumns(table) {
+		fmt.Println(row)
+	}
+
+	path, err := timingsPath()
+	if err == nil {
+		err = appendLine(path, strings.Join(fields, " "))
+	}
+	if err != nil {
+		warn(tr("timings.notSaved", err))
+		return
+	}
+	fmt.Println()
+	fmt.Println(tr("timings.saved", path))
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(100 * time.Microsecond).String()
+}
+
+func appendLine(path string, line string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintln(file, line)
+	return err
+}
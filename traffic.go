@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// interfaceTraffic is what went through one network interface of a
+// container since it started.
+type interfaceTraffic struct {
+	RxBytes   int64 `json:"rx_bytes"`
+	TxBytes   int64 `json:"tx_bytes"`
+	RxPackets int64 `json:"rx_packets"`
+	TxPackets int64 `json:"tx_packets"`
+}
+
+// getInterfaceTraffic reads the counters of each interface: from the stats
+// API when whale talks to the engine directly, from /proc/net/dev inside the
+// container otherwise, since `docker stats` only gives the totals.
+func getInterfaceTraffic(container Container) (map[string]interfaceTraffic, error) {
+	if engine != nil {
+		body, err := engine.get("stats", "/containers/"+url.PathEscape(container.ID)+"/stats", url.Values{"stream": {"false"}, "one-shot": {"true"}})
+		if err != nil {
+			return nil, err
+		}
+		var stats struct {
+			Networks map[string]interfaceTraffic `json:"networks"`
+		}
+		err = json.Unmarshal(body, &stats)
+		return stats.Networks, err
+	}
+
+	output, err := runChild(exec.Command("docker", "exec", container.ID, "cat", "/proc/net/dev"), true)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return parseNetDev(string(output)), nil
+}
+
+// parseNetDev parses /proc/net/dev: "eth0: rxBytes rxPackets ... txBytes
+// txPackets ...", skipping the loopback.
+func parseNetDev(text string) map[string]interfaceTraffic {
+	traffic := map[string]interfaceTraffic{}
+	for _, line := range strings.Split(text, "\n") {
+		name, counters, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		fields := strings.Fields(counters)
+		if !ok || name == "lo" || len(fields) < 10 {
+			continue
+		}
+		value := func(i int) int64 {
+			n, _ := strconv.ParseInt(fields[i], 10, 64)
+			return n
+		}
+		traffic[name] = interfaceTraffic{RxBytes: value(0), RxPackets: value(1), TxBytes: value(8), TxPackets: value(9)}
+	}
+	return traffic
+}
+
+type trafficMsg struct {
+	at      time.Time
+	traffic map[string]interfaceTraffic
+	err     error
+}
+
+type trafficTickMsg struct{}
+
+// trafficView shows the counters of each interface with the rates since the
+// previous sample, refreshed at list.statsInterval.
+type trafficView struct {
+	config    *Config
+	container Container
+	previous  trafficMsg
+	current   trafficMsg
+}
+
+func (view trafficView) sample() tea.Cmd {
+	return func() tea.Msg {
+		traffic, err := getInterfaceTraffic(view.container)
+		return trafficMsg{at: time.Now(), traffic: traffic, err: err}
+	}
+}
+
+func (view trafficView) Init() tea.Cmd {
+	return view.sample()
+}
+
+func (view trafficView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case trafficMsg:
+		if msg.err == nil {
+			view.previous = view.current
+		}
+		view.current = msg
+		interval := time.Duration(max(view.config.List.StatsInterval, 1)) * time.Second
+		return view, tea.Tick(interval, func(time.Time) tea.Msg { return trafficTickMsg{} })
+	case trafficTickMsg:
+		return view, view.sample()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return view, tea.Quit
+		}
+	}
+	return view, nil
+}
+
+func (view trafficView) View() string {
+	s := renderHeader(view.config)
+	s += tr("traffic.title", view.container.Name) + "\n\n"
+
+	switch {
+	case view.current.err != nil:
+		s += renderColor(view.current.err.Error(), "31") + "\n"
+	case view.current.traffic == nil:
+		s += tr("details.loading") + "\n"
+	case len(view.current.traffic) == 0:
+		s += tr("traffic.none") + "\n"
+	default:
+		var names []string
+		for name := range view.current.traffic {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		elapsed := view.current.at.Sub(view.previous.at).Seconds()
+		rate := func(now int64, before int64) string {
+			if view.previous.traffic == nil || elapsed <= 0 || now < before {
+				return "-"
+			}
+			return formatBytes(int64(float64(now-before)/elapsed)) + "/s"
+		}
+
+		table := [][]string{{tr("traffic.interface"), tr("traffic.received"), tr("traffic.sent"), tr("traffic.rxRate"), tr("traffic.txRate")}}
+		for _, name := range names {
+			now, before := view.current.traffic[name], view.previous.traffic[name]
+			table = append(table, []string{
+				name,
+				fmt.Sprintf("%s (%d)", formatBytes(now.RxBytes), now.RxPackets),
+				fmt.Sprintf("%s (%d)", formatBytes(now.TxBytes), now.TxPackets),
+				rate(now.RxBytes, before.RxBytes),
+				rate(now.TxBytes, before.TxBytes),
+			})
+		}
+		for _, row := range alignColumns(table) {
+			s += "  " + row + "\n"
+		}
+	}
+
+	s += "\n" + renderColor(tr("traffic.help"), "2") + "\n"
+	return s
+}
+
+// showTraffic opens the traffic view of the container until q is pressed.
+func showTraffic(cfg *Config, container Container) error {
+	if plainMode {
+		traffic, err := getInterfaceTraffic(container)
+		if err != nil {
+			return err
+		}
+		view := trafficView{container: container, current: trafficMsg{at: time.Now(), traffic: traffic}}
+		fmt.Print(view.View())
+		return nil
+	}
+
+	_, err := runProgram(trafficView{config: cfg, container: container})
+	return err
+}
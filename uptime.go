@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uptimeWindows are the periods `whale uptime` sums up, shortest first.
+var uptimeWindows = []struct {
+	Name   string
+	Length time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+// daemonEventsLimit is how many events the daemon keeps to answer --since,
+// older ones are gone no matter how far back is asked.
+const daemonEventsLimit = 256
+
+// availability is how a container fared over a window: how many times it
+// was started again after going down, how long it was down and the share
+// of the window it was up, counted from its creation when it is younger.
+type availability struct {
+	Restarts int           `json:"restarts"`
+	Downtime time.Duration `json:"-"`
+	Seconds  float64       `json:"downtimeSeconds"`
+	Percent  float64       `json:"uptimePercent"`
+}
+
+// containerAvailability replays the start and die events of container,
+// sorted, over the window from..now. Its state when the window opens is
+// the opposite of its first event in it, or its current one without any.
+func containerAvailability(container Container, events []containerEvent, from time.Time, now time.Time) availability {
+	start := from
+	if container.CreatedAt.After(start) {
+		start = container.CreatedAt
+	}
+
+	var inWindow []containerEvent
+	for _, event := range events {
+		if t := event.time(); !t.Before(start) && !t.After(now) {
+			inWindow = append(inWindow, event)
+		}
+	}
+
+	up := container.State == "running"
+	if len(inWindow) > 0 {
+		up = inWindow[0].Action == "die"
+	}
+	// A container created in the window wasn't down before its first start.
+	created := container.CreatedAt.After(from)
+
+	var result availability
+	downSince := start
+	for _, event := range inWindow {
+		switch event.Action {
+		case "die":
+			if up {
+				up = false
+				downSince = event.time()
+			}
+		case "start":
+			if !up {
+				result.Downtime += event.time().Sub(downSince)
+				up = true
+				if !created {
+					result.Restarts++
+				}
+				created = false
+			}
+		}
+	}
+	if !up {
+		result.Downtime += now.Sub(downSince)
+	}
+
+	result.Percent = 100
+	if length := now.Sub(start); length > 0 {
+		result.Percent = 100 * (1 - float64(result.Downtime)/float64(length))
+	}
+	result.Seconds = result.Downtime.Seconds()
+	return result
+}
+
+// lifecycleEvents returns the start and die events of the containers since
+// from, by container ID, sorted, and whether the daemon's history didn't go
+// back that far.
+func lifecycleEvents(from time.Time, now time.Time) (map[string][]containerEvent, bool, error) {
+	events, err := pastEvents([]string{"type=container", "event=start", "event=die"}, from, now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	byContainer := map[string][]containerEvent{}
+	for _, event := range events {
+		byContainer[event.Actor.ID] = append(byContainer[event.Actor.ID], event)
+	}
+	for _, list := range byContainer {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].TimeNano < list[j].TimeNano })
+	}
+	return byContainer, len(events) >= daemonEventsLimit, nil
+}
+
+// uptimeRow is an entry of `whale uptime --json`.
+type uptimeRow struct {
+	Name    string                  `json:"name"`
+	Windows map[string]availability `json:"windows"`
+}
+
+// formatDowntime is a downtime to the second, "-" for none.
+func formatDowntime(d time.Duration) string {
+	if d < time.Second {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+// uptimeCommand implements `whale uptime [--json] [NAME...]`: for each
+// container, the restarts, downtime and share of time up over the last
+// 24 hours and 7 days, from the daemon's events.
+func uptimeCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	requireAction(cfg, "uptime")
+
+	asJSON := false
+	var queries []string
+	for _, arg := range args {
+		if arg == "--json" {
+			asJSON = true
+		} else {
+			queries = append(queries, arg)
+		}
+	}
+	if len(queries) > 0 {
+		var selected []Container
+		for _, query := range queries {
+			container, err := resolveContainer(cfg, containers, query)
+			if err != nil {
+				println(err.Error())
+				os.Exit(1)
+			}
+			selected = append(selected, container)
+		}
+		containers = selected
+	}
+
+	now := time.Now()
+	longest := uptimeWindows[len(uptimeWindows)-1].Length
+	events, truncated, err := lifecycleEvents(now.Add(-longest), now)
+	if err != nil {
+		println(tr("error.getEvents"), err)
+		os.Exit(1)
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	var rows []uptimeRow
+	for _, container := range containers {
+		row := uptimeRow{Name: container.Name, Windows: map[string]availability{}}
+		for _, window := range uptimeWindows {
+			row.Windows[window.Name] = containerAvailability(container, events[container.ID], now.Add(-window.Length), now)
+		}
+		rows = append(rows, row)
+	}
+
+	if asJSON {
+		data, _ := json.MarshalIndent(rows, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	header := []string{tr("column.name")}
+	for _, window := range uptimeWindows {
+		header = append(header, tr("uptime.uptime", window.Name), tr("uptime.restarts", window.Name), tr("uptime.downtime", window.Name))
+	}
+	table := [][]string{header}
+	for _, row := range rows {
+		line := []string{row.Name}
+		for _, window := range uptimeWindows {
+			result := row.Windows[window.Name]
+			line = append(line, strconv.FormatFloat(result.Percent, 'f', 2, 64)+"%", strconv.Itoa(result.Restarts), formatDowntime(result.Downtime))
+		}
+		table = append(table, line)
+	}
+	for _, line := range alignColumns(table) {
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+
+	if truncated {
+		fmt.Println()
+		fmt.Println(renderColor(tr("uptime.truncated", daemonEventsLimit), "33"))
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// backupVolume tars the volume from a throwaway container to a local file.
+// The archive is streamed through docker rather than bind-mounting a host
+// directory, so it lands on this machine even on ssh:// hosts.
+func backupVolume(cfg *Config, volume Volume) error {
+	defaultPath := fmt.Sprintf("%s-%s.tar.gz", volume.Name, time.Now().Format("20060102-150405"))
+	fields, ok, err := fillForm(cfg, tr("backup.title", volume.Name), []formField{
+		{Label: tr("backup.file"), Value: defaultPath, Hint: tr("backup.fileHint")},
+	})
+	if err != nil {
+		return err
+	}
+	path := strings.TrimSpace(fields[0].Value)
+	if !ok || path == "" {
+		return errCancelled
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	cmd := exec.Command("docker", "run", "--rm", "-v", volume.Name+":/volume:ro", cfg.Volumes.HelperImage, "tar", "czf", "-", "-C", "/volume", ".")
+	cmd.Stdout = file
+	err = runPipedWithSpinner(cmd)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	absolute, _ := filepath.Abs(path)
+	fmt.Println(tr("backup.done", volume.Name, absolute))
+	return nil
+}
+
+// browseVolume mounts the volume read-only in a throwaway container, left
+// sleeping while the file browser runs commands in it.
+func browseVolume(cfg *Config, volume Volume) error {
+	output, err := runWithSpinner(exec.Command("docker", "run", "-d", "--rm", "-v", volume.Name+":/volume:ro", cfg.Volumes.HelperImage, "sleep", "86400"))
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	// The ID comes last, after the pull progress when the image was missing.
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	id := lines[len(lines)-1]
+	defer exec.Command("docker", "rm", "-f", id).Run()
+
+	return browseFiles(cfg, tr("files.volumeTitle", volume.Name), id, "/volume")
+}
+
+// restoreVolume extracts a backup into the volume, emptying it first when
+// asked so that files absent from the backup don't linger.
+func restoreVolume(cfg *Config, volume Volume) error {
+	fields, ok, err := fillForm(cfg, tr("restore.title", volume.Name), []formField{
+		{Label: tr("backup.file"), Hint: tr("restore.fileHint")},
+		{Label: tr("restore.wipe"), Value: yesNo(false), Hint: tr("restore.wipeHint")},
+	})
+	if err != nil {
+		return err
+	}
+	path := strings.TrimSpace(fields[0].Value)
+	if !ok || path == "" {
+		return errCancelled
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	script := "tar xzf - -C /volume"
+	if isYes(fields[1].Value) {
+		if !confirmBulk(tr("restore.wipeSummary", volume.Name), confirmWord, false) {
+			return errCancelled
+		}
+		script = "find /volume -mindepth 1 -delete && " + script
+	}
+
+	cmd := exec.Command("docker", "run", "--rm", "-i", "-v", volume.Name+":/volume", cfg.Volumes.HelperImage, "sh", "-c", script)
+	cmd.Stdin = file
+	return runPipedWithSpinner(cmd)
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+type Volume struct {
+	Name       string
+	Driver     string
+	Scope      string
+	Mountpoint string
+	Labels     map[string]string
+
+	// Anonymous volumes are the ones docker names itself, for VOLUME lines
+	// of images and -v without a name.
+	Anonymous bool
+}
+
+// volumeLine mirrors one line of `docker volume ls --format '{{json .}}'`.
+type volumeLine struct {
+	Name       string `json:"Name"`
+	Driver     string `json:"Driver"`
+	Scope      string `json:"Scope"`
+	Mountpoint string `json:"Mountpoint"`
+	Labels     string `json:"Labels"`
+}
+
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// isAnonymousVolume recognizes anonymous volumes by the label recent daemons
+// set, or by their name, 64 hexadecimal characters, on older ones.
+func isAnonymousVolume(v volumeLine) bool {
+	if strings.Contains(v.Labels, anonymousVolumeLabel) {
+		return true
+	}
+	if len(v.Name) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(v.Name)
+	return err == nil
+}
+
+func getVolumes() ([]Volume, error) {
+	output, err := dockerRead("volume", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []Volume
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var v volumeLine
+		err := json.Unmarshal([]byte(line), &v)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing volume: %v", err)
+		}
+
+		volumes = append(volumes, Volume{Name: v.Name, Driver: v.Driver, Scope: v.Scope, Mountpoint: v.Mountpoint, Labels: whale.ParseLabels(v.Labels), Anonymous: isAnonymousVolume(v)})
+	}
+
+	markFetched()
+	return volumes, nil
+}
+
+// volumeUsage is what the volumes tab shows to tell whether a volume can go:
+// the containers, running or not, that mount it and the space it takes.
+type volumeUsage struct {
+	Containers []string
+	Size       string
+}
+
+// getVolumeUsage maps volume names to their usage. Sizes come from
+// `docker system df -v`, which some daemons can't compute; they are left
+// empty then rather than failing the tab.
+func getVolumeUsage() (map[string]*volumeUsage, error) {
+	output, err := dockerRead("container", "ls", "-a", "--no-trunc", "--format", "{{.Names}}\t{{.State}}\t{{.Mounts}}")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[string]*volumeUsage{}
+	get := func(name string) *volumeUsage {
+		if usage[name] == nil {
+			usage[name] = &volumeUsage{}
+		}
+		return usage[name]
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || fields[2] == "" {
+			continue
+		}
+		container := fields[0]
+		if fields[1] != "running" {
+			container = tr("volumes.stopped", container)
+		}
+		for _, mount := range strings.Split(fields[2], ",") {
+			// Bind mounts show up as their host path.
+			if !strings.HasPrefix(mount, "/") {
+				get(mount).Containers = append(get(mount).Containers, container)
+			}
+		}
+	}
+
+	output, err = dockerRead("system", "df", "-v", "--format", "{{json .}}")
+	if err != nil {
+		return usage, nil
+	}
+	var df struct {
+		Volumes []struct {
+			Name string `json:"Name"`
+			Size string `json:"Size"`
+		} `json:"Volumes"`
+	}
+	if json.Unmarshal(output, &df) == nil {
+		for _, volume := range df.Volumes {
+			get(volume.Name).Size = volume.Size
+		}
+	}
+	return usage, nil
+}
+
+// volumesTab is the volumes tab of the dashboard.
+func volumesTab(notice string) (string, string) {
+	cfg := currentConfig()
+	volumes, err := getVolumes()
+	if err != nil {
+		return "containers", fmt.Sprintf("✗ %s: %v", tr("tabs.volumes"), err)
+	}
+
+	usage, err := getVolumeUsage()
+	if err != nil {
+		return "containers", fmt.Sprintf("✗ %s: %v", tr("tabs.volumes"), err)
+	}
+
+	table := [][]string{{tr("column.name"), tr("column.driver"), tr("column.size"), tr("column.usedBy")}}
+	for _, volume := range volumes {
+		size, usedBy := "", tr("volumes.unused")
+		if volume.Anonymous {
+			usedBy = tr("volumes.orphaned")
+		}
+		if u := usage[volume.Name]; u != nil {
+			size = u.Size
+			if len(u.Containers) > 0 {
+				usedBy = truncateMiddle(strings.Join(u.Containers, ", "), 50)
+			}
+		}
+		table = append(table, []string{truncateMiddle(volume.Name, 40), volume.Driver, size, usedBy})
+	}
+	rows := alignColumns(table)
+	keys := make([]string, len(volumes))
+	for i, volume := range volumes {
+		keys[i] = volume.Name
+	}
+
+	choice, next, err := chooseInTab(cfg, "volumes", tr("volumes.title"), rows[0], rows[1:], keys, notice)
+	if err != nil {
+		println(tr("error.chooseVolume"), err)
+		os.Exit(1)
+	}
+	if next != "" || choice < 0 {
+		return next, ""
+	}
+
+	volume := volumes[choice]
+	action, err := chooseResourceAction(cfg, tr("volumes.actionsTitle", volume.Name), []string{"exit", "inspectVolume", "browseVolume", "backupVolume", "restoreVolume", "removeVolume", "removeOrphanVolumes", "pruneVolumes"})
+	if err != nil {
+		println(tr("error.chooseAction"), err)
+		os.Exit(1)
+	}
+
+	name := volume.Name
+	if action == "pruneVolumes" || action == "removeOrphanVolumes" {
+		name = tr("tabs.volumes")
+	}
+	return tabOutcome("volumes", name, action, func() error {
+		return doVolumeAction(cfg, action, volume)
+	})
+}
+
+func doVolumeAction(cfg *Config, action string, volume Volume) error {
+	switch action {
+	case "inspectVolume":
+		return runStream(cfg, tr("volumes.inspectTitle", volume.Name), exec.Command("docker", "volume", "inspect", volume.Name))
+	case "browseVolume":
+		return browseVolume(cfg, volume)
+	case "backupVolume":
+		return backupVolume(cfg, volume)
+	case "restoreVolume":
+		return restoreVolume(cfg, volume)
+	case "removeVolume":
+		if !confirmRemoval(volume.Name) {
+			return errCancelled
+		}
+		return runDocker("volume", "rm", volume.Name)
+	case "removeOrphanVolumes":
+		return removeOrphanVolumes(cfg)
+	case "pruneVolumes":
+		return previewAndPrune(cfg, []string{"volumes"}, false)
+	}
+
+	return nil
+}
+
+// removeOrphanVolumes offers to remove the anonymous volumes no container
+// mounts anymore, with their age, all checked: nobody can mount them again
+// by name, so they only take disk space.
+func removeOrphanVolumes(cfg *Config) error {
+	volumes, err := getVolumes()
+	if err != nil {
+		return err
+	}
+	usage, err := getVolumeUsage()
+	if err != nil {
+		return err
+	}
+
+	var orphans []string
+	for _, volume := range volumes {
+		if volume.Anonymous && (usage[volume.Name] == nil || len(usage[volume.Name].Containers) == 0) {
+			orphans = append(orphans, volume.Name)
+		}
+	}
+	if len(orphans) == 0 {
+		return fmt.Errorf("%s", tr("volumes.noOrphans"))
+	}
+
+	created := map[string]time.Time{}
+	output, err := dockerRead(append([]string{"volume", "inspect", "--format", "{{.Name}}\t{{.CreatedAt}}"}, orphans...)...)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, at, _ := strings.Cut(line, "\t")
+		if t, ok := parseDockerTime(cfg, at); ok {
+			created[name] = t
+		}
+	}
+	// Oldest first, the likeliest to be forgotten.
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return created[orphans[i]].Before(created[orphans[j]])
+	})
+
+	table := [][]string{{tr("column.name"), tr("column.created"), tr("column.size")}}
+	checked := make([]bool, len(orphans))
+	for i, name := range orphans {
+		age := ""
+		if at, ok := created[name]; ok {
+			age = tr("volumes.age", int(time.Since(at).Hours()/24))
+		}
+		size := ""
+		if u := usage[name]; u != nil {
+			size = u.Size
+		}
+		table = append(table, []string{shortID(name), age, size})
+		checked[i] = true
+	}
+	rows := alignColumns(table)
+
+	chosen, err := chooseMany(cfg, tr("volumes.orphansTitle", len(orphans)), rows[0], rows[1:], checked)
+	if err != nil {
+		return err
+	}
+	if len(chosen) == 0 {
+		return errCancelled
+	}
+
+	var failed []string
+	for _, i := range chosen {
+		if runDocker("volume", "rm", orphans[i]) != nil {
+			failed = append(failed, shortID(orphans[i]))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", tr("volumes.orphansFailed", len(failed), len(chosen), strings.Join(failed, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// exitTimeout is the exit code of `whale wait` when the timeout expires,
+// the same as timeout(1).
+const exitTimeout = 124
+
+var errWaitTimeout = errors.New("timed out")
+
+// waitState is the subset of State needed to tell whether a container is
+// ready.
+type waitState struct {
+	Status   string `json:"Status"`
+	ExitCode int    `json:"ExitCode"`
+	Health   *struct {
+		Status string `json:"Status"`
+	} `json:"Health"`
+}
+
+// waitReady blocks until the container is healthy, or merely running when
+// it has no healthcheck. It fails as soon as the container stops, and with
+// errWaitTimeout once timeout expires.
+func waitReady(container Container, timeout time.Duration) error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go spin(tr("wait.waiting", container.Name), done, stopped)
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		output, err := dockerRead("container", "inspect", "--format", "{{json .State}}", container.ID)
+		if err != nil {
+			return err
+		}
+
+		var state waitState
+		err = json.Unmarshal(output, &state)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case state.Status == "exited" || state.Status == "dead":
+			return fmt.Errorf("%s", tr("wait.exited", state.ExitCode))
+		case state.Status == "running" && (state.Health == nil || state.Health.Status == "healthy"):
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errWaitTimeout
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// parseTimeout accepts Go durations ("90s", "2m") or plain seconds.
+func parseTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// waitCommand implements `whale wait <name>... [--timeout D]`, exiting 0
+// once every container is ready, 1 when one stops and 124 on timeout.
+func waitCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	timeout := time.Duration(max(cfg.Wait.Timeout, 1)) * time.Second
+	var queries []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--timeout", "-t":
+			if i+1 >= len(args) {
+				println(tr("cli.missingValue", args[i]))
+				os.Exit(1)
+			}
+			i++
+			value, err := parseTimeout(args[i])
+			if err != nil || value <= 0 {
+				println(tr("cli.invalidValue", args[i-1], args[i]))
+				os.Exit(1)
+			}
+			timeout = value
+		default:
+			queries = append(queries, args[i])
+		}
+	}
+
+	if len(queries) == 0 {
+		println(tr("wait.usage"))
+		os.Exit(1)
+	}
+
+	requireAction(cfg, "wait")
+
+	deadline := time.Now().Add(timeout)
+	for _, query := range queries {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+
+		err = waitReady(container, time.Until(deadline))
+		if errors.Is(err, errWaitTimeout) {
+			fmt.Printf("✗ %s: %s\n", container.Name, tr("wait.timeout", timeout))
+			os.Exit(exitTimeout)
+		}
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", container.Name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ %s: %s\n", container.Name, tr("wait.ready"))
+	}
+}
+
+// waitAction is the "Wait until healthy" menu entry.
+func waitAction(cfg *Config, container Container) error {
+	timeout := time.Duration(max(cfg.Wait.Timeout, 1)) * time.Second
+	err := waitReady(container, timeout)
+	if errors.Is(err, errWaitTimeout) {
+		return fmt.Errorf("%s", tr("wait.timeout", timeout))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s: %s\n", container.Name, tr("wait.ready"))
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abroudoux/whale/pkg/whale"
+)
+
+// Webhook is where `whale watch` posts what happens to watched containers.
+// Kind is "slack", "discord" or "generic", guessed from the URL when empty;
+// Events limits the notifications to some of "die", "unhealthy" and "oom".
+type Webhook struct {
+	URL    string   `json:"url"`
+	Kind   string   `json:"kind"`
+	Events []string `json:"events"`
+}
+
+func (webhook Webhook) kind() string {
+	switch {
+	case webhook.Kind != "":
+		return webhook.Kind
+	case strings.Contains(webhook.URL, "hooks.slack.com"):
+		return "slack"
+	case strings.Contains(webhook.URL, "discord.com/api/webhooks"), strings.Contains(webhook.URL, "discordapp.com/api/webhooks"):
+		return "discord"
+	}
+	return "generic"
+}
+
+func (webhook Webhook) wants(event string) bool {
+	return len(webhook.Events) == 0 || containsString(webhook.Events, event)
+}
+
+// watchEvent is what the webhooks receive, as is for generic ones.
+type watchEvent struct {
+	Event     string    `json:"event"`
+	Container string    `json:"container"`
+	ID        string    `json:"id"`
+	Image     string    `json:"image"`
+	ExitCode  *int      `json:"exitCode,omitempty"`
+	Host      string    `json:"host,omitempty"`
+	Time      time.Time `json:"time"`
+	Message   string    `json:"message"`
+}
+
+// stopGrace is how long after a kill or stop a container dying is expected
+// rather than worth a notification.
+const stopGrace = 30 * time.Second
+
+// watchCommand implements `whale watch [names...]`: follows the daemon
+// events and reports watched containers that die with an error, become
+// unhealthy or are OOM-killed, posting them to the configured webhooks.
+func watchCommand(containers []Container, args []string) {
+	cfg := currentConfig()
+	requireAction(cfg, "watch")
+	webhooks := cfg.Webhooks
+
+	watched := map[string]bool{}
+	for _, query := range args {
+		container, err := resolveContainer(cfg, containers, query)
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		// By name, so that recreated containers stay watched.
+		watched[container.Name] = true
+	}
+
+	events, errs := daemon.Watch(context.Background(), whale.WatchOptions{Filters: []string{"type=container"}})
+
+	if len(watched) == 0 {
+		fmt.Println(tr("watch.all", len(webhooks)))
+	} else {
+		fmt.Println(tr("watch.some", len(watched), len(webhooks)))
+	}
+	if cfg.GC.Enabled && isActionAllowed(cfg, "gc") {
+		fmt.Println(tr("watch.gc", max(cfg.GC.ExitedDays, 1), max(cfg.GC.IntervalMinutes, 1)))
+		go collectGarbage(cfg)
+	}
+
+	stopped := map[string]time.Time{}
+	for streamed := range events {
+		event := fromWhaleEvent(streamed)
+		if len(watched) > 0 && !watched[event.Actor.Attributes["name"]] {
+			continue
+		}
+		if event.Action == "start" {
+			recordStart(event.Actor.ID)
+		}
+
+		notification, ok := toWatchEvent(event, stopped)
+		if !ok {
+			continue
+		}
+
+		fmt.Printf("%s  %s\n", notification.Time.Local().Format("15:04:05"), renderColor(notification.Message, "31"))
+		for _, webhook := range webhooks {
+			if !webhook.wants(notification.Event) {
+				continue
+			}
+			err := postWebhook(webhook, notification)
+			if err != nil {
+				warn(tr("watch.webhookFailed", webhook.URL, err))
+			}
+		}
+	}
+
+	if err := streamError(errs); err != nil {
+		println(tr("error.watch"), err.Error())
+		os.Exit(1)
+	}
+}
+
+// toWatchEvent turns the daemon events worth a notification into one.
+// Kills and stops are recorded in stopped so that the die that follows them
+// is not reported.
+func toWatchEvent(event containerEvent, stopped map[string]time.Time) (watchEvent, bool) {
+	attributes := event.Actor.Attributes
+	notification := watchEvent{
+		Container: attributes["name"],
+		ID:        shortID(event.Actor.ID),
+		Image:     attributes["image"],
+		Host:      activeHost,
+		Time:      event.time(),
+	}
+
+	switch {
+	case event.Action == "kill", event.Action == "stop":
+		stopped[event.Actor.ID] = notification.Time
+		return watchEvent{}, false
+	case event.Action == "die":
+		at, wasStopped := stopped[event.Actor.ID]
+		delete(stopped, event.Actor.ID)
+		code, err := strconv.Atoi(attributes["exitCode"])
+		if err != nil || code == 0 || wasStopped && notification.Time.Sub(at) < stopGrace {
+			return watchEvent{}, false
+		}
+		notification.Event = "die"
+		notification.ExitCode = &code
+		notification.Message = tr("watch.died", notification.Container, code)
+	case event.Action == "oom":
+		notification.Event = "oom"
+		notification.Message = tr("watch.oom", notification.Container)
+	case strings.HasPrefix(event.Action, "health_status") && strings.HasSuffix(event.Action, "unhealthy"):
+		notification.Event = "unhealthy"
+		notification.Message = tr("watch.unhealthy", notification.Container)
+	default:
+		return watchEvent{}, false
+	}
+
+	if activeHost != "" {
+		notification.Message += " (" + activeHost + ")"
+	}
+	return notification, true
+}
+
+func postWebhook(webhook Webhook, notification watchEvent) error {
+	var payload any = notification
+	switch webhook.kind() {
+	case "slack":
+		payload = map[string]string{"text": notification.Message}
+	case "discord":
+		payload = map[string]string{"content": notification.Message}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Post(webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", webhook.URL, response.Status)
+	}
+	return nil
+}
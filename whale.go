@@ -1,398 +1,293 @@
 package main
 
 import (
-	_ "embed"
-	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+	"runtime"
 	"strings"
-
-	tea "github.com/charmbracelet/bubbletea"
 )
 
-//go:embed config.json
-var configFile string
-var config Config
-
-type Config struct {
-	Ui struct {
-		CursorColor string `json:"cursorColor"`
-		BranchColor string `json:"branchColor"`
-		ContainerSelectedColor string `json:"containerSelectedColor"`
-		ActionSelectedColor string `json:"actionSelectedColor"`
-	} `json:"Ui"`
-}
-
 func main() {
-	err := loadConfig()
+	defer recoverTerminal()
+	handleSignals()
+
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
+	useConfig(cfg)
 
-	if !isDockerInstalled() {
-		println("Docker is not installed")
-		os.Exit(1)
-	}
-
-	if !isDockerRunning() {
-		println("Docker is not running")
-		os.Exit(1)
-	}
-
-	containers, err := getContainers()
+	loadLocale(cfg)
+	err = parseGlobalFlags()
 	if err != nil {
-		println("Error getting containers")
+		println(err.Error())
 		os.Exit(1)
 	}
-
-	if len(os.Args) > 1 {
-		flagMode(containers)
+	configureDockerHost(cfg)
+	cfg = applyHostProfile(cfg)
+	useConfig(cfg)
+	loadPalette(cfg)
+	connectEngine(cfg)
+	connectDocker(cfg)
+
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		doctorCommand()
 		os.Exit(0)
 	}
-
-	containerSelected, err := chooseContainer(containers)
-	if err != nil {
-		println("Error choosing container", err)
-		os.Exit(1)
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		configCommand(os.Args[2:])
+		os.Exit(0)
 	}
-
-	container := convertStringToContainer(containerSelected)
-
-	debugPrintContainerInfos(container)
-
-	actionSelected, err := chooseAction(container)
-	if err != nil {
-		println("Error choosing action", err)
-		os.Exit(1)
+	if len(os.Args) > 1 && os.Args[1] == "packs" {
+		packsCommand(os.Args[2:])
+		os.Exit(0)
 	}
-
-	err = doAction(actionSelected, container)
-	if err != nil {
-		println("Error doing action", err)
-		os.Exit(1)
+	// Each host is reached on its own, whether the active one answers or not.
+	if len(os.Args) > 1 && os.Args[1] == "ps" {
+		psCommand(os.Args[2:])
+		os.Exit(0)
 	}
-}
 
-func loadConfig() error {
-	err := json.Unmarshal([]byte(configFile), &config)
-	if err != nil {
-		return fmt.Errorf("error parsing config file: %v", err)
+	done := track("daemon ping")
+	err = pingDaemon()
+	if err != nil && negotiateAPIVersion(err) {
+		err = pingDaemon()
+		if err == nil {
+			warn(tr("api.pinned", pinnedAPIVersion))
+		}
 	}
+	done()
 
-	return nil
-}
-
-func isDockerInstalled() bool {
-	cmd := exec.Command("docker", "-v")
-	err := cmd.Run()
-	return err == nil
-}
-
-func isDockerRunning() bool {
-	cmd := exec.Command("docker", "container", "ls")
-	err := cmd.Run()
-	return err == nil
-}
-
-func flagMode(containers []string) {
-	flag := os.Args[1]
+	if err == nil && len(os.Args) > 1 && os.Args[1] == "list" {
+		listCommand(os.Args[2:])
+		os.Exit(0)
+	}
 
-	switch flag {
-	case "--run", "-r":
-		containerSelected, err := chooseContainer(containers)
+	var containers []Container
+	switch {
+	case err == nil:
+		containers, err = getContainers(cfg)
 		if err != nil {
-			println("Error choosing container")
+			println(tr("error.getContainers"), err)
 			os.Exit(1)
 		}
-
-		container := convertStringToContainer(containerSelected)
-
-		actionSelected, err := chooseAction(container)
-		if err != nil {
-			println("Error choosing action")
+		cacheContainers(containers)
+	case runtime.GOOS == "linux" && isPermissionError(err):
+		printSocketPermissionHelp(err)
+		os.Exit(1)
+	default:
+		// The list can still be browsed from the last known state while
+		// the daemon is away; everything else needs the daemon.
+		cache, ok := loadContainerCache()
+		if !ok || len(os.Args) > 1 || plainMode || !isInteractive() {
+			printDiagnostics(runDiagnostics())
 			os.Exit(1)
 		}
-
-		println("Action selected: ", actionSelected)
-	case "--help", "-h":
-		printHelpManual()
-	case "--version", "-v":
-		fmt.Println("0.0.1")
-	}
-}
-
-func printHelpManual() {
-	fmt.Println("Usage: whale [options]")
-	fmt.Printf("  %-20s %s\n", "whale [--run | -r]", "Run the program")
-	fmt.Printf("  %-20s %s\n", "whale [--help | -h]", "Show this help message")
-}
-
-func getContainers() ([]string, error) {
-	cmd := exec.Command("docker", "container", "ls", "-a")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
+		offline = true
+		cachedAt = cache.SavedAt
+		fetchedAt.Store(cachedAt.UnixNano())
+		containers = cache.Containers
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var containers []string
-
-	for i, line := range lines {
-		if i == 0 {
-			continue
-		}
-
-		containers = append(containers, line)
+	if len(os.Args) > 1 {
+		flagMode(containers)
+		os.Exit(0)
 	}
 
-	return containers, nil
-}
-
-
-type containerChoice struct {
-	containers []string
-	cursor    int
-	selectedContainer string
+	dashboard(containers, "containers", "")
 }
 
-func initialContainerModel(containers []string) containerChoice {
-	return containerChoice{
-		containers: containers,
-		cursor:    len(containers) - 1,
-		selectedContainer: "",
-	}
-}
-
-func (menu containerChoice) Init() tea.Cmd {
-	return nil
-}
-
-func (menu containerChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "ctrl+c":
-			return menu, tea.Quit
-		case "up":
-			menu.cursor--
-			if menu.cursor < 0 {
-				menu.cursor = len(menu.containers) - 1
+// parseGlobalFlags consumes the options that can be combined with any mode,
+// leaving the remaining arguments in os.Args for flagMode.
+func parseGlobalFlags() error {
+	args := []string{os.Args[0]}
+	for i := 1; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--plain":
+			plainMode = true
+		case arg == "--no-interactive":
+			noInteractive = true
+		case arg == "--read-only":
+			readOnly = true
+		case arg == "--all":
+			showIgnored = true
+		case arg == "--record":
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s", tr("cli.missingValue", arg))
 			}
-		case "down":
-			menu.cursor++
-			if menu.cursor >= len(menu.containers) {
-				menu.cursor = 0
+			i++
+			sessionPath = os.Args[i]
+		case strings.HasPrefix(arg, "--record="):
+			sessionPath = strings.TrimPrefix(arg, "--record=")
+		case arg == "--filter":
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s", tr("cli.missingValue", arg))
 			}
-		case "enter":
-			menu.selectedContainer = menu.containers[menu.cursor]
-			return menu, tea.Quit
+			i++
+			containerFilters = append(containerFilters, os.Args[i])
+		case strings.HasPrefix(arg, "--filter="):
+			containerFilters = append(containerFilters, strings.TrimPrefix(arg, "--filter="))
+		default:
+			args = append(args, arg)
 		}
 	}
-
-	return menu, nil
-}
-
-func (menu containerChoice) View() string {
-	s := "\033[H\033[2J"
-    s += "Choose a container:\n\n"
-
-	for i, container := range menu.containers {
-        cursor := " "
-
-        if menu.cursor == i {
-            cursor = renderCursor()
-            s += fmt.Sprintf("%s %s\n", cursor, renderContainerSelected(container, true))
-            // s += fmt.Sprintf("%s %s\n", cursor, container)
-        } else {
-            s += fmt.Sprintf("%s %s\n", cursor, renderContainerSelected(container, false))
-            // s += fmt.Sprintf("%s %s\n", cursor, container)
-        }
-    }
-
-    return s
-}
-
-func chooseContainer(containers []string) (string, error) {
-	containersMenu := tea.NewProgram(initialContainerModel(containers))
-	finalModel, err := containersMenu.Run()
-	if err != nil {
-		return "", err
-	}
-
-	containerMenu := finalModel.(containerChoice)
-	return containerMenu.selectedContainer, nil
-}
-
-func renderCursor() string {
-	render := fmt.Sprintf("\033[%sm>\033[0m", config.Ui.CursorColor)
-	return render
-}
-
-func renderContainerSelected(container string, isSelected bool) string {
-    if isSelected {
-		return fmt.Sprintf("\033[%sm%s\033[0m", config.Ui.ContainerSelectedColor, container)
-    }
-    return container
-}
-
-
-type actionChoice struct {
-	actions []string
-	cursor int
-	selectedAction string
-	selectedContainer Container
-}
-
-func initialActionModel(container Container) actionChoice {
-	actions := []string{
-		"Exit",
-		"Copy container ID",
-	}
-
-	return actionChoice{
-		actions: actions,
-		cursor: len(actions) - 1,
-		selectedAction: "",
-		selectedContainer: container,
-	}
-}
-
-func (menu actionChoice) Init() tea.Cmd {
+	os.Args = args
 	return nil
 }
 
-func (menu actionChoice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "ctrl+c":
-			return menu, tea.Quit
-		case "up":
-			menu.cursor--
-			if menu.cursor < 0 {
-				menu.cursor = len(menu.actions) - 1
-			}
-		case "down":
-			menu.cursor++
-			if menu.cursor >= len(menu.actions) {
-				menu.cursor = 0
-			}
-		case "enter":
-			menu.selectedAction = menu.actions[menu.cursor]
-			return menu, tea.Quit
-		}
-	}
-
-	return menu, nil
-}
-
-func (menu actionChoice) View() string {
-	s := "\033[H\033[2J"
-	s += fmt.Sprintf("Container: %s\n\n", menu.selectedContainer.Name)
-
-	for i, action := range menu.actions {
-		cursor := " "
+func flagMode(containers []Container) {
+	cfg := currentConfig()
+	flag := os.Args[1]
 
-		if menu.cursor == i {
-			cursor = renderCursor()
-			s += fmt.Sprintf("%s %s\n", cursor, renderActionSelected(action, true))
-		} else {
-			s += fmt.Sprintf("%s %s\n", cursor, renderActionSelected(action, false))
+	switch flag {
+	case "--run", "-r":
+		if len(os.Args) > 2 && isDashboardTab(os.Args[2]) {
+			dashboard(containers, os.Args[2], "")
+			break
 		}
-	}
-
-	return s
-}
 
-func renderActionSelected(action string, isSelected bool) string {
-    if isSelected {
-        return fmt.Sprintf("\033[%sm%s\033[0m", config.Ui.ActionSelectedColor, action)
-    }
-    return action
-}
-
-func chooseAction(container Container) (string, error) {
-	actionsMenu := tea.NewProgram(initialActionModel(container))
-	finalModel, err := actionsMenu.Run()
-	if err != nil {
-		return "", err
-	}
+		container, err := chooseContainer(cfg, containers)
+		if err != nil {
+			println(tr("error.chooseContainer"))
+			os.Exit(1)
+		}
 
-	actionMenu := finalModel.(actionChoice)
-	return actionMenu.selectedAction, nil
-}
+		if container.ID == "" {
+			os.Exit(0)
+		}
 
-func doAction(action string, container Container) error {
-	switch action {
-	case "Exit":
-		os.Exit(0)
-	case "Copy container ID":
-		containerID := container.ID
-		err := copyContainerId(containerID)
+		actionSelected, err := chooseAction(cfg, container)
 		if err != nil {
-			println(err)
+			println(tr("error.chooseAction"))
 			os.Exit(1)
 		}
-	}
 
-	return nil
-}
+		if actionSelected == "" {
+			os.Exit(0)
+		}
 
-func copyContainerId(container string) error {
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(container)
-	err := cmd.Run()
-	if err != nil {
-		return fmt.Errorf("error copying container ID: %v", err)
+		println(tr("action.selected", actionLabel(cfg, actionSelected)))
+	case "--compose", "-c":
+		composeMode(containers)
+	case "logs":
+		logsCommand(containers, os.Args[2:])
+	case "follow":
+		followCommand(os.Args[2:])
+	case "stats":
+		statsCommand(containers, os.Args[2:])
+	case "inspect":
+		inspectCommand(containers, os.Args[2:])
+	case "note":
+		noteCommand(containers, os.Args[2:])
+	case "wait":
+		waitCommand(containers, os.Args[2:])
+	case "watch":
+		watchCommand(containers, os.Args[2:])
+	case "serve":
+		serveCommand(os.Args[2:])
+	case "report":
+		reportCommand(containers, os.Args[2:])
+	case "resources":
+		resourcesCommand(containers)
+	case "uptime":
+		uptimeCommand(containers, os.Args[2:])
+	case "prune":
+		pruneCommand(os.Args[2:])
+	case "cleanup":
+		cleanupCommand(containers)
+	case "gc":
+		gcCommand(containers, os.Args[2:])
+	case "tags":
+		tagsCommand(os.Args[2:])
+	case "digests":
+		digestsCommand(containers)
+	case "macro":
+		macroCommand(containers, os.Args[2:])
+	case "run-file":
+		runFileCommand(os.Args[2:])
+	case "replay":
+		replayCommand(os.Args[2:])
+	case "registries":
+		registriesCommand()
+	case "init":
+		initMode()
+	case "--images", "-i":
+		imagesMode()
+	case "--help", "-h":
+		printHelpManual()
+	case "pick":
+		pickCommand(containers, os.Args[2:])
+	case "--timings":
+		timingsCommand()
+	case "--version", "-v":
+		fmt.Println("0.0.1")
+	default:
+		if isLifecycleVerb(flag) {
+			lifecycleCommand(containers, flag, os.Args[2:])
+		} else if !strings.HasPrefix(flag, "-") {
+			jumpCommand(containers, flag)
+		}
 	}
-
-	println("Container ID copied to clipboard")
-	return nil
 }
 
-type Container struct {
-	ID string
-	Image string
-	Command string
-	Created string
-	Status string
-	Ports string
-	Name string
-}
-
-func convertStringToContainer(str string) Container {
-	fields := strings.Fields(str)
-	id := fields[0]
-	image := fields[1]
-	command := fields[2]
-	created := strings.Join(fields[3:6], " ")
-
-	status := ""
-	ports := ""
-	if len(fields) > 10 {
-		status = strings.Join(fields[6:10], " ")
-	}
-
-	name := fields[len(fields)-1]
-	return Container{
-		ID:      id,
-		Image:   image,
-		Command: command,
-		Created: created,
-		Status:  status,
-		Ports:   ports,
-		Name:    name,
-	}
+func printHelpManual() {
+	fmt.Println(tr("help.usage"))
+	fmt.Printf("  %-24s %s\n", "whale [--run | -r]", tr("help.run"))
+	fmt.Printf("  %-24s %s\n", "whale -r <tab>", tr("help.runTab"))
+	fmt.Printf("  %-24s %s\n", "whale <name>", tr("help.jump"))
+	fmt.Printf("  %-24s %s\n", "whale [--images | -i]", tr("help.images"))
+	fmt.Printf("  %-24s %s\n", "whale [--compose | -c]", tr("help.compose"))
+	fmt.Printf("  %-24s %s\n", "whale init", tr("help.init"))
+	fmt.Printf("  %-24s %s\n", "whale list [--ids]", tr("help.list"))
+	fmt.Printf("  %-24s %s\n", "whale pick [--stdin]", tr("help.pick"))
+	fmt.Printf("  %-24s %s\n", "whale ps [--all-hosts]", tr("help.ps"))
+	fmt.Printf("  %-24s %s\n", "whale logs <name>", tr("help.logs"))
+	fmt.Printf("  %-24s %s\n", "whale follow [prefix]", tr("help.follow"))
+	fmt.Printf("  %-24s %s\n", "whale stats [names]", tr("help.stats"))
+	fmt.Printf("  %-24s %s\n", "whale inspect <name>", tr("help.inspect"))
+	fmt.Printf("  %-24s %s\n", "whale resources", tr("help.resources"))
+	fmt.Printf("  %-24s %s\n", "whale uptime [--json]", tr("help.uptime"))
+	fmt.Printf("  %-24s %s\n", "whale serve [--metrics]", tr("help.serve"))
+	fmt.Printf("  %-24s %s\n", "whale watch [names]", tr("help.watch"))
+	fmt.Printf("  %-24s %s\n", "whale report [--json]", tr("help.report"))
+	fmt.Printf("  %-24s %s\n", "whale wait <name>...", tr("help.wait"))
+	fmt.Printf("  %-24s %s\n", "whale note <name> [text]", tr("help.note"))
+	fmt.Printf("  %-24s %s\n", "whale <verb> <name>...", tr("help.lifecycle"))
+	fmt.Printf("  %-24s %s\n", "whale macro <m> <name>...", tr("help.macro"))
+	fmt.Printf("  %-24s %s\n", "whale run-file <file>", tr("help.runFile"))
+	fmt.Printf("  %-24s %s\n", "whale replay <file>", tr("help.replay"))
+	fmt.Printf("  %-24s %s\n", "whale prune", tr("help.prune"))
+	fmt.Printf("  %-24s %s\n", "whale cleanup", tr("help.cleanup"))
+	fmt.Printf("  %-24s %s\n", "whale gc [--apply]", tr("help.gc"))
+	fmt.Printf("  %-24s %s\n", "whale tags [--keep N]", tr("help.tags"))
+	fmt.Printf("  %-24s %s\n", "whale digests", tr("help.digests"))
+	fmt.Printf("  %-24s %s\n", "whale registries", tr("help.registries"))
+	fmt.Printf("  %-24s %s\n", "whale packs [--update]", tr("help.packs"))
+	fmt.Printf("  %-24s %s\n", "whale doctor", tr("help.doctor"))
+	fmt.Printf("  %-24s %s\n", "whale config export", tr("help.configExport"))
+	fmt.Printf("  %-24s %s\n", "whale config import <f>", tr("help.configImport"))
+	fmt.Printf("  %-24s %s\n", "whale --timings", tr("help.timings"))
+	fmt.Printf("  %-24s %s\n", "whale [--help | -h]", tr("help.help"))
+	fmt.Printf("  %-24s %s\n", "whale --plain", tr("help.plain"))
+	fmt.Printf("  %-24s %s\n", "whale --no-interactive", tr("help.noInteractive"))
+	fmt.Printf("  %-24s %s\n", "whale --filter key=value", tr("help.filter"))
+	fmt.Printf("  %-24s %s\n", "whale --read-only", tr("help.readOnly"))
+	fmt.Printf("  %-24s %s\n", "whale --all", tr("help.all"))
+	fmt.Printf("  %-24s %s\n", "whale --record <file>", tr("help.record"))
+	fmt.Println()
+	fmt.Println(tr("help.keys"))
+	fmt.Printf("  %-24s %s\n", "t", tr("help.keyStats"))
+	fmt.Printf("  %-24s %s\n", "o", tr("help.keySort"))
+	fmt.Printf("  %-24s %s\n", "c", tr("help.keyColumns"))
+	fmt.Printf("  %-24s %s\n", "f", tr("help.keyFull"))
+	fmt.Printf("  %-24s %s\n", "e E", tr("help.keyExport"))
+	fmt.Printf("  %-24s %s\n", "p", tr("help.keyDetails"))
+	fmt.Printf("  %-24s %s\n", "s r l d", tr("help.keyShortcuts"))
+	fmt.Printf("  %-24s %s\n", ".", tr("help.keyRepeat"))
+	fmt.Printf("  %-24s %s\n", "m M", tr("help.keyMacro"))
+	fmt.Printf("  %-24s %s\n", "1-4 tab shift+tab", tr("help.keyTabs"))
+	fmt.Printf("  %-24s %s\n", "F5 ctrl+r", tr("help.keyRefresh"))
+	fmt.Printf("  %-24s %s\n", "g c  g i  g v  g n", tr("help.keyChords"))
 }
-
-func debugPrintContainerInfos(container Container) {
-	println("Container id: ", container.ID)
-	println("Container image: ", container.Image)
-	println("Container command: ", container.Command)
-	println("Container created: ", container.Created)
-	println("Container status: ", container.Status)
-	println("Container ports: ", container.Ports)
-	println("Container name: ", container.Name)
-}
\ No newline at end of file
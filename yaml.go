@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// whale reads a small subset of YAML, enough for the files of `whale
+// run-file` without a dependency: nested mappings and lists by
+// indentation, "- key: value" list items, [a, b] lists, quoted strings,
+// true and false, and comments. Anything else is an error with its line.
+
+type yamlLine struct {
+	indent int
+	text   string
+	number int
+}
+
+// parseYAML returns the document as maps, lists, strings, bools and nils,
+// the shapes encoding/json produces, so that it can be decoded like JSON.
+func parseYAML(data string) (any, error) {
+	lines, err := yamlLines(data)
+	if err != nil || len(lines) == 0 {
+		return nil, err
+	}
+
+	value, rest, err := parseYAMLBlock(lines, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("line %d: unexpected indentation", rest[0].number)
+	}
+	return value, nil
+}
+
+// yamlLines drops the blank lines, comments and document markers.
+func yamlLines(data string) ([]yamlLine, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(data, "\n") {
+		text := strings.TrimRight(stripYAMLComment(raw), " \r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs can't indent YAML", i+1)
+		}
+		lines = append(lines, yamlLine{indent: len(text) - len(trimmed), text: trimmed, number: i + 1})
+	}
+	return lines, nil
+}
+
+// stripYAMLComment cuts a # comment starting the line or after a space,
+// outside quotes.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseYAMLBlock(lines []yamlLine, indent int) (any, []yamlLine, error) {
+	if isYAMLItem(lines[0].text) {
+		return parseYAMLSequence(lines, indent)
+	}
+	return parseYAMLMapping(lines, indent)
+}
+
+func isYAMLItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func parseYAMLSequence(lines []yamlLine, indent int) (any, []yamlLine, error) {
+	items := []any{}
+	for len(lines) > 0 && lines[0].indent == indent && isYAMLItem(lines[0].text) {
+		line := lines[0]
+		lines = lines[1:]
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+
+		switch _, _, isKey := yamlKey(item); {
+		case item == "":
+			if len(lines) == 0 || lines[0].indent <= indent {
+				items = append(items, nil)
+				continue
+			}
+			value, rest, err := parseYAMLBlock(lines, lines[0].indent)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, value)
+			lines = rest
+		case isKey:
+			// The first key is on the dash line, the others below it,
+			// aligned with it.
+			column := indent + len(line.text) - len(item)
+			value, rest, err := parseYAMLMapping(append([]yamlLine{{indent: column, text: item, number: line.number}}, lines...), column)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, value)
+			lines = rest
+		default:
+			value, err := yamlScalar(item, line.number)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, value)
+		}
+	}
+
+	if len(lines) > 0 && lines[0].indent > indent {
+		return nil, nil, fmt.Errorf("line %d: unexpected indentation", lines[0].number)
+	}
+	return items, lines, nil
+}
+
+func parseYAMLMapping(lines []yamlLine, indent int) (any, []yamlLine, error) {
+	mapping := map[string]any{}
+	for len(lines) > 0 && lines[0].indent == indent && !isYAMLItem(lines[0].text) {
+		line := lines[0]
+		lines = lines[1:]
+		key, value, ok := yamlKey(line.text)
+		if !ok {
+			return nil, nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+
+		if value != "" {
+			scalar, err := yamlScalar(value, line.number)
+			if err != nil {
+				return nil, nil, err
+			}
+			mapping[key] = scalar
+			continue
+		}
+
+		// A list may start at the indentation of its key.
+		if len(lines) > 0 && (lines[0].indent > indent || lines[0].indent == indent && isYAMLItem(lines[0].text)) {
+			nested, rest, err := parseYAMLBlock(lines, lines[0].indent)
+			if err != nil {
+				return nil, nil, err
+			}
+			mapping[key] = nested
+			lines = rest
+			continue
+		}
+		mapping[key] = nil
+	}
+
+	if len(lines) > 0 && lines[0].indent > indent {
+		return nil, nil, fmt.Errorf("line %d: unexpected indentation", lines[0].number)
+	}
+	return mapping, lines, nil
+}
+
+// yamlKey splits "key: value" or "key:". A colon not followed by a space,
+// as in nginx:1.27, doesn't make a key.
+func yamlKey(text string) (string, string, bool) {
+	if strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") || strings.HasPrefix(text, "[") {
+		return "", "", false
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] != ':' {
+			continue
+		}
+		if i == len(text)-1 || text[i+1] == ' ' {
+			key := strings.TrimSpace(text[:i])
+			return key, strings.TrimSpace(text[i+1:]), key != ""
+		}
+	}
+	return "", "", false
+}
+
+func yamlScalar(value string, number int) (any, error) {
+	switch {
+	case strings.HasPrefix(value, "\""):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: bad quoted string %s", number, value)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return nil, fmt.Errorf("line %d: bad quoted string %s", number, value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("line %d: unterminated list %s", number, value)
+		}
+		items := []any{}
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		if inner == "" {
+			return items, nil
+		}
+		for _, item := range strings.Split(inner, ",") {
+			scalar, err := yamlScalar(strings.TrimSpace(item), number)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, scalar)
+		}
+		return items, nil
+	case value == "true":
+		return true, nil
+	case value == "false":
+		return false, nil
+	case value == "null" || value == "~":
+		return nil, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{"empty", "# nothing\n\n---\n", nil},
+		{"scalars", "name: web\nenabled: true\nrm: false\nnone: ~\nimage: nginx:1.27\n", map[string]any{"name": "web", "enabled": true, "rm": false, "none": nil, "image": "nginx:1.27"}},
+		{"nesting", "a:\n  b:\n    c: deep\n  d: shallow\n", map[string]any{"a": map[string]any{"b": map[string]any{"c": "deep"}, "d": "shallow"}}},
+		{"lists", "images:\n  - nginx\n  - redis\nflow: [a, 'b', \"c\"]\nempty: []\n", map[string]any{"images": []any{"nginx", "redis"}, "flow": []any{"a", "b", "c"}, "empty": []any{}}},
+		{"list at key indentation", "steps:\n- action: stop\n  containers: [web]\n- action: pull\n", map[string]any{"steps": []any{map[string]any{"action": "stop", "containers": []any{"web"}}, map[string]any{"action": "pull"}}}},
+		{"nested list item", "-\n  - a\n  - b\n- c\n", []any{[]any{"a", "b"}, "c"}},
+		{"quoted scalars", "double: \"a # not a comment\\n\"\nsingle: 'it''s: here'\nkey: \"x: y\"\n", map[string]any{"double": "a # not a comment\n", "single": "it's: here", "key": "x: y"}},
+		{"comments", "# header\nname: web # trailing\nurl: http://host/#anchor\n", map[string]any{"name": "web", "url": "http://host/#anchor"}},
+		{"key without value", "a:\nb: 1\n", map[string]any{"a": nil, "b": "1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseYAML(test.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"tab indentation", "a:\n\tb: c\n", "line 2: tabs"},
+		{"not a key", "a: b\njust text\n", "line 2: expected"},
+		{"deeper without a key", "a: b\n    c: d\n", "line 2: unexpected indentation"},
+		{"item under a scalar item", "- a\n  - b\n", "line 2: unexpected indentation"},
+		{"bad double quotes", "a: \"open\n", "line 1: bad quoted string"},
+		{"bad single quotes", "a: 'open\n", "line 1: bad quoted string"},
+		{"unterminated list", "a: [b, c\n", "line 1: unterminated list"},
+		{"back out of the document", "  a: b\nc: d\n", "line 2: unexpected indentation"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseYAML(test.data)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %v, want one with %q", err, test.want)
+			}
+		})
+	}
+}